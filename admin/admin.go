@@ -0,0 +1,137 @@
+// Package admin implements the operator subcommands of the server binary.
+// They run against the configured database directly, without the HTTP API.
+package admin
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/service"
+)
+
+const usage = `Usage: app <command> [subcommand] [flags] [args]
+
+Commands:
+  serve                                     start the API server (default)
+  users list [-deleted]
+  users reset-password <username> [-password <password>]
+  users revoke-tokens <username>
+  users set-role <username> <user|admin>
+  users disable <username>
+  users enable <username>
+  users delete <username> [-hard]
+`
+
+type Admin struct {
+	UserAdminService service.UserAdminService
+	Stdin            io.Reader
+}
+
+func NewAdmin(userAdminService service.UserAdminService) *Admin {
+	return &Admin{UserAdminService: userAdminService, Stdin: os.Stdin}
+}
+
+// usageError reports a command line the commands cannot run
+type usageError string
+
+func (err usageError) Error() string {
+	return string(err)
+}
+
+// Run runs the command in args and returns the exit code
+func (admin *Admin) Run(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	if err := admin.dispatch(args, stdout); err != nil {
+		fmt.Fprintln(stderr, err)
+		var usageError usageError
+		if errors.As(err, &usageError) {
+			fmt.Fprint(stderr, usage)
+			return 2
+		}
+		return 1
+	}
+
+	return 0
+}
+
+func (admin *Admin) dispatch(args []string, stdout io.Writer) (err error) {
+	// Services report failures by panicking, the API turns these into error
+	// responses and the command line into a message
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			recoveredErr, ok := recovered.(error)
+			if !ok {
+				panic(recovered)
+			}
+			err = fmt.Errorf("%v", helper.NewErrorResponse(recoveredErr).Data)
+		}
+	}()
+
+	command, args := args[0], args[1:]
+	if command != "users" {
+		return usageError(fmt.Sprintf("unknown command %q", command))
+	}
+	if len(args) == 0 {
+		return usageError("users needs a subcommand")
+	}
+
+	ctx := helper.NewContext(context.Background())
+	name, args := args[0], args[1:]
+	switch name {
+	case "list":
+		return admin.listUsers(ctx, args, stdout)
+	case "reset-password":
+		return admin.resetPassword(ctx, args, stdout)
+	case "revoke-tokens":
+		return admin.revokeTokens(ctx, args, stdout)
+	case "set-role":
+		return admin.setRole(ctx, args, stdout)
+	case "disable":
+		return admin.disableUser(ctx, args, stdout)
+	case "enable":
+		return admin.enableUser(ctx, args, stdout)
+	case "delete":
+		return admin.deleteUser(ctx, args, stdout)
+	}
+
+	return usageError(fmt.Sprintf("unknown users subcommand %q", name))
+}
+
+// newFlagSet returns a flag set that reports errors instead of exiting
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	return flags
+}
+
+// parseFlags parses args allowing flags before and after the positional
+// arguments, and checks the number of positional arguments
+func parseFlags(flags *flag.FlagSet, args []string, positional int) ([]string, error) {
+	var values []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, usageError(fmt.Sprintf("%s: %v", flags.Name(), err))
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			break
+		}
+		values = append(values, args[0])
+		args = args[1:]
+	}
+
+	if len(values) != positional {
+		return nil, usageError(fmt.Sprintf("%s takes %d argument(s), got %d", flags.Name(), positional, len(values)))
+	}
+
+	return values, nil
+}
@@ -0,0 +1,145 @@
+package admin
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/web/user"
+)
+
+func (admin *Admin) listUsers(ctx *fiber.Ctx, args []string, stdout io.Writer) error {
+	flags := newFlagSet("users list")
+	deleted := flags.Bool("deleted", false, "include soft deleted users")
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	userResponses := admin.UserAdminService.List(ctx, *deleted)
+
+	writer := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tUSERNAME\tNAME\tROLE\tLOGGED IN\tDISABLED AT\tCREATED AT\tDELETED AT")
+	for _, userResponse := range userResponses {
+		loggedIn := "no"
+		if userResponse.TokenExp > time.Now().UnixMilli() {
+			loggedIn = "yes"
+		}
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			userResponse.ID,
+			userResponse.Username,
+			userResponse.Name,
+			userResponse.Role,
+			loggedIn,
+			formatTime(userResponse.DisabledAt),
+			userResponse.CreatedAt.Format(time.RFC3339),
+			formatTime(userResponse.DeletedAt),
+		)
+	}
+
+	return writer.Flush()
+}
+
+// formatTime formats an optional time, leaving the column empty when unset
+func formatTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.Format(time.RFC3339)
+}
+
+func (admin *Admin) resetPassword(ctx *fiber.Ctx, args []string, stdout io.Writer) error {
+	flags := newFlagSet("users reset-password")
+	password := flags.String("password", "", "new password, read from ADMIN_PASSWORD or stdin when empty")
+	values, err := parseFlags(flags, args, 1)
+	if err != nil {
+		return err
+	}
+
+	if *password == "" {
+		*password = os.Getenv("ADMIN_PASSWORD")
+	}
+	if *password == "" {
+		fmt.Fprint(os.Stderr, "New password: ")
+		line, err := bufio.NewReader(admin.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return err
+		}
+		*password = strings.TrimRight(line, "\r\n")
+	}
+
+	admin.UserAdminService.ResetPassword(ctx, &user.UserResetPasswordRequest{Username: values[0], Password: *password})
+
+	fmt.Fprintf(stdout, "password of %s reset, existing sessions revoked\n", values[0])
+	return nil
+}
+
+func (admin *Admin) revokeTokens(ctx *fiber.Ctx, args []string, stdout io.Writer) error {
+	values, err := parseFlags(newFlagSet("users revoke-tokens"), args, 1)
+	if err != nil {
+		return err
+	}
+
+	admin.UserAdminService.RevokeTokens(ctx, values[0])
+
+	fmt.Fprintf(stdout, "sessions of %s revoked\n", values[0])
+	return nil
+}
+
+func (admin *Admin) setRole(ctx *fiber.Ctx, args []string, stdout io.Writer) error {
+	values, err := parseFlags(newFlagSet("users set-role"), args, 2)
+	if err != nil {
+		return err
+	}
+
+	admin.UserAdminService.SetRole(ctx, values[0], &user.UserRoleRequest{Role: values[1]})
+
+	fmt.Fprintf(stdout, "%s is now %s\n", values[0], values[1])
+	return nil
+}
+
+func (admin *Admin) disableUser(ctx *fiber.Ctx, args []string, stdout io.Writer) error {
+	values, err := parseFlags(newFlagSet("users disable"), args, 1)
+	if err != nil {
+		return err
+	}
+
+	admin.UserAdminService.Disable(ctx, values[0])
+
+	fmt.Fprintf(stdout, "user %s disabled, existing sessions revoked\n", values[0])
+	return nil
+}
+
+func (admin *Admin) enableUser(ctx *fiber.Ctx, args []string, stdout io.Writer) error {
+	values, err := parseFlags(newFlagSet("users enable"), args, 1)
+	if err != nil {
+		return err
+	}
+
+	admin.UserAdminService.Enable(ctx, values[0])
+
+	fmt.Fprintf(stdout, "user %s enabled\n", values[0])
+	return nil
+}
+
+func (admin *Admin) deleteUser(ctx *fiber.Ctx, args []string, stdout io.Writer) error {
+	flags := newFlagSet("users delete")
+	hard := flags.Bool("hard", false, "remove the user, its contacts and addresses from the database")
+	values, err := parseFlags(flags, args, 1)
+	if err != nil {
+		return err
+	}
+
+	admin.UserAdminService.Delete(ctx, values[0], *hard)
+
+	if *hard {
+		fmt.Fprintf(stdout, "user %s and its data removed\n", values[0])
+	} else {
+		fmt.Fprintf(stdout, "user %s deleted\n", values[0])
+	}
+	return nil
+}
@@ -10,10 +10,17 @@ import (
 )
 
 type Config struct {
-	AppEnv   string
-	AppPort  string
-	Database DatabaseConfig
-	LogLevel string
+	AppEnv          string
+	AppPort         string
+	GRPCPort        string
+	Database        DatabaseConfig
+	LogLevel        string
+	OpenAPISpecPath string
+	Webhook         WebhookConfig
+	Outbox          OutboxConfig
+	Stream          StreamConfig
+	Photo           PhotoConfig
+	Blob            BlobConfig
 }
 
 type DatabaseConfig struct {
@@ -28,6 +35,53 @@ type DatabaseConfig struct {
 	ConnMaxIdleTime time.Duration
 }
 
+// WebhookConfig configures the delivery of outgoing webhooks.
+// AllowPrivateNetworks lets webhooks reach loopback, private, link-local and
+// reserved addresses, which are refused otherwise.
+type WebhookConfig struct {
+	Timeout              time.Duration
+	MaxAttempts          int
+	RetryBackoff         time.Duration
+	PollInterval         time.Duration
+	AllowPrivateNetworks bool
+}
+
+// OutboxConfig configures the dispatch of domain events to their subscribers
+type OutboxConfig struct {
+	RetryBackoff time.Duration
+	PollInterval time.Duration
+}
+
+// StreamConfig configures the live event stream
+type StreamConfig struct {
+	PollInterval      time.Duration
+	HeartbeatInterval time.Duration
+	Retention         time.Duration
+}
+
+// PhotoConfig configures contact photos
+type PhotoConfig struct {
+	MaxSize int
+	BaseURL string
+}
+
+// BlobConfig configures where blobs such as photos are stored, a local
+// directory or an S3 compatible bucket
+type BlobConfig struct {
+	Store    string
+	LocalDir string
+	S3       S3Config
+}
+
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	UsePathStyle    bool
+}
+
 var AppConfig *Config
 
 // LoadConfig loads configuration from environment variables
@@ -41,8 +95,9 @@ func LoadConfig() *Config {
 	}
 
 	config := &Config{
-		AppEnv:  helper.GetEnv("APP_ENV", "development"),
-		AppPort: helper.GetEnv("APP_PORT", "3000"),
+		AppEnv:   helper.GetEnv("APP_ENV", "development"),
+		AppPort:  helper.GetEnv("APP_PORT", "3000"),
+		GRPCPort: helper.GetEnv("GRPC_PORT", "50051"),
 		Database: DatabaseConfig{
 			Host:            helper.GetEnv("DB_HOST", "localhost"),
 			Port:            helper.GetEnv("DB_PORT", "3306"),
@@ -54,7 +109,40 @@ func LoadConfig() *Config {
 			ConnMaxLifetime: helper.GetEnvAsDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
 			ConnMaxIdleTime: helper.GetEnvAsDuration("DB_CONN_MAX_IDLE_TIME", 10*time.Minute),
 		},
-		LogLevel: helper.GetEnv("LOG_LEVEL", "info"),
+		LogLevel:        helper.GetEnv("LOG_LEVEL", "info"),
+		OpenAPISpecPath: helper.GetEnv("OPENAPI_SPEC_PATH", "./docs/apispec.yaml"),
+		Webhook: WebhookConfig{
+			Timeout:              helper.GetEnvAsDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			MaxAttempts:          helper.GetEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 8),
+			RetryBackoff:         helper.GetEnvAsDuration("WEBHOOK_RETRY_BACKOFF", 30*time.Second),
+			PollInterval:         helper.GetEnvAsDuration("WEBHOOK_POLL_INTERVAL", 5*time.Second),
+			AllowPrivateNetworks: helper.GetEnv("WEBHOOK_ALLOW_PRIVATE_NETWORKS", "false") == "true",
+		},
+		Outbox: OutboxConfig{
+			RetryBackoff: helper.GetEnvAsDuration("OUTBOX_RETRY_BACKOFF", 10*time.Second),
+			PollInterval: helper.GetEnvAsDuration("OUTBOX_POLL_INTERVAL", time.Second),
+		},
+		Stream: StreamConfig{
+			PollInterval:      helper.GetEnvAsDuration("STREAM_POLL_INTERVAL", time.Second),
+			HeartbeatInterval: helper.GetEnvAsDuration("STREAM_HEARTBEAT_INTERVAL", 15*time.Second),
+			Retention:         helper.GetEnvAsDuration("STREAM_RETENTION", 24*time.Hour),
+		},
+		Photo: PhotoConfig{
+			MaxSize: helper.GetEnvAsInt("PHOTO_MAX_SIZE", 5*1024*1024),
+			BaseURL: helper.GetEnv("PHOTO_BASE_URL", "/photos"),
+		},
+		Blob: BlobConfig{
+			Store:    helper.GetEnv("BLOB_STORE", "local"),
+			LocalDir: helper.GetEnv("BLOB_LOCAL_DIR", "./storage"),
+			S3: S3Config{
+				Endpoint:        helper.GetEnv("S3_ENDPOINT", ""),
+				Region:          helper.GetEnv("S3_REGION", "us-east-1"),
+				Bucket:          helper.GetEnv("S3_BUCKET", ""),
+				AccessKeyID:     helper.GetEnv("S3_ACCESS_KEY_ID", ""),
+				SecretAccessKey: helper.GetEnv("S3_SECRET_ACCESS_KEY", ""),
+				UsePathStyle:    helper.GetEnv("S3_USE_PATH_STYLE", "false") == "true",
+			},
+		},
 	}
 
 	AppConfig = config
@@ -7,13 +7,47 @@ import (
 	"github.com/gofiber/swagger"
 	"github.com/sorfian/go-contact-management-api/controller"
 	"github.com/sorfian/go-contact-management-api/middleware"
+	"github.com/sorfian/go-contact-management-api/model/domain"
 	"github.com/sorfian/go-contact-management-api/repository"
+	"github.com/sorfian/go-contact-management-api/service"
 	"gorm.io/gorm"
 )
 
-func Router(app *fiber.App, userController controller.UserController, contactController controller.ContactController, addressController controller.AddressController, userRepository repository.UserRepository, db *gorm.DB) {
+// RequestMethods are the HTTP methods the server accepts, fiber's and those
+// CardDAV adds
+var RequestMethods = append(append([]string{}, fiber.DefaultMethods...), "PROPFIND", "REPORT")
+
+// RouterDeps are what the routes are served with. OpenAPIMiddleware is not
+// provided by wire, the app creates it from its configuration.
+type RouterDeps struct {
+	UserController          controller.UserController
+	ContactController       controller.ContactController
+	AddressController       controller.AddressController
+	AdminController         controller.AdminController
+	AddressBookController   controller.AddressBookController
+	InvitationController    controller.InvitationController
+	OrganizationController  controller.OrganizationController
+	WebhookController       controller.WebhookController
+	EventStreamController   controller.EventStreamController
+	CollaborationController controller.CollaborationController
+	SyncController          controller.SyncController
+	CardDAVController       controller.CardDAVController
+	GraphQLController       controller.GraphQLController
+	PhotoController         controller.PhotoController
+	OrganizationService     service.OrganizationService
+	UserRepository          repository.UserRepository
+	DB                      *gorm.DB
+	OpenAPIMiddleware       *middleware.OpenAPIMiddleware `wire:"-"`
+}
+
+func Router(app *fiber.App, deps RouterDeps) {
 	// Initialize middleware
-	authMiddleware := middleware.NewAuthMiddleware(userRepository, db)
+	authMiddleware := middleware.NewAuthMiddleware(deps.UserRepository, deps.DB)
+	organizationMiddleware := middleware.NewOrganizationMiddleware(deps.OrganizationService)
+
+	// Requests are validated against the OpenAPI spec after authentication,
+	// so an unauthenticated request gets a 401 rather than the schema's errors
+	validate := deps.OpenAPIMiddleware.Validate()
 
 	// API v1 group
 	api := app.Group("/api")
@@ -21,28 +55,125 @@ func Router(app *fiber.App, userController controller.UserController, contactCon
 	// User routes
 	users := api.Group("/users")
 
-	users.Post("/register", userController.Register)
-	users.Post("/login", userController.Login)
+	users.Post("/register", validate, deps.UserController.Register)
+	users.Post("/login", validate, deps.UserController.Login)
 
-	users.Get("/current", authMiddleware.Authenticate(), userController.Get)
-	users.Patch("/current", authMiddleware.Authenticate(), userController.Update)
-	users.Delete("/logout", authMiddleware.Authenticate(), userController.Logout)
+	users.Get("/current", authMiddleware.Authenticate(), validate, deps.UserController.Get)
+	users.Patch("/current", authMiddleware.Authenticate(), validate, deps.UserController.Update)
+	users.Delete("/logout", authMiddleware.Authenticate(), validate, deps.UserController.Logout)
 
 	// Contact routes
-	contacts := api.Group("/contacts", authMiddleware.Authenticate())
-	contacts.Post("/", contactController.Create)
-	contacts.Get("/", contactController.GetAll)
-	contacts.Get("/:contactId", contactController.Get)
-	contacts.Patch("/:contactId", contactController.Update)
-	contacts.Delete("/:contactId", contactController.Delete)
+	contacts := api.Group("/contacts", authMiddleware.Authenticate(), validate, organizationMiddleware.Resolve())
+	contacts.Post("/", deps.ContactController.Create)
+	contacts.Get("/", deps.ContactController.GetAll)
+	contacts.Post("/bulk", deps.ContactController.Bulk)
+	contacts.Get("/:contactId", deps.ContactController.Get)
+	contacts.Get("/:contactId/history", deps.ContactController.GetHistory)
+	contacts.Get("/:contactId/versions", deps.ContactController.GetVersions)
+	contacts.Get("/:contactId/versions/:number", deps.ContactController.GetVersion)
+	contacts.Post("/:contactId/versions/:number/revert", deps.ContactController.Revert)
+	contacts.Patch("/:contactId", deps.ContactController.Update)
+	contacts.Put("/:contactId", deps.ContactController.Replace)
+	contacts.Delete("/:contactId", deps.ContactController.Delete)
+	contacts.Put("/:contactId/photo", deps.PhotoController.Upload)
 
 	// Address routes (nested under contacts)
 	addresses := contacts.Group("/:contactId/addresses")
-	addresses.Post("/", addressController.Create)
-	addresses.Get("/", addressController.GetAll)
-	addresses.Get("/:addressId", addressController.Get)
-	addresses.Patch("/:addressId", addressController.Update)
-	addresses.Delete("/:addressId", addressController.Delete)
+	addresses.Post("/", deps.AddressController.Create)
+	addresses.Get("/", deps.AddressController.GetAll)
+	addresses.Get("/:addressId", deps.AddressController.Get)
+	addresses.Patch("/:addressId", deps.AddressController.Update)
+	addresses.Put("/:addressId", deps.AddressController.Replace)
+	addresses.Delete("/:addressId", deps.AddressController.Delete)
+
+	// Address book routes
+	addressBooks := api.Group("/address-books", authMiddleware.Authenticate(), validate, organizationMiddleware.Resolve())
+	addressBooks.Post("/", deps.AddressBookController.Create)
+	addressBooks.Get("/", deps.AddressBookController.GetAll)
+	addressBooks.Get("/:addressBookId", deps.AddressBookController.Get)
+	addressBooks.Patch("/:addressBookId", deps.AddressBookController.Update)
+	addressBooks.Delete("/:addressBookId", deps.AddressBookController.Delete)
+	addressBooks.Get("/:addressBookId/members", deps.AddressBookController.GetMembers)
+	addressBooks.Put("/:addressBookId/members/:userId", deps.AddressBookController.UpdateMember)
+	addressBooks.Delete("/:addressBookId/members/:userId", deps.AddressBookController.RemoveMember)
+	addressBooks.Post("/:addressBookId/invitations", deps.InvitationController.Create)
+	addressBooks.Get("/:addressBookId/invitations", deps.InvitationController.GetAllByAddressBook)
+
+	// Invitation routes (received by the current user)
+	invitations := api.Group("/invitations", authMiddleware.Authenticate(), validate, organizationMiddleware.Resolve())
+	invitations.Get("/", deps.InvitationController.GetAllReceived)
+	invitations.Post("/:invitationId/accept", deps.InvitationController.Accept)
+	invitations.Post("/:invitationId/decline", deps.InvitationController.Decline)
+
+	// Organization routes. Routes naming an organization resolve it from the
+	// path instead of the X-Organization-ID header.
+	organizations := api.Group("/organizations", authMiddleware.Authenticate(), validate)
+	organizations.Post("/", deps.OrganizationController.Create)
+	organizations.Get("/", deps.OrganizationController.GetAll)
+	organizations.Get("/:organizationId", organizationMiddleware.Resolve(), deps.OrganizationController.Get)
+	organizations.Patch("/:organizationId", organizationMiddleware.Resolve(), deps.OrganizationController.Update)
+	organizations.Get("/:organizationId/members", organizationMiddleware.Resolve(), deps.OrganizationController.GetMembers)
+	organizations.Post("/:organizationId/members", organizationMiddleware.Resolve(), deps.OrganizationController.AddMember)
+	organizations.Put("/:organizationId/members/:userId", organizationMiddleware.Resolve(), deps.OrganizationController.UpdateMember)
+	organizations.Delete("/:organizationId/members/:userId", organizationMiddleware.Resolve(), deps.OrganizationController.RemoveMember)
+
+	// Webhook routes
+	webhooks := api.Group("/webhooks", authMiddleware.Authenticate(), validate, organizationMiddleware.Resolve())
+	webhooks.Post("/", deps.WebhookController.Create)
+	webhooks.Get("/", deps.WebhookController.GetAll)
+	webhooks.Get("/:webhookId", deps.WebhookController.Get)
+	webhooks.Patch("/:webhookId", deps.WebhookController.Update)
+	webhooks.Delete("/:webhookId", deps.WebhookController.Delete)
+	webhooks.Get("/:webhookId/deliveries", deps.WebhookController.GetDeliveries)
+	webhooks.Post("/:webhookId/deliveries/:deliveryId/redeliver", deps.WebhookController.Redeliver)
+
+	// Live event stream of the contact and address changes the user can see
+	events := api.Group("/events", authMiddleware.Authenticate(), validate, organizationMiddleware.Resolve())
+	events.Get("/stream", deps.EventStreamController.Stream)
+
+	// Delta sync for offline clients
+	sync := api.Group("/sync", authMiddleware.Authenticate(), validate, organizationMiddleware.Resolve())
+	sync.Get("/", deps.SyncController.Pull)
+	sync.Post("/", deps.SyncController.Push)
+
+	// Collaboration connections, browsers give the credentials as query
+	// parameters of the WebSocket handshake
+	api.Get("/collaboration", middleware.WebSocketCredentials(), authMiddleware.Authenticate(), validate, organizationMiddleware.Resolve(), deps.CollaborationController.Connect)
+
+	// CardDAV for the address books of phones and desktops. Clients find the
+	// service through the well-known URI and sign in with the username and
+	// password of the user.
+	app.All("/.well-known/carddav", deps.CardDAVController.WellKnown)
+	app.Options("/dav/*", deps.CardDAVController.Options)
+	dav := app.Group("/dav", authMiddleware.AuthenticateBasic("Contacts"))
+	dav.Add("PROPFIND", "/", deps.CardDAVController.PropfindRoot)
+	dav.Add("PROPFIND", "/principal", deps.CardDAVController.PropfindPrincipal)
+	dav.Add("PROPFIND", "/addressbooks", organizationMiddleware.Resolve(), deps.CardDAVController.PropfindHome)
+	davAddressBook := dav.Group("/addressbooks/:organizationId/:addressBookId", organizationMiddleware.Resolve())
+	davAddressBook.Add("PROPFIND", "/", deps.CardDAVController.PropfindAddressBook)
+	davAddressBook.Add("REPORT", "/", deps.CardDAVController.Report)
+	davAddressBook.Add("PROPFIND", "/:card", deps.CardDAVController.PropfindCard)
+	davAddressBook.Get("/:card", deps.CardDAVController.GetCard)
+	davAddressBook.Put("/:card", deps.CardDAVController.PutCard)
+	davAddressBook.Delete("/:card", deps.CardDAVController.DeleteCard)
+
+	// GraphQL over the same services as the REST API
+	app.Post("/graphql", authMiddleware.Authenticate(), organizationMiddleware.Resolve(), deps.GraphQLController.Execute)
+
+	// Photo thumbnails, readable by anyone with the URL. Their keys are not
+	// guessable, so the URL is what grants access, as <img> tags send no token.
+	app.Get("/photos/*", deps.PhotoController.Serve)
+
+	// Admin routes
+	admin := api.Group("/admin", authMiddleware.Authenticate(), validate, middleware.RequireRole(domain.RoleAdmin))
+	admin.Get("/users", deps.AdminController.SearchUsers)
+	admin.Get("/users/stats", deps.AdminController.Stats)
+	admin.Post("/users/:userId/disable", deps.AdminController.Disable)
+	admin.Post("/users/:userId/enable", deps.AdminController.Enable)
+	admin.Post("/users/:userId/logout", deps.AdminController.ForceLogout)
+	admin.Put("/users/:userId/role", deps.AdminController.SetRole)
+	admin.Get("/actions", deps.AdminController.ListActions)
+	admin.Get("/audit-logs", deps.AdminController.SearchAuditLogs)
 
 	// Serve OpenAPI spec file
 	app.Get("/apispec.yaml", func(c *fiber.Ctx) error {
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/controller"
+	"github.com/sorfian/go-contact-management-api/service"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var specPathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// specOperations returns "METHOD /api/path" for every operation in the spec,
+// with path templates written the way fiber declares them
+func specOperations(t *testing.T) []string {
+	file, err := os.ReadFile("../docs/apispec.yaml")
+	assert.NoError(t, err)
+
+	var spec struct {
+		Servers []struct {
+			URL string `yaml:"url"`
+		} `yaml:"servers"`
+		Paths map[string]map[string]interface{} `yaml:"paths"`
+	}
+	err = yaml.Unmarshal(file, &spec)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, spec.Servers)
+
+	serverURL, err := url.Parse(spec.Servers[0].URL)
+	assert.NoError(t, err)
+	basePath := strings.TrimSuffix(serverURL.Path, "/")
+
+	var operations []string
+	for path, item := range spec.Paths {
+		for method := range item {
+			switch method {
+			case "get", "put", "post", "delete", "options", "head", "patch", "trace":
+			default:
+				// parameters, summary and other path item fields
+				continue
+			}
+			fiberPath := specPathParam.ReplaceAllString(basePath+path, ":$1")
+			operations = append(operations, strings.ToUpper(method)+" "+fiberPath)
+		}
+	}
+
+	sort.Strings(operations)
+	return operations
+}
+
+// routerOperations returns "METHOD /api/path" for every API route registered by Router
+func routerOperations() []string {
+	app := fiber.New(fiber.Config{RequestMethods: RequestMethods})
+	Router(app, RouterDeps{
+		UserController:          controller.NewUserController(nil),
+		ContactController:       controller.NewContactController(nil),
+		AddressController:       controller.NewAddressController(nil),
+		AdminController:         controller.NewAdminController(nil),
+		AddressBookController:   controller.NewAddressBookController(nil),
+		InvitationController:    controller.NewInvitationController(nil),
+		OrganizationController:  controller.NewOrganizationController(nil),
+		WebhookController:       controller.NewWebhookController(nil),
+		EventStreamController:   controller.NewEventStreamController(nil, service.EventStreamOptions{}),
+		CollaborationController: controller.NewCollaborationController(nil, nil, service.EventStreamOptions{}),
+		SyncController:          controller.NewSyncController(nil),
+		CardDAVController:       controller.NewCardDAVController(nil),
+		GraphQLController:       controller.NewGraphQLController(nil, nil, nil),
+		PhotoController:         controller.NewPhotoController(nil),
+	})
+
+	seen := map[string]bool{}
+	var operations []string
+	for _, route := range app.GetRoutes(true) {
+		// fiber adds HEAD for every GET, the spec does not list them
+		if route.Method == fiber.MethodHead || !strings.HasPrefix(route.Path, "/api/") {
+			continue
+		}
+		operation := route.Method + " " + strings.TrimSuffix(route.Path, "/")
+		if !seen[operation] {
+			seen[operation] = true
+			operations = append(operations, operation)
+		}
+	}
+
+	sort.Strings(operations)
+	return operations
+}
+
+func TestRouterMatchesAPISpec(t *testing.T) {
+	specOps := specOperations(t)
+	routerOps := routerOperations()
+
+	for _, operation := range routerOps {
+		assert.Contains(t, specOps, operation, "route is registered but not documented in docs/apispec.yaml")
+	}
+	for _, operation := range specOps {
+		assert.Contains(t, routerOps, operation, "operation is documented in docs/apispec.yaml but not registered")
+	}
+}
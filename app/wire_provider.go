@@ -1,8 +1,13 @@
 package app
 
 import (
+	"net/http"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/google/wire"
+	"github.com/sorfian/go-contact-management-api/blob"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/service"
 	"gorm.io/gorm"
 )
 
@@ -16,8 +21,82 @@ func ProvideValidator() *validator.Validate {
 	return validator.New()
 }
 
+// ProvideWebhookOptions provides the delivery settings of outgoing webhooks.
+// Deliveries only reach public addresses unless
+// WEBHOOK_ALLOW_PRIVATE_NETWORKS is true.
+func ProvideWebhookOptions() service.WebhookOptions {
+	config := LoadConfig().Webhook
+
+	client := helper.NewPublicHTTPClient(config.Timeout)
+	if config.AllowPrivateNetworks {
+		client = &http.Client{Timeout: config.Timeout}
+	}
+
+	return service.WebhookOptions{
+		Client:               client,
+		MaxAttempts:          config.MaxAttempts,
+		RetryBackoff:         config.RetryBackoff,
+		PollInterval:         config.PollInterval,
+		AllowPrivateNetworks: config.AllowPrivateNetworks,
+	}
+}
+
+// ProvideOutboxOptions provides the settings of the domain event dispatcher
+func ProvideOutboxOptions() service.OutboxOptions {
+	config := LoadConfig().Outbox
+	return service.OutboxOptions{
+		RetryBackoff: config.RetryBackoff,
+		PollInterval: config.PollInterval,
+	}
+}
+
+// ProvideEventStreamOptions provides the settings of the live event stream
+func ProvideEventStreamOptions() service.EventStreamOptions {
+	config := LoadConfig().Stream
+	return service.EventStreamOptions{
+		PollInterval:      config.PollInterval,
+		HeartbeatInterval: config.HeartbeatInterval,
+		Retention:         config.Retention,
+	}
+}
+
+// ProvidePhotoOptions provides the settings of contact photos
+func ProvidePhotoOptions() service.PhotoOptions {
+	config := LoadConfig().Photo
+	return service.PhotoOptions{
+		MaxSize: config.MaxSize,
+		BaseURL: config.BaseURL,
+	}
+}
+
+// ProvideBlobStore provides the store of photos, the local directory unless
+// BLOB_STORE is s3
+func ProvideBlobStore() blob.BlobStore {
+	config := LoadConfig().Blob
+	switch config.Store {
+	case "local":
+		return blob.NewLocalStore(config.LocalDir)
+	case "s3":
+		return blob.NewS3Store(blob.S3Options{
+			Endpoint:        config.S3.Endpoint,
+			Region:          config.S3.Region,
+			Bucket:          config.S3.Bucket,
+			AccessKeyID:     config.S3.AccessKeyID,
+			SecretAccessKey: config.S3.SecretAccessKey,
+			UsePathStyle:    config.S3.UsePathStyle,
+		})
+	default:
+		panic("BLOB_STORE must be local or s3, not " + config.Store)
+	}
+}
+
 // Set AppSet is a Wire provider set for app dependencies
 var Set = wire.NewSet(
 	ProvideDatabase,
 	ProvideValidator,
+	ProvideWebhookOptions,
+	ProvideOutboxOptions,
+	ProvideEventStreamOptions,
+	ProvidePhotoOptions,
+	ProvideBlobStore,
 )
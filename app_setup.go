@@ -1,81 +1,45 @@
 package main
 
 import (
-	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/sorfian/go-contact-management-api/app"
-	"github.com/sorfian/go-contact-management-api/controller"
 	"github.com/sorfian/go-contact-management-api/helper"
-	"github.com/sorfian/go-contact-management-api/model/web"
-	"github.com/sorfian/go-contact-management-api/repository"
-	"gorm.io/gorm"
+	"github.com/sorfian/go-contact-management-api/middleware"
 )
 
 // setupFiberApp creates and configures the Fiber application
-func setupFiberApp(
-	userController controller.UserController,
-	contactController controller.ContactController,
-	addressController controller.AddressController,
-	userRepository repository.UserRepository,
-	db *gorm.DB,
-) *fiber.App {
+func setupFiberApp(deps app.RouterDeps) *fiber.App {
 	fiberApp := fiber.New(fiber.Config{
-		Prefork: true,
+		Prefork:        true,
+		RequestMethods: app.RequestMethods,
+		// Photos are the largest bodies the API takes
+		BodyLimit: max(fiber.DefaultBodyLimit, app.AppConfig.Photo.MaxSize),
 		ErrorHandler: func(ctx *fiber.Ctx, err error) error {
-			code := fiber.StatusInternalServerError
-			status := "Internal Server Error"
-			message := err.Error()
-
-			// Handle custom errors
-			switch e := err.(type) {
-			case *fiber.Error:
-				code = e.Code
-				status = helper.GetStatusText(e.Code)
-				message = e.Message
-			case validator.ValidationErrors:
-				code = fiber.StatusBadRequest
-				status = "Bad Request"
-				message = "Validation failed: " + e.Error()
-			case helper.NotFoundError:
-				code = fiber.StatusNotFound
-				status = "Not Found"
-				message = e.Err
-			case helper.ResourceConflictError:
-				code = fiber.StatusConflict
-				status = "Resource Conflict"
-				message = e.Err
-			case helper.BadRequestError:
-				code = fiber.StatusBadRequest
-				status = "Bad Request"
-				message = e.Err
-			case helper.UnauthorizedError:
-				code = fiber.StatusUnauthorized
-				status = "Unauthorized"
-				message = e.Err
-			}
-
-			return ctx.Status(code).JSON(web.Response{
-				Code:   code,
-				Status: status,
-				Data:   message,
-			})
+			response := helper.NewErrorResponse(err)
+			return ctx.Status(response.Code).JSON(response)
 		},
 	})
 
 	// Middleware
 	fiberApp.Use(recover.New())
+	fiberApp.Use(requestid.New())
 	fiberApp.Use(logger.New())
 	fiberApp.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowMethods: "GET,POST,PUT,PATCH,DELETE,OPTIONS",
-		AllowHeaders: "*",
+		AllowOrigins:  "*",
+		AllowMethods:  "GET,POST,PUT,PATCH,DELETE,OPTIONS,PROPFIND,REPORT",
+		AllowHeaders:  "*",
+		ExposeHeaders: "ETag,X-Request-ID",
 	}))
 
+	// Validate /api traffic against the OpenAPI spec, responses outside production only
+	deps.OpenAPIMiddleware = middleware.NewOpenAPIMiddleware(app.AppConfig.OpenAPISpecPath, app.AppConfig.AppEnv != "production")
+
 	// Setup routes
-	app.Router(fiberApp, userController, contactController, addressController, userRepository, db)
+	app.Router(fiberApp, deps)
 
 	return fiberApp
 }
@@ -0,0 +1,16 @@
+package blob
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned for a key no blob is stored under
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore stores blobs by key. Keys are slash separated paths.
+type BlobStore interface {
+	Put(ctx context.Context, key string, contentType string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,51 @@
+package blob
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStore(t *testing.T) {
+	store := NewLocalStore(t.TempDir())
+	testBlobStore(t, store)
+
+	// The blob is a file below the directory
+	assert.NoError(t, store.Put(context.Background(), "photos/1/small.jpg", "image/jpeg", []byte("jpeg")))
+	data, err := os.ReadFile(filepath.Join(store.Dir, "photos", "1", "small.jpg"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("jpeg"), data)
+}
+
+func TestLocalStoreRejectsKeysOutsideDir(t *testing.T) {
+	store := NewLocalStore(t.TempDir())
+	for _, key := range []string{"", "/etc/passwd", "../secret", "photos/../../secret", "photos//1"} {
+		assert.Error(t, store.Put(context.Background(), key, "text/plain", []byte("data")), key)
+		_, err := store.Get(context.Background(), key)
+		assert.Error(t, err, key)
+	}
+}
+
+// testBlobStore checks the behavior every BlobStore shares
+func testBlobStore(t *testing.T, store BlobStore) {
+	ctx := context.Background()
+
+	_, err := store.Get(ctx, "contacts/1/missing.jpg")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.NoError(t, store.Put(ctx, "contacts/1/photo.jpg", "image/jpeg", []byte("first")))
+	assert.NoError(t, store.Put(ctx, "contacts/1/photo.jpg", "image/jpeg", []byte("second")))
+	data, err := store.Get(ctx, "contacts/1/photo.jpg")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("second"), data)
+
+	assert.NoError(t, store.Delete(ctx, "contacts/1/photo.jpg"))
+	_, err = store.Get(ctx, "contacts/1/photo.jpg")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Deleting what is gone already is not an error
+	assert.NoError(t, store.Delete(ctx, "contacts/1/photo.jpg"))
+}
@@ -0,0 +1,81 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3Options configures an S3Store. Endpoint is left empty for AWS and set
+// for other S3-compatible services, most of which need path-style URLs.
+type S3Options struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	UsePathStyle    bool
+}
+
+// S3Store keeps blobs as objects of a bucket of Amazon S3 or a compatible
+// service
+type S3Store struct {
+	Client *s3.Client
+	Bucket string
+}
+
+func NewS3Store(options S3Options) *S3Store {
+	s3Options := s3.Options{
+		Region:       options.Region,
+		Credentials:  credentials.NewStaticCredentialsProvider(options.AccessKeyID, options.SecretAccessKey, ""),
+		UsePathStyle: options.UsePathStyle,
+	}
+	if options.Endpoint != "" {
+		s3Options.BaseEndpoint = aws.String(options.Endpoint)
+	}
+
+	return &S3Store{Client: s3.New(s3Options), Bucket: options.Bucket}
+}
+
+func (store *S3Store) Put(ctx context.Context, key string, contentType string, data []byte) error {
+	_, err := store.Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(store.Bucket),
+		Key:           aws.String(key),
+		Body:          bytes.NewReader(data),
+		ContentLength: aws.Int64(int64(len(data))),
+		ContentType:   aws.String(contentType),
+	})
+	return err
+}
+
+func (store *S3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	output, err := store.Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(store.Bucket),
+		Key:    aws.String(key),
+	})
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer output.Body.Close()
+
+	return io.ReadAll(output.Body)
+}
+
+// Delete removes the object, S3 reports no error for a key without one
+func (store *S3Store) Delete(ctx context.Context, key string) error {
+	_, err := store.Client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(store.Bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
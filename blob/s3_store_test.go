@@ -0,0 +1,47 @@
+package blob
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/johannesboyne/gofakes3"
+	"github.com/johannesboyne/gofakes3/backend/s3mem"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestS3Store returns a store of a bucket of an in-memory S3 server
+func newTestS3Store(t *testing.T) *S3Store {
+	backend := s3mem.New()
+	assert.NoError(t, backend.CreateBucket("photos"))
+
+	server := httptest.NewServer(gofakes3.New(backend).Server())
+	t.Cleanup(server.Close)
+
+	return NewS3Store(S3Options{
+		Endpoint:        server.URL,
+		Region:          "us-east-1",
+		Bucket:          "photos",
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+		UsePathStyle:    true,
+	})
+}
+
+func TestS3Store(t *testing.T) {
+	store := newTestS3Store(t)
+	testBlobStore(t, store)
+
+	// The blob is an object of the bucket with its content type
+	assert.NoError(t, store.Put(context.Background(), "contacts/1/small.jpg", "image/jpeg", []byte("jpeg")))
+	output, err := store.Client.HeadObject(context.Background(), &s3.HeadObjectInput{
+		Bucket: aws.String("photos"),
+		Key:    aws.String("contacts/1/small.jpg"),
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "image/jpeg", aws.ToString(output.ContentType))
+		assert.Equal(t, int64(4), aws.ToInt64(output.ContentLength))
+	}
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/sorfian/go-contact-management-api/model/web/address"
+)
+
+func addressesPath(contactID int64) string {
+	return contactPath(contactID) + "/addresses"
+}
+
+func addressPath(contactID int64, addressID int64) string {
+	return addressesPath(contactID) + "/" + strconv.FormatInt(addressID, 10)
+}
+
+func (client *Client) CreateAddress(ctx context.Context, contactID int64, request *address.AddressCreateRequest) (address.AddressResponse, error) {
+	addressResponse := address.AddressResponse{}
+
+	req, err := newJSONRequest(http.MethodPost, addressesPath(contactID), request)
+	if err != nil {
+		return addressResponse, err
+	}
+
+	err = client.do(ctx, req, &addressResponse)
+	return addressResponse, err
+}
+
+func (client *Client) GetAddress(ctx context.Context, contactID int64, addressID int64) (address.AddressResponse, error) {
+	addressResponse := address.AddressResponse{}
+
+	req, err := newJSONRequest(http.MethodGet, addressPath(contactID, addressID), nil)
+	if err != nil {
+		return addressResponse, err
+	}
+
+	err = client.do(ctx, req, &addressResponse)
+	return addressResponse, err
+}
+
+func (client *Client) ListAddresses(ctx context.Context, contactID int64) ([]address.AddressResponse, error) {
+	var addressResponses []address.AddressResponse
+
+	req, err := newJSONRequest(http.MethodGet, addressesPath(contactID), nil)
+	if err != nil {
+		return addressResponses, err
+	}
+
+	err = client.do(ctx, req, &addressResponses)
+	return addressResponses, err
+}
+
+// UpdateAddress sends request as a merge patch, unset members are left out and
+// kept and cleared ones are sent as null. A non zero expectedVersion is sent as
+// If-Match.
+func (client *Client) UpdateAddress(ctx context.Context, contactID int64, addressID int64, request *address.AddressUpdateRequest, expectedVersion int64) (address.AddressResponse, error) {
+	addressResponse := address.AddressResponse{}
+
+	req, err := newJSONRequest(http.MethodPatch, addressPath(contactID, addressID), request)
+	if err != nil {
+		return addressResponse, err
+	}
+	req.ifMatch = expectedVersion
+
+	err = client.do(ctx, req, &addressResponse)
+	return addressResponse, err
+}
+
+// ReplaceAddress overwrites every field of the address. A non zero
+// expectedVersion is sent as If-Match.
+func (client *Client) ReplaceAddress(ctx context.Context, contactID int64, addressID int64, request *address.AddressCreateRequest, expectedVersion int64) (address.AddressResponse, error) {
+	addressResponse := address.AddressResponse{}
+
+	req, err := newJSONRequest(http.MethodPut, addressPath(contactID, addressID), request)
+	if err != nil {
+		return addressResponse, err
+	}
+	req.ifMatch = expectedVersion
+
+	err = client.do(ctx, req, &addressResponse)
+	return addressResponse, err
+}
+
+// DeleteAddress deletes the address. A non zero expectedVersion is sent as If-Match.
+func (client *Client) DeleteAddress(ctx context.Context, contactID int64, addressID int64, expectedVersion int64) error {
+	req, err := newJSONRequest(http.MethodDelete, addressPath(contactID, addressID), nil)
+	if err != nil {
+		return err
+	}
+	req.ifMatch = expectedVersion
+
+	return client.do(ctx, req, nil)
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const mimeApplicationJSON = "application/json"
+
+// Doer sends an HTTP request, *http.Client satisfies it
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// RefreshTokenFunc is called when the API answers 401 Unauthorized. It returns
+// a new token, usually by logging in again, and the request is retried once.
+type RefreshTokenFunc func(ctx context.Context, client *Client) (string, error)
+
+// Client is a typed client for the contact management API
+type Client struct {
+	// BaseURL is the API root including the /api prefix, e.g. http://localhost:3000/api
+	BaseURL        string
+	HTTPClient     Doer
+	OnUnauthorized RefreshTokenFunc
+	// OrganizationID is sent as the X-Organization-ID header when set, the API
+	// falls back to the user's first organization otherwise
+	OrganizationID int64
+
+	mutex sync.RWMutex
+	token string
+}
+
+func NewClient(baseURL string, httpClient Doer) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: httpClient,
+	}
+}
+
+// Token returns the token sent as the bearer token of every request
+func (client *Client) Token() string {
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
+	return client.token
+}
+
+// SetToken sets the token sent as the bearer token of every request
+func (client *Client) SetToken(token string) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.token = token
+}
+
+// request describes one call, the body is kept as bytes so it can be resent
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	contentType string
+	body        []byte
+	ifMatch     int64
+	// skipRefresh is set for the login calls a RefreshTokenFunc itself makes
+	skipRefresh bool
+}
+
+func newJSONRequest(method string, path string, body interface{}) (*request, error) {
+	req := &request{method: method, path: path}
+	if body == nil {
+		return req, nil
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req.contentType = mimeApplicationJSON
+	req.body = payload
+
+	return req, nil
+}
+
+// do sends req and decodes the data of the response envelope into out, which
+// may be nil. Non 2xx answers are returned as *APIError.
+func (client *Client) do(ctx context.Context, req *request, out interface{}) error {
+	token := client.Token()
+	response, err := client.send(ctx, req, token)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode == http.StatusUnauthorized && client.OnUnauthorized != nil && !req.skipRefresh {
+		response.Body.Close()
+
+		newToken, err := client.OnUnauthorized(ctx, client)
+		if err != nil {
+			return err
+		}
+		client.SetToken(newToken)
+
+		response, err = client.send(ctx, req, newToken)
+		if err != nil {
+			return err
+		}
+	}
+	defer response.Body.Close()
+
+	return decodeResponse(response, out)
+}
+
+func (client *Client) send(ctx context.Context, req *request, token string) (*http.Response, error) {
+	endpoint := client.BaseURL + req.path
+	if len(req.query) > 0 {
+		endpoint += "?" + req.query.Encode()
+	}
+
+	var body io.Reader
+	if req.body != nil {
+		body = bytes.NewReader(req.body)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, req.method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	httpRequest.Header.Set("Accept", mimeApplicationJSON)
+	if req.contentType != "" {
+		httpRequest.Header.Set("Content-Type", req.contentType)
+	}
+	if token != "" {
+		httpRequest.Header.Set("Authorization", "Bearer "+token)
+	}
+	if req.ifMatch != 0 {
+		httpRequest.Header.Set("If-Match", strconv.Quote(strconv.FormatInt(req.ifMatch, 10)))
+	}
+	if client.OrganizationID != 0 {
+		httpRequest.Header.Set("X-Organization-ID", strconv.FormatInt(client.OrganizationID, 10))
+	}
+
+	return client.HTTPClient.Do(httpRequest)
+}
+
+// envelope is web.Response with the data left undecoded
+type envelope struct {
+	Code   int             `json:"code"`
+	Status string          `json:"status"`
+	Data   json.RawMessage `json:"data"`
+}
+
+func decodeResponse(response *http.Response, out interface{}) error {
+	payload, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	var body envelope
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &body); err != nil && response.StatusCode < 300 {
+			return err
+		}
+	}
+
+	if response.StatusCode >= 300 {
+		return newAPIError(response.StatusCode, body, payload)
+	}
+
+	if out == nil || len(body.Data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(body.Data, out)
+}
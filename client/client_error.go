@@ -0,0 +1,57 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is an error answer of the API, Code is the HTTP status code
+type APIError struct {
+	Code    int
+	Status  string
+	Message string
+}
+
+func (err *APIError) Error() string {
+	return fmt.Sprintf("%d %s: %s", err.Code, err.Status, err.Message)
+}
+
+func newAPIError(code int, body envelope, payload []byte) *APIError {
+	apiError := &APIError{Code: code, Status: body.Status}
+	if apiError.Status == "" {
+		apiError.Status = http.StatusText(code)
+	}
+
+	switch {
+	case len(body.Data) == 0:
+		apiError.Message = string(payload)
+	case json.Unmarshal(body.Data, &apiError.Message) != nil:
+		// Data is not a string, keep it as JSON
+		apiError.Message = string(body.Data)
+	}
+
+	return apiError
+}
+
+// StatusCode returns the HTTP status code of an *APIError, or 0 for other errors
+func StatusCode(err error) int {
+	var apiError *APIError
+	if errors.As(err, &apiError) {
+		return apiError.Code
+	}
+	return 0
+}
+
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+func IsUnauthorized(err error) bool {
+	return StatusCode(err) == http.StatusUnauthorized
+}
+
+func IsPreconditionFailed(err error) bool {
+	return StatusCode(err) == http.StatusPreconditionFailed
+}
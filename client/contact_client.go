@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+)
+
+func contactPath(contactID int64) string {
+	return "/contacts/" + strconv.FormatInt(contactID, 10)
+}
+
+func (client *Client) CreateContact(ctx context.Context, request *contact.ContactCreateRequest) (contact.ContactResponse, error) {
+	contactResponse := contact.ContactResponse{}
+
+	req, err := newJSONRequest(http.MethodPost, "/contacts", request)
+	if err != nil {
+		return contactResponse, err
+	}
+
+	err = client.do(ctx, req, &contactResponse)
+	return contactResponse, err
+}
+
+func (client *Client) GetContact(ctx context.Context, contactID int64) (contact.ContactResponse, error) {
+	contactResponse := contact.ContactResponse{}
+
+	req, err := newJSONRequest(http.MethodGet, contactPath(contactID), nil)
+	if err != nil {
+		return contactResponse, err
+	}
+
+	err = client.do(ctx, req, &contactResponse)
+	return contactResponse, err
+}
+
+// SearchContacts lists contacts matching params, zero Page and Size use the server defaults
+func (client *Client) SearchContacts(ctx context.Context, params contact.SearchParams) (contact.SearchResult, error) {
+	searchResult := contact.SearchResult{}
+
+	req, err := newJSONRequest(http.MethodGet, "/contacts", nil)
+	if err != nil {
+		return searchResult, err
+	}
+
+	req.query = url.Values{}
+	if params.AddressBookID != 0 {
+		req.query.Set("address_book_id", strconv.FormatInt(params.AddressBookID, 10))
+	}
+	if params.Name != "" {
+		req.query.Set("name", params.Name)
+	}
+	if params.Phone != "" {
+		req.query.Set("phone", params.Phone)
+	}
+	if params.Email != "" {
+		req.query.Set("email", params.Email)
+	}
+	if params.Page != 0 {
+		req.query.Set("page", strconv.Itoa(params.Page))
+	}
+	if params.Size != 0 {
+		req.query.Set("size", strconv.Itoa(params.Size))
+	}
+
+	err = client.do(ctx, req, &searchResult)
+	return searchResult, err
+}
+
+// UpdateContact sends request as a merge patch, unset members are left out and
+// kept and cleared ones are sent as null. A non zero expectedVersion is sent as
+// If-Match.
+func (client *Client) UpdateContact(ctx context.Context, contactID int64, request *contact.ContactUpdateRequest, expectedVersion int64) (contact.ContactResponse, error) {
+	contactResponse := contact.ContactResponse{}
+
+	req, err := newJSONRequest(http.MethodPatch, contactPath(contactID), request)
+	if err != nil {
+		return contactResponse, err
+	}
+	req.ifMatch = expectedVersion
+
+	err = client.do(ctx, req, &contactResponse)
+	return contactResponse, err
+}
+
+// ReplaceContact overwrites every field of the contact. A non zero
+// expectedVersion is sent as If-Match.
+func (client *Client) ReplaceContact(ctx context.Context, contactID int64, request *contact.ContactCreateRequest, expectedVersion int64) (contact.ContactResponse, error) {
+	contactResponse := contact.ContactResponse{}
+
+	req, err := newJSONRequest(http.MethodPut, contactPath(contactID), request)
+	if err != nil {
+		return contactResponse, err
+	}
+	req.ifMatch = expectedVersion
+
+	err = client.do(ctx, req, &contactResponse)
+	return contactResponse, err
+}
+
+// DeleteContact deletes the contact and its addresses. A non zero
+// expectedVersion is sent as If-Match.
+func (client *Client) DeleteContact(ctx context.Context, contactID int64, expectedVersion int64) error {
+	req, err := newJSONRequest(http.MethodDelete, contactPath(contactID), nil)
+	if err != nil {
+		return err
+	}
+	req.ifMatch = expectedVersion
+
+	return client.do(ctx, req, nil)
+}
+
+// BulkContacts runs several contact operations in one request, per operation
+// failures are reported in the result rather than as an error
+func (client *Client) BulkContacts(ctx context.Context, request *contact.BulkRequest) (contact.BulkResult, error) {
+	bulkResult := contact.BulkResult{}
+
+	req, err := newJSONRequest(http.MethodPost, "/contacts/bulk", request)
+	if err != nil {
+		return bulkResult, err
+	}
+
+	err = client.do(ctx, req, &bulkResult)
+	return bulkResult, err
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/user"
+)
+
+// Register creates a user and keeps the returned token for later requests
+func (client *Client) Register(ctx context.Context, request *user.UserRegisterRequest) (web.TokenResponse, error) {
+	tokenResponse := web.TokenResponse{}
+
+	req, err := newJSONRequest(http.MethodPost, "/users/register", request)
+	if err != nil {
+		return tokenResponse, err
+	}
+	req.skipRefresh = true
+	if err := client.do(ctx, req, &tokenResponse); err != nil {
+		return tokenResponse, err
+	}
+
+	client.SetToken(tokenResponse.Token)
+	return tokenResponse, nil
+}
+
+// Login authenticates a user and keeps the returned token for later requests
+func (client *Client) Login(ctx context.Context, request *user.UserLoginRequest) (web.TokenResponse, error) {
+	tokenResponse := web.TokenResponse{}
+
+	req, err := newJSONRequest(http.MethodPost, "/users/login", request)
+	if err != nil {
+		return tokenResponse, err
+	}
+	req.skipRefresh = true
+	if err := client.do(ctx, req, &tokenResponse); err != nil {
+		return tokenResponse, err
+	}
+
+	client.SetToken(tokenResponse.Token)
+	return tokenResponse, nil
+}
+
+func (client *Client) GetCurrentUser(ctx context.Context) (user.UserResponse, error) {
+	userResponse := user.UserResponse{}
+
+	req, err := newJSONRequest(http.MethodGet, "/users/current", nil)
+	if err != nil {
+		return userResponse, err
+	}
+
+	err = client.do(ctx, req, &userResponse)
+	return userResponse, err
+}
+
+// UpdateCurrentUser sends request as a merge patch, empty members are left out and kept
+func (client *Client) UpdateCurrentUser(ctx context.Context, request *user.UserUpdateRequest) (user.UserResponse, error) {
+	userResponse := user.UserResponse{}
+
+	req, err := newJSONRequest(http.MethodPatch, "/users/current", request)
+	if err != nil {
+		return userResponse, err
+	}
+
+	err = client.do(ctx, req, &userResponse)
+	return userResponse, err
+}
+
+// Logout revokes the current token and forgets it
+func (client *Client) Logout(ctx context.Context) error {
+	req, err := newJSONRequest(http.MethodDelete, "/users/logout", nil)
+	if err != nil {
+		return err
+	}
+	if err := client.do(ctx, req, nil); err != nil {
+		return err
+	}
+
+	client.SetToken("")
+	return nil
+}
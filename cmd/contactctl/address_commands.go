@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/address"
+)
+
+func (app *cli) addresses(args []string) error {
+	name, args, err := subcommand("addresses", args)
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case "list":
+		return app.listAddresses(args)
+	case "get":
+		return app.getAddress(args)
+	case "create":
+		return app.createAddress(args)
+	case "update":
+		return app.updateAddress(args)
+	case "delete":
+		return app.deleteAddress(args)
+	}
+
+	return usageError(fmt.Sprintf("unknown addresses subcommand %q", name))
+}
+
+// parseAddressIDs parses the contactId and, when given, addressId arguments
+func parseAddressIDs(values []string) (int64, int64, error) {
+	contactID, err := parseID("contactId", values[0])
+	if err != nil || len(values) == 1 {
+		return contactID, 0, err
+	}
+
+	addressID, err := parseID("addressId", values[1])
+	return contactID, addressID, err
+}
+
+func (app *cli) listAddresses(args []string) error {
+	values, err := parseFlags(newFlagSet("addresses list"), args, 1)
+	if err != nil {
+		return err
+	}
+	contactID, _, err := parseAddressIDs(values)
+	if err != nil {
+		return err
+	}
+
+	addressResponses, err := app.client.ListAddresses(app.ctx, contactID)
+	if err != nil {
+		return err
+	}
+
+	return app.print(addressTable(addressResponses, addressResponses))
+}
+
+func (app *cli) getAddress(args []string) error {
+	values, err := parseFlags(newFlagSet("addresses get"), args, 2)
+	if err != nil {
+		return err
+	}
+	contactID, addressID, err := parseAddressIDs(values)
+	if err != nil {
+		return err
+	}
+
+	addressResponse, err := app.client.GetAddress(app.ctx, contactID, addressID)
+	if err != nil {
+		return err
+	}
+
+	return app.print(addressTable([]address.AddressResponse{addressResponse}, addressResponse))
+}
+
+func (app *cli) createAddress(args []string) error {
+	flags := newFlagSet("addresses create")
+	request := address.AddressCreateRequest{}
+	flags.StringVar(&request.Street, "street", "", "street")
+	flags.StringVar(&request.City, "city", "", "city")
+	flags.StringVar(&request.Province, "province", "", "province")
+	flags.StringVar(&request.Country, "country", "", "country")
+	flags.StringVar(&request.PostalCode, "postal-code", "", "postal code")
+	values, err := parseFlags(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	contactID, _, err := parseAddressIDs(values)
+	if err != nil {
+		return err
+	}
+
+	addressResponse, err := app.client.CreateAddress(app.ctx, contactID, &request)
+	if err != nil {
+		return err
+	}
+
+	return app.print(addressTable([]address.AddressResponse{addressResponse}, addressResponse))
+}
+
+func (app *cli) updateAddress(args []string) error {
+	flags := newFlagSet("addresses update")
+	request := address.AddressUpdateRequest{}
+	patchFlags(flags, map[string]*web.PatchString{
+		"street":      &request.Street,
+		"city":        &request.City,
+		"province":    &request.Province,
+		"country":     &request.Country,
+		"postal-code": &request.PostalCode,
+	})
+	version := flags.Int64("version", 0, "expected version, the update fails if the address changed since")
+	values, err := parseFlags(flags, args, 2)
+	if err != nil {
+		return err
+	}
+	contactID, addressID, err := parseAddressIDs(values)
+	if err != nil {
+		return err
+	}
+
+	addressResponse, err := app.client.UpdateAddress(app.ctx, contactID, addressID, &request, *version)
+	if err != nil {
+		return err
+	}
+
+	return app.print(addressTable([]address.AddressResponse{addressResponse}, addressResponse))
+}
+
+func (app *cli) deleteAddress(args []string) error {
+	flags := newFlagSet("addresses delete")
+	version := flags.Int64("version", 0, "expected version, the delete fails if the address changed since")
+	values, err := parseFlags(flags, args, 2)
+	if err != nil {
+		return err
+	}
+	contactID, addressID, err := parseAddressIDs(values)
+	if err != nil {
+		return err
+	}
+
+	if err := app.client.DeleteAddress(app.ctx, contactID, addressID, *version); err != nil {
+		return err
+	}
+
+	return app.print(messageTable(fmt.Sprintf("address %d deleted", addressID)))
+}
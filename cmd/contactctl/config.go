@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+const defaultServer = "http://localhost:3000/api"
+
+// Config is saved as JSON between runs
+type Config struct {
+	Server   string `json:"server"`
+	Token    string `json:"token,omitempty"`
+	TokenExp int64  `json:"token_exp,omitempty"`
+}
+
+// DefaultConfigPath returns contactctl/config.json in the user config directory
+func DefaultConfigPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "contactctl", "config.json"), nil
+}
+
+// LoadConfig reads the config at path, a missing file gives the defaults
+func LoadConfig(path string) (*Config, error) {
+	config := &Config{Server: defaultServer}
+
+	file, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(file, config); err != nil {
+		return nil, err
+	}
+	if config.Server == "" {
+		config.Server = defaultServer
+	}
+
+	return config, nil
+}
+
+// Save writes the config readable by the current user only, it holds the token
+func (config *Config) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	file, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(file, '\n'), 0o600)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+)
+
+func (app *cli) contacts(args []string) error {
+	name, args, err := subcommand("contacts", args)
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case "list", "search":
+		return app.searchContacts(name, args)
+	case "get":
+		return app.getContact(args)
+	case "create":
+		return app.createContact(args)
+	case "update":
+		return app.updateContact(args)
+	case "delete":
+		return app.deleteContact(args)
+	}
+
+	return usageError(fmt.Sprintf("unknown contacts subcommand %q", name))
+}
+
+func parseID(name string, value string) (int64, error) {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, usageError(fmt.Sprintf("%s must be a number, got %q", name, value))
+	}
+	return id, nil
+}
+
+func (app *cli) searchContacts(name string, args []string) error {
+	flags := newFlagSet("contacts " + name)
+	params := contact.SearchParams{}
+	if name == "search" {
+		flags.StringVar(&params.Name, "name", "", "first or last name contains")
+		flags.StringVar(&params.Email, "email", "", "email contains")
+		flags.StringVar(&params.Phone, "phone", "", "phone contains")
+	}
+	flags.IntVar(&params.Page, "page", 1, "page number")
+	flags.IntVar(&params.Size, "size", 10, "contacts per page")
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	searchResult, err := app.client.SearchContacts(app.ctx, params)
+	if err != nil {
+		return err
+	}
+
+	return app.print(contactTable(searchResult.Contacts, searchResult))
+}
+
+func (app *cli) getContact(args []string) error {
+	values, err := parseFlags(newFlagSet("contacts get"), args, 1)
+	if err != nil {
+		return err
+	}
+	contactID, err := parseID("contactId", values[0])
+	if err != nil {
+		return err
+	}
+
+	contactResponse, err := app.client.GetContact(app.ctx, contactID)
+	if err != nil {
+		return err
+	}
+
+	return app.print(contactTable([]contact.ContactResponse{contactResponse}, contactResponse))
+}
+
+func (app *cli) createContact(args []string) error {
+	flags := newFlagSet("contacts create")
+	request := contact.ContactCreateRequest{}
+	flags.StringVar(&request.FirstName, "first-name", "", "first name")
+	flags.StringVar(&request.LastName, "last-name", "", "last name")
+	flags.StringVar(&request.Email, "email", "", "email")
+	flags.StringVar(&request.Phone, "phone", "", "phone")
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	contactResponse, err := app.client.CreateContact(app.ctx, &request)
+	if err != nil {
+		return err
+	}
+
+	return app.print(contactTable([]contact.ContactResponse{contactResponse}, contactResponse))
+}
+
+func (app *cli) updateContact(args []string) error {
+	flags := newFlagSet("contacts update")
+	request := contact.ContactUpdateRequest{}
+	patchFlags(flags, map[string]*web.PatchString{
+		"first-name": &request.FirstName,
+		"last-name":  &request.LastName,
+		"email":      &request.Email,
+		"phone":      &request.Phone,
+	})
+	version := flags.Int64("version", 0, "expected version, the update fails if the contact changed since")
+	values, err := parseFlags(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	contactID, err := parseID("contactId", values[0])
+	if err != nil {
+		return err
+	}
+
+	contactResponse, err := app.client.UpdateContact(app.ctx, contactID, &request, *version)
+	if err != nil {
+		return err
+	}
+
+	return app.print(contactTable([]contact.ContactResponse{contactResponse}, contactResponse))
+}
+
+func (app *cli) deleteContact(args []string) error {
+	flags := newFlagSet("contacts delete")
+	version := flags.Int64("version", 0, "expected version, the delete fails if the contact changed since")
+	values, err := parseFlags(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	contactID, err := parseID("contactId", values[0])
+	if err != nil {
+		return err
+	}
+
+	if err := app.client.DeleteContact(app.ctx, contactID, *version); err != nil {
+		return err
+	}
+
+	return app.print(messageTable(fmt.Sprintf("contact %d deleted", contactID)))
+}
@@ -0,0 +1,216 @@
+// Command contactctl manages contacts and addresses through the API.
+//
+// Usage:
+//
+//	contactctl [global flags] <command> [subcommand] [flags] [args]
+//
+// Run contactctl help for the list of commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sorfian/go-contact-management-api/client"
+	"github.com/sorfian/go-contact-management-api/model/web"
+)
+
+const usage = `Usage: contactctl [global flags] <command> [subcommand] [flags] [args]
+
+Commands:
+  login -username <name> [-password <password>]
+  logout
+  whoami
+  contacts list [-page n] [-size n]
+  contacts search [-name s] [-email s] [-phone s] [-page n] [-size n]
+  contacts get <contactId>
+  contacts create -first-name s -last-name s -email s -phone s
+  contacts update <contactId> [-first-name s] [-last-name s] [-email s] [-phone s] [-clear member]... [-version n]
+  contacts delete <contactId> [-version n]
+  addresses list <contactId>
+  addresses get <contactId> <addressId>
+  addresses create <contactId> -street s -city s -province s -country s -postal-code s
+  addresses update <contactId> <addressId> [-street s] [-city s] [-province s] [-country s] [-postal-code s] [-clear member]... [-version n]
+  addresses delete <contactId> <addressId> [-version n]
+  import <file.csv|file.json>
+  export
+
+Global flags:
+`
+
+// cli holds the state shared by every command
+type cli struct {
+	ctx        context.Context
+	client     *client.Client
+	config     *Config
+	configPath string
+	output     string
+	stdout     io.Writer
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	defaultConfigPath, err := DefaultConfigPath()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	flags := flag.NewFlagSet("contactctl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+	configPath := flags.String("config", defaultConfigPath, "config file holding the server and token")
+	server := flags.String("server", "", "API base URL, e.g. http://localhost:3000/api (saved on login)")
+	output := flags.String("o", OutputTable, "output format: table, json or csv")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 || flags.Arg(0) == "help" {
+		flags.Usage()
+		return 2
+	}
+	if !isOutputFormat(*output) {
+		fmt.Fprintf(stderr, "unknown output format %q\n", *output)
+		return 2
+	}
+
+	config, err := LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	if *server != "" {
+		config.Server = *server
+	}
+
+	apiClient := client.NewClient(config.Server, &http.Client{Timeout: 30 * time.Second})
+	apiClient.SetToken(config.Token)
+
+	app := &cli{
+		ctx:        context.Background(),
+		client:     apiClient,
+		config:     config,
+		configPath: *configPath,
+		output:     *output,
+		stdout:     stdout,
+	}
+
+	if err := app.dispatch(flags.Args()); err != nil {
+		var usageError usageError
+		if errors.As(err, &usageError) {
+			fmt.Fprintln(stderr, err)
+			fmt.Fprint(stderr, usage)
+			flags.PrintDefaults()
+			return 2
+		}
+		if client.IsUnauthorized(err) {
+			fmt.Fprintln(stderr, "not logged in or the token expired, run contactctl login")
+			return 1
+		}
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+// usageError reports a command line the commands cannot run
+type usageError string
+
+func (err usageError) Error() string {
+	return string(err)
+}
+
+func (app *cli) dispatch(args []string) error {
+	command, args := args[0], args[1:]
+
+	switch command {
+	case "login":
+		return app.login(args)
+	case "logout":
+		return app.logout(args)
+	case "whoami":
+		return app.whoami(args)
+	case "contacts":
+		return app.contacts(args)
+	case "addresses":
+		return app.addresses(args)
+	case "import":
+		return app.importContacts(args)
+	case "export":
+		return app.exportContacts(args)
+	}
+
+	return usageError(fmt.Sprintf("unknown command %q", command))
+}
+
+// subcommand splits args into the subcommand name and its arguments
+func subcommand(command string, args []string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", nil, usageError(command + " needs a subcommand")
+	}
+	return args[0], args[1:], nil
+}
+
+// newFlagSet returns a flag set that reports errors instead of exiting
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	return flags
+}
+
+// patchFlags defines a flag for each member of a merge patch, named by its
+// key, and -clear, which sends the member it names as null to clear it and may
+// be repeated. Members without a flag are left out of the patch.
+func patchFlags(flags *flag.FlagSet, members map[string]*web.PatchString) {
+	for name, member := range members {
+		flags.Func(name, strings.ReplaceAll(name, "-", " "), func(value string) error {
+			*member = web.SetString(value)
+			return nil
+		})
+	}
+	flags.Func("clear", "member to clear, may be repeated", func(name string) error {
+		member, ok := members[name]
+		if !ok {
+			return fmt.Errorf("unknown member %q", name)
+		}
+		*member = web.ClearString()
+		return nil
+	})
+}
+
+// parseFlags parses args allowing flags before and after the positional
+// arguments, and checks the number of positional arguments
+func parseFlags(flags *flag.FlagSet, args []string, positional int) ([]string, error) {
+	var values []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, usageError(fmt.Sprintf("%s: %v", flags.Name(), err))
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			break
+		}
+		values = append(values, args[0])
+		args = args[1:]
+	}
+
+	if len(values) != positional {
+		return nil, usageError(fmt.Sprintf("%s takes %d argument(s), got %d", flags.Name(), positional, len(values)))
+	}
+
+	return values, nil
+}
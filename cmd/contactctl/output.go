@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/sorfian/go-contact-management-api/model/web/address"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+)
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputCSV   = "csv"
+)
+
+func isOutputFormat(format string) bool {
+	return format == OutputTable || format == OutputJSON || format == OutputCSV
+}
+
+// table is command output, value is what the json format prints
+type table struct {
+	headers []string
+	rows    [][]string
+	value   interface{}
+}
+
+func writeTable(writer io.Writer, format string, output table) error {
+	switch format {
+	case OutputJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output.value)
+	case OutputCSV:
+		csvWriter := csv.NewWriter(writer)
+		if err := csvWriter.Write(output.headers); err != nil {
+			return err
+		}
+		if err := csvWriter.WriteAll(output.rows); err != nil {
+			return err
+		}
+		return csvWriter.Error()
+	}
+
+	tabWriter := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tabWriter, strings.ToUpper(strings.Join(output.headers, "\t")))
+	for _, row := range output.rows {
+		fmt.Fprintln(tabWriter, strings.Join(row, "\t"))
+	}
+	return tabWriter.Flush()
+}
+
+func (app *cli) print(output table) error {
+	return writeTable(app.stdout, app.output, output)
+}
+
+var contactHeaders = []string{"id", "first_name", "last_name", "email", "phone", "version"}
+
+func contactRow(contactResponse contact.ContactResponse) []string {
+	return []string{
+		strconv.FormatInt(contactResponse.ID, 10),
+		contactResponse.FirstName,
+		contactResponse.LastName,
+		contactResponse.Email,
+		contactResponse.Phone,
+		strconv.FormatInt(contactResponse.Version, 10),
+	}
+}
+
+func contactTable(contactResponses []contact.ContactResponse, value interface{}) table {
+	output := table{headers: contactHeaders, rows: [][]string{}, value: value}
+	for _, contactResponse := range contactResponses {
+		output.rows = append(output.rows, contactRow(contactResponse))
+	}
+	return output
+}
+
+var addressHeaders = []string{"id", "street", "city", "province", "country", "postal_code", "version"}
+
+func addressTable(addressResponses []address.AddressResponse, value interface{}) table {
+	output := table{headers: addressHeaders, rows: [][]string{}, value: value}
+	for _, addressResponse := range addressResponses {
+		output.rows = append(output.rows, []string{
+			strconv.FormatInt(addressResponse.ID, 10),
+			addressResponse.Street,
+			addressResponse.City,
+			addressResponse.Province,
+			addressResponse.Country,
+			addressResponse.PostalCode,
+			strconv.FormatInt(addressResponse.Version, 10),
+		})
+	}
+	return output
+}
+
+// messageTable is the output of commands that only report what they did
+func messageTable(message string) table {
+	return table{
+		headers: []string{"message"},
+		rows:    [][]string{{message}},
+		value:   map[string]string{"message": message},
+	}
+}
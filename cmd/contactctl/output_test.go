@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteTable(t *testing.T) {
+	contactResponses := []contact.ContactResponse{
+		{ID: 1, AddressBookID: 1, FirstName: "John", LastName: "Doe", Email: "john@example.com", Phone: "0812", Version: 2},
+	}
+	output := contactTable(contactResponses, contactResponses)
+
+	var buffer bytes.Buffer
+	assert.NoError(t, writeTable(&buffer, OutputCSV, output))
+	assert.Equal(t, "id,first_name,last_name,email,phone,version\n1,John,Doe,john@example.com,0812,2\n", buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, writeTable(&buffer, OutputJSON, output))
+	assert.JSONEq(t, `[{"id":1,"address_book_id":1,"first_name":"John","last_name":"Doe","email":"john@example.com","phone":"0812","version":2}]`, buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, writeTable(&buffer, OutputTable, output))
+	assert.Equal(t, "ID  FIRST_NAME  LAST_NAME  EMAIL             PHONE  VERSION\n1   John        Doe        john@example.com  0812   2\n", buffer.String())
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+)
+
+// bulkLimit is the most operations the bulk endpoint takes in one request
+const bulkLimit = 100
+
+// exportPageSize is the largest page GET /contacts serves
+const exportPageSize = 100
+
+// importContacts creates the contacts of a CSV or JSON file through the bulk
+// endpoint. Rows that fail are reported and the others are still created.
+func (app *cli) importContacts(args []string) error {
+	values, err := parseFlags(newFlagSet("import"), args, 1)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(values[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var requests []contact.ContactCreateRequest
+	switch strings.ToLower(filepath.Ext(values[0])) {
+	case ".csv":
+		requests, err = readContactsCSV(file)
+	case ".json":
+		requests, err = readContactsJSON(file)
+	default:
+		return usageError("import reads .csv or .json files")
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", values[0], err)
+	}
+
+	output := table{headers: []string{"row", "code", "status", "result"}, rows: [][]string{}}
+	var results []contact.BulkOperationResult
+	created := 0
+	for start := 0; start < len(requests); start += bulkLimit {
+		end := min(start+bulkLimit, len(requests))
+
+		bulkRequest := contact.BulkRequest{Mode: contact.BulkModeBestEffort}
+		for _, request := range requests[start:end] {
+			data, err := json.Marshal(request)
+			if err != nil {
+				return err
+			}
+			bulkRequest.Operations = append(bulkRequest.Operations, contact.BulkOperation{Operation: contact.BulkOperationCreate, Data: data})
+		}
+
+		bulkResult, err := app.client.BulkContacts(app.ctx, &bulkRequest)
+		if err != nil {
+			return err
+		}
+
+		for _, result := range bulkResult.Results {
+			result.Index += start
+			results = append(results, result)
+
+			// The created contact ID, or the error message of a failed row
+			outcome := ""
+			if data, ok := result.Data.(map[string]interface{}); ok {
+				if id, ok := data["id"].(float64); ok {
+					outcome = strconv.FormatInt(int64(id), 10)
+					created++
+				}
+			} else if message, ok := result.Data.(string); ok {
+				outcome = message
+			}
+			output.rows = append(output.rows, []string{strconv.Itoa(result.Index + 1), strconv.Itoa(result.Code), result.Status, outcome})
+		}
+	}
+	output.value = results
+
+	if err := app.print(output); err != nil {
+		return err
+	}
+	if created != len(requests) {
+		return fmt.Errorf("imported %d of %d contacts", created, len(requests))
+	}
+
+	return nil
+}
+
+// readContactsCSV reads contacts from CSV with a header row naming the columns
+// first_name, last_name, email and phone in any order
+func readContactsCSV(reader io.Reader) ([]contact.ContactCreateRequest, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+
+	headers, err := csvReader.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := map[string]int{}
+	for i, header := range headers {
+		columns[strings.ToLower(strings.TrimSpace(header))] = i
+	}
+	for _, name := range []string{"first_name", "last_name", "email", "phone"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	var requests []contact.ContactCreateRequest
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		requests = append(requests, contact.ContactCreateRequest{
+			FirstName: record[columns["first_name"]],
+			LastName:  record[columns["last_name"]],
+			Email:     record[columns["email"]],
+			Phone:     record[columns["phone"]],
+		})
+	}
+
+	return requests, nil
+}
+
+// readContactsJSON reads a JSON array of contacts, the format export -o json writes
+func readContactsJSON(reader io.Reader) ([]contact.ContactCreateRequest, error) {
+	var requests []contact.ContactCreateRequest
+	if err := json.NewDecoder(reader).Decode(&requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// exportContacts prints every contact, following the pages of GET /contacts
+func (app *cli) exportContacts(args []string) error {
+	if _, err := parseFlags(newFlagSet("export"), args, 0); err != nil {
+		return err
+	}
+
+	contactResponses := []contact.ContactResponse{}
+	params := contact.SearchParams{Page: 1, Size: exportPageSize}
+	for {
+		searchResult, err := app.client.SearchContacts(app.ctx, params)
+		if err != nil {
+			return err
+		}
+		contactResponses = append(contactResponses, searchResult.Contacts...)
+
+		if params.Page >= searchResult.Paging.TotalPage {
+			break
+		}
+		params.Page++
+	}
+
+	return app.print(contactTable(contactResponses, contactResponses))
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/address"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadContactsCSV(t *testing.T) {
+	requests, err := readContactsCSV(strings.NewReader("phone,email,first_name,last_name\n0812,john@example.com,John,Doe\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []contact.ContactCreateRequest{
+		{FirstName: "John", LastName: "Doe", Email: "john@example.com", Phone: "0812"},
+	}, requests)
+
+	_, err = readContactsCSV(strings.NewReader("first_name,last_name\nJohn,Doe\n"))
+	assert.Error(t, err)
+}
+
+func TestParseFlagsAcceptsFlagsAfterArguments(t *testing.T) {
+	flags := newFlagSet("contacts delete")
+	version := flags.Int64("version", 0, "")
+
+	values, err := parseFlags(flags, []string{"7", "-version", "3"}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"7"}, values)
+	assert.Equal(t, int64(3), *version)
+
+	_, err = parseFlags(newFlagSet("contacts get"), []string{}, 1)
+	assert.Error(t, err)
+}
+
+func TestPatchFlagsClearMembers(t *testing.T) {
+	flags := newFlagSet("addresses update")
+	request := address.AddressUpdateRequest{}
+	patchFlags(flags, map[string]*web.PatchString{
+		"street":      &request.Street,
+		"province":    &request.Province,
+		"postal-code": &request.PostalCode,
+	})
+
+	_, err := parseFlags(flags, []string{"-street", "Main Street", "-clear", "province", "-clear", "postal-code"}, 0)
+	assert.NoError(t, err)
+
+	patch, err := json.Marshal(request)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"street":"Main Street","province":null,"postal_code":null}`, string(patch))
+
+	_, err = parseFlags(flags, []string{"-clear", "city"}, 0)
+	assert.Error(t, err)
+}
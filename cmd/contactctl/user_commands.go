@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sorfian/go-contact-management-api/model/web/user"
+)
+
+func (app *cli) login(args []string) error {
+	flags := newFlagSet("login")
+	username := flags.String("username", "", "username")
+	password := flags.String("password", "", "password, read from CONTACTCTL_PASSWORD or stdin when empty")
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+	if *username == "" {
+		return usageError("login needs -username")
+	}
+
+	if *password == "" {
+		*password = os.Getenv("CONTACTCTL_PASSWORD")
+	}
+	if *password == "" {
+		fmt.Fprint(os.Stderr, "Password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return err
+		}
+		*password = strings.TrimRight(line, "\r\n")
+	}
+
+	tokenResponse, err := app.client.Login(app.ctx, &user.UserLoginRequest{Username: *username, Password: *password})
+	if err != nil {
+		return err
+	}
+
+	app.config.Token = tokenResponse.Token
+	app.config.TokenExp = tokenResponse.TokenExp
+	if err := app.config.Save(app.configPath); err != nil {
+		return err
+	}
+
+	return app.print(messageTable("logged in as " + *username + " on " + app.config.Server))
+}
+
+func (app *cli) logout(args []string) error {
+	if _, err := parseFlags(newFlagSet("logout"), args, 0); err != nil {
+		return err
+	}
+
+	if err := app.client.Logout(app.ctx); err != nil {
+		return err
+	}
+
+	app.config.Token = ""
+	app.config.TokenExp = 0
+	if err := app.config.Save(app.configPath); err != nil {
+		return err
+	}
+
+	return app.print(messageTable("logged out"))
+}
+
+func (app *cli) whoami(args []string) error {
+	if _, err := parseFlags(newFlagSet("whoami"), args, 0); err != nil {
+		return err
+	}
+
+	userResponse, err := app.client.GetCurrentUser(app.ctx)
+	if err != nil {
+		return err
+	}
+
+	return app.print(table{
+		headers: []string{"username", "name"},
+		rows:    [][]string{{userResponse.Username, userResponse.Name}},
+		value:   userResponse,
+	})
+}
@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/addressbook"
+	"github.com/sorfian/go-contact-management-api/service"
+)
+
+type AddressBookControllerImpl struct {
+	AddressBookService service.AddressBookService
+}
+
+func NewAddressBookController(addressBookService service.AddressBookService) AddressBookController {
+	return &AddressBookControllerImpl{AddressBookService: addressBookService}
+}
+
+func (controller *AddressBookControllerImpl) Create(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	request := addressbook.AddressBookCreateRequest{}
+	err := ctx.BodyParser(&request)
+	helper.PanicIfError(err)
+
+	addressBookResponse := controller.AddressBookService.Create(ctx, *user, &request)
+
+	webResponse := web.Response{
+		Code:   201,
+		Status: "Created",
+		Data:   addressBookResponse,
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(webResponse)
+}
+
+func (controller *AddressBookControllerImpl) Get(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	addressBookID, err := strconv.ParseInt(ctx.Params("addressBookId"), 10, 64)
+	helper.PanicIfError(err)
+
+	addressBookResponse := controller.AddressBookService.Get(ctx, *user, addressBookID)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   addressBookResponse,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *AddressBookControllerImpl) GetAll(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	addressBookResponses := controller.AddressBookService.GetAll(ctx, *user)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   addressBookResponses,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *AddressBookControllerImpl) Update(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	addressBookID, err := strconv.ParseInt(ctx.Params("addressBookId"), 10, 64)
+	helper.PanicIfError(err)
+
+	patch, err := helper.ReadMergePatch(ctx)
+	helper.PanicIfError(err)
+
+	addressBookResponse := controller.AddressBookService.Update(ctx, *user, addressBookID, patch)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   addressBookResponse,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *AddressBookControllerImpl) Delete(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	addressBookID, err := strconv.ParseInt(ctx.Params("addressBookId"), 10, 64)
+	helper.PanicIfError(err)
+
+	controller.AddressBookService.Delete(ctx, *user, addressBookID)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   "Address book deleted successfully",
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *AddressBookControllerImpl) GetMembers(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	addressBookID, err := strconv.ParseInt(ctx.Params("addressBookId"), 10, 64)
+	helper.PanicIfError(err)
+
+	memberResponses := controller.AddressBookService.GetMembers(ctx, *user, addressBookID)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   memberResponses,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *AddressBookControllerImpl) UpdateMember(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	addressBookID, err := strconv.ParseInt(ctx.Params("addressBookId"), 10, 64)
+	helper.PanicIfError(err)
+
+	memberUserID, err := strconv.Atoi(ctx.Params("userId"))
+	helper.PanicIfError(err)
+
+	request := addressbook.MemberUpdateRequest{}
+	err = ctx.BodyParser(&request)
+	helper.PanicIfError(err)
+
+	memberResponse := controller.AddressBookService.UpdateMember(ctx, *user, addressBookID, memberUserID, &request)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   memberResponse,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *AddressBookControllerImpl) RemoveMember(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	addressBookID, err := strconv.ParseInt(ctx.Params("addressBookId"), 10, 64)
+	helper.PanicIfError(err)
+
+	memberUserID, err := strconv.Atoi(ctx.Params("userId"))
+	helper.PanicIfError(err)
+
+	controller.AddressBookService.RemoveMember(ctx, *user, addressBookID, memberUserID)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   "Member removed successfully",
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
@@ -9,5 +9,6 @@ type AddressController interface {
 	Get(ctx *fiber.Ctx) error
 	GetAll(ctx *fiber.Ctx) error
 	Update(ctx *fiber.Ctx) error
+	Replace(ctx *fiber.Ctx) error
 	Delete(ctx *fiber.Ctx) error
 }
@@ -30,6 +30,7 @@ func (controller *AddressControllerImpl) Create(ctx *fiber.Ctx) error {
 	helper.PanicIfError(err)
 
 	addressResponse := controller.AddressService.Create(ctx, *user, contactID, &request)
+	ctx.Set(fiber.HeaderETag, helper.FormatETag(addressResponse.Version))
 
 	webResponse := web.Response{
 		Code:   201,
@@ -49,12 +50,21 @@ func (controller *AddressControllerImpl) Get(ctx *fiber.Ctx) error {
 	addressID, err := strconv.ParseInt(ctx.Params("addressId"), 10, 64)
 	helper.PanicIfError(err)
 
-	addressResponse := controller.AddressService.Get(ctx, *user, contactID, addressID)
+	fields, err := helper.ParseQueryList("fields", ctx.Query("fields"), address.Fields)
+	helper.PanicIfError(err)
+
+	addressResponse := controller.AddressService.Get(ctx, *user, contactID, addressID, fields)
+
+	etag := helper.FormatETag(addressResponse.Version)
+	ctx.Set(fiber.HeaderETag, etag)
+	if helper.MatchesETag(ctx.Get(fiber.HeaderIfNoneMatch), etag) {
+		return ctx.SendStatus(fiber.StatusNotModified)
+	}
 
 	webResponse := web.Response{
 		Code:   200,
 		Status: "OK",
-		Data:   addressResponse,
+		Data:   helper.SparseFields(addressResponse, fields),
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(webResponse)
@@ -66,12 +76,15 @@ func (controller *AddressControllerImpl) GetAll(ctx *fiber.Ctx) error {
 	contactID, err := strconv.ParseInt(ctx.Params("contactId"), 10, 64)
 	helper.PanicIfError(err)
 
-	addressResponses := controller.AddressService.GetAll(ctx, *user, contactID)
+	fields, err := helper.ParseQueryList("fields", ctx.Query("fields"), address.Fields)
+	helper.PanicIfError(err)
+
+	addressResponses := controller.AddressService.GetAll(ctx, *user, contactID, fields)
 
 	webResponse := web.Response{
 		Code:   200,
 		Status: "OK",
-		Data:   addressResponses,
+		Data:   helper.SparseFields(addressResponses, fields),
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(webResponse)
@@ -86,11 +99,42 @@ func (controller *AddressControllerImpl) Update(ctx *fiber.Ctx) error {
 	addressID, err := strconv.ParseInt(ctx.Params("addressId"), 10, 64)
 	helper.PanicIfError(err)
 
-	var request address.AddressUpdateRequest
+	expectedVersion, err := helper.ParseIfMatch(ctx.Get(fiber.HeaderIfMatch))
+	helper.PanicIfError(err)
+
+	patch, err := helper.ReadMergePatch(ctx)
+	helper.PanicIfError(err)
+
+	addressResponse := controller.AddressService.Update(ctx, *user, contactID, addressID, patch, expectedVersion)
+	ctx.Set(fiber.HeaderETag, helper.FormatETag(addressResponse.Version))
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   addressResponse,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *AddressControllerImpl) Replace(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	contactID, err := strconv.ParseInt(ctx.Params("contactId"), 10, 64)
+	helper.PanicIfError(err)
+
+	addressID, err := strconv.ParseInt(ctx.Params("addressId"), 10, 64)
+	helper.PanicIfError(err)
+
+	expectedVersion, err := helper.ParseIfMatch(ctx.Get(fiber.HeaderIfMatch))
+	helper.PanicIfError(err)
+
+	request := address.AddressCreateRequest{}
 	err = ctx.BodyParser(&request)
 	helper.PanicIfError(err)
 
-	addressResponse := controller.AddressService.Update(ctx, *user, contactID, addressID, request)
+	addressResponse := controller.AddressService.Replace(ctx, *user, contactID, addressID, &request, expectedVersion)
+	ctx.Set(fiber.HeaderETag, helper.FormatETag(addressResponse.Version))
 
 	webResponse := web.Response{
 		Code:   200,
@@ -110,7 +154,10 @@ func (controller *AddressControllerImpl) Delete(ctx *fiber.Ctx) error {
 	addressID, err := strconv.ParseInt(ctx.Params("addressId"), 10, 64)
 	helper.PanicIfError(err)
 
-	controller.AddressService.Delete(ctx, *user, contactID, addressID)
+	expectedVersion, err := helper.ParseIfMatch(ctx.Get(fiber.HeaderIfMatch))
+	helper.PanicIfError(err)
+
+	controller.AddressService.Delete(ctx, *user, contactID, addressID, expectedVersion)
 
 	webResponse := web.Response{
 		Code:   200,
@@ -0,0 +1,16 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type AdminController interface {
+	SearchUsers(ctx *fiber.Ctx) error
+	Stats(ctx *fiber.Ctx) error
+	Disable(ctx *fiber.Ctx) error
+	Enable(ctx *fiber.Ctx) error
+	ForceLogout(ctx *fiber.Ctx) error
+	SetRole(ctx *fiber.Ctx) error
+	ListActions(ctx *fiber.Ctx) error
+	SearchAuditLogs(ctx *fiber.Ctx) error
+}
@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/audit"
+	"github.com/sorfian/go-contact-management-api/model/web/user"
+	"github.com/sorfian/go-contact-management-api/service"
+)
+
+type AdminControllerImpl struct {
+	AdminService service.AdminService
+}
+
+func NewAdminController(adminService service.AdminService) AdminController {
+	return &AdminControllerImpl{AdminService: adminService}
+}
+
+func (controller *AdminControllerImpl) SearchUsers(ctx *fiber.Ctx) error {
+	searchParams := user.UserSearchParams{
+		Username: ctx.Query("username", ""),
+		Name:     ctx.Query("name", ""),
+		Role:     ctx.Query("role", ""),
+		Page:     ctx.QueryInt("page", 1),
+		Size:     ctx.QueryInt("size", 10),
+	}
+	if disabled := ctx.Query("disabled", ""); disabled != "" {
+		value := ctx.QueryBool("disabled")
+		searchParams.Disabled = &value
+	}
+
+	searchResult := controller.AdminService.Search(ctx, searchParams)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   searchResult,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *AdminControllerImpl) Stats(ctx *fiber.Ctx) error {
+	statsResponse := controller.AdminService.Stats(ctx)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   statsResponse,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *AdminControllerImpl) Disable(ctx *fiber.Ctx) error {
+	admin := ctx.Locals("user").(*domain.User)
+
+	userID, err := strconv.Atoi(ctx.Params("userId"))
+	helper.PanicIfError(err)
+
+	userResponse := controller.AdminService.Disable(ctx, *admin, userID)
+
+	return ctx.Status(fiber.StatusOK).JSON(web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   userResponse,
+	})
+}
+
+func (controller *AdminControllerImpl) Enable(ctx *fiber.Ctx) error {
+	admin := ctx.Locals("user").(*domain.User)
+
+	userID, err := strconv.Atoi(ctx.Params("userId"))
+	helper.PanicIfError(err)
+
+	userResponse := controller.AdminService.Enable(ctx, *admin, userID)
+
+	return ctx.Status(fiber.StatusOK).JSON(web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   userResponse,
+	})
+}
+
+func (controller *AdminControllerImpl) ForceLogout(ctx *fiber.Ctx) error {
+	admin := ctx.Locals("user").(*domain.User)
+
+	userID, err := strconv.Atoi(ctx.Params("userId"))
+	helper.PanicIfError(err)
+
+	userResponse := controller.AdminService.ForceLogout(ctx, *admin, userID)
+
+	return ctx.Status(fiber.StatusOK).JSON(web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   userResponse,
+	})
+}
+
+func (controller *AdminControllerImpl) SetRole(ctx *fiber.Ctx) error {
+	admin := ctx.Locals("user").(*domain.User)
+
+	userID, err := strconv.Atoi(ctx.Params("userId"))
+	helper.PanicIfError(err)
+
+	request := user.UserRoleRequest{}
+	err = ctx.BodyParser(&request)
+	helper.PanicIfError(err)
+
+	userResponse := controller.AdminService.SetRole(ctx, *admin, userID, &request)
+
+	return ctx.Status(fiber.StatusOK).JSON(web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   userResponse,
+	})
+}
+
+func (controller *AdminControllerImpl) ListActions(ctx *fiber.Ctx) error {
+	actionsResult := controller.AdminService.ListActions(ctx, ctx.QueryInt("page", 1), ctx.QueryInt("size", 10))
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   actionsResult,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *AdminControllerImpl) SearchAuditLogs(ctx *fiber.Ctx) error {
+	searchParams := audit.AuditLogSearchParams{
+		OrganizationID: int64(ctx.QueryInt("organization_id", 0)),
+		ActorID:        ctx.QueryInt("actor_id", 0),
+		Action:         ctx.Query("action", ""),
+		EntityType:     ctx.Query("entity_type", ""),
+		EntityID:       int64(ctx.QueryInt("entity_id", 0)),
+		RequestID:      ctx.Query("request_id", ""),
+		From:           queryTime(ctx, "from"),
+		To:             queryTime(ctx, "to"),
+		Page:           ctx.QueryInt("page", 1),
+		Size:           ctx.QueryInt("size", 10),
+	}
+
+	searchResult := controller.AdminService.SearchAuditLogs(ctx, searchParams)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   searchResult,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+// queryTime parses an RFC 3339 query parameter, nil when it is missing
+func queryTime(ctx *fiber.Ctx, key string) *time.Time {
+	value := ctx.Query(key, "")
+	if value == "" {
+		return nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(helper.NewBadRequestError(key + " must be an RFC 3339 date-time"))
+	}
+	return &parsed
+}
@@ -0,0 +1,17 @@
+package controller
+
+import "github.com/gofiber/fiber/v2"
+
+type CardDAVController interface {
+	WellKnown(ctx *fiber.Ctx) error
+	Options(ctx *fiber.Ctx) error
+	PropfindRoot(ctx *fiber.Ctx) error
+	PropfindPrincipal(ctx *fiber.Ctx) error
+	PropfindHome(ctx *fiber.Ctx) error
+	PropfindAddressBook(ctx *fiber.Ctx) error
+	Report(ctx *fiber.Ctx) error
+	PropfindCard(ctx *fiber.Ctx) error
+	GetCard(ctx *fiber.Ctx) error
+	PutCard(ctx *fiber.Ctx) error
+	DeleteCard(ctx *fiber.Ctx) error
+}
@@ -0,0 +1,358 @@
+package controller
+
+import (
+	"encoding/xml"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/carddav"
+	"github.com/sorfian/go-contact-management-api/service"
+)
+
+// The CardDAV resources are the root, the principal of the user, the home
+// with the address books of the user, and the cards of each address book
+const (
+	davRoot         = "/dav/"
+	davPrincipal    = "/dav/principal/"
+	davAddressBooks = "/dav/addressbooks/"
+)
+
+type CardDAVControllerImpl struct {
+	CardDAVService service.CardDAVService
+}
+
+func NewCardDAVController(cardDAVService service.CardDAVService) CardDAVController {
+	return &CardDAVControllerImpl{CardDAVService: cardDAVService}
+}
+
+// WellKnown points clients looking up the service to the root
+func (controller *CardDAVControllerImpl) WellKnown(ctx *fiber.Ctx) error {
+	return ctx.Redirect(davRoot, fiber.StatusMovedPermanently)
+}
+
+// Options tells clients the resources are address books
+func (controller *CardDAVControllerImpl) Options(ctx *fiber.Ctx) error {
+	ctx.Set("DAV", "1, 3, addressbook")
+	ctx.Set(fiber.HeaderAllow, "OPTIONS, GET, HEAD, PUT, DELETE, PROPFIND, REPORT")
+	return ctx.SendStatus(fiber.StatusOK)
+}
+
+func (controller *CardDAVControllerImpl) PropfindRoot(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+	names := propfindNames(ctx)
+
+	multistatus := carddav.NewMultistatus()
+	multistatus.Responses = append(multistatus.Responses, carddav.NewResponse(davRoot, rootProperties(), names))
+	if ctx.Get("Depth") != "0" {
+		multistatus.Responses = append(multistatus.Responses,
+			carddav.NewResponse(davPrincipal, principalProperties(user), names),
+			carddav.NewResponse(davAddressBooks, homeProperties(), names),
+		)
+	}
+
+	return sendMultistatus(ctx, multistatus)
+}
+
+func (controller *CardDAVControllerImpl) PropfindPrincipal(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	multistatus := carddav.NewMultistatus()
+	multistatus.Responses = append(multistatus.Responses, carddav.NewResponse(davPrincipal, principalProperties(user), propfindNames(ctx)))
+
+	return sendMultistatus(ctx, multistatus)
+}
+
+// PropfindHome lists the address books of the user in every organization
+// they belong to
+func (controller *CardDAVControllerImpl) PropfindHome(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+	names := propfindNames(ctx)
+
+	multistatus := carddav.NewMultistatus()
+	multistatus.Responses = append(multistatus.Responses, carddav.NewResponse(davAddressBooks, homeProperties(), names))
+	if ctx.Get("Depth") != "0" {
+		for _, addressBook := range controller.CardDAVService.AddressBooks(ctx, *user) {
+			multistatus.Responses = append(multistatus.Responses, carddav.NewResponse(addressBookHref(addressBook.OrganizationID, addressBook.ID), addressBookProperties(addressBook), names))
+		}
+	}
+
+	return sendMultistatus(ctx, multistatus)
+}
+
+func (controller *CardDAVControllerImpl) PropfindAddressBook(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+	organizationID, addressBookID := addressBookParams(ctx)
+	names := propfindNames(ctx)
+
+	href := addressBookHref(organizationID, addressBookID)
+	addressBook := controller.CardDAVService.AddressBook(ctx, *user, addressBookID)
+
+	multistatus := carddav.NewMultistatus()
+	multistatus.Responses = append(multistatus.Responses, carddav.NewResponse(href, addressBookProperties(addressBook), names))
+	if ctx.Get("Depth") != "0" {
+		for _, card := range controller.CardDAVService.Cards(ctx, *user, addressBookID, nil) {
+			multistatus.Responses = append(multistatus.Responses, carddav.NewResponse(href+url.PathEscape(card.Name), cardProperties(card, names), names))
+		}
+	}
+
+	return sendMultistatus(ctx, multistatus)
+}
+
+// Report answers an addressbook-query, an addressbook-multiget or a
+// sync-collection of the address book
+func (controller *CardDAVControllerImpl) Report(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+	organizationID, addressBookID := addressBookParams(ctx)
+
+	report := carddav.Report{}
+	if err := xml.Unmarshal(ctx.Body(), &report); err != nil {
+		panic(helper.NewBadRequestError("body must be an XML report"))
+	}
+	names := carddav.PropNames(report.Prop)
+	href := addressBookHref(organizationID, addressBookID)
+
+	multistatus := carddav.NewMultistatus()
+	switch report.XMLName {
+	case carddav.ReportAddressBookMultiget:
+		cardNames := make([]string, 0, len(report.Hrefs))
+		for _, cardHref := range report.Hrefs {
+			cardNames = append(cardNames, cardNameOf(href, cardHref))
+		}
+
+		cards := map[string]carddav.Card{}
+		for _, card := range controller.CardDAVService.FindCards(ctx, *user, addressBookID, cardNames) {
+			cards[card.Name] = card
+		}
+
+		for i, cardHref := range report.Hrefs {
+			card, ok := cards[cardNames[i]]
+			if !ok {
+				multistatus.Responses = append(multistatus.Responses, carddav.Response{Href: cardHref, Status: carddav.StatusNotFound})
+				continue
+			}
+			multistatus.Responses = append(multistatus.Responses, carddav.NewResponse(cardHref, cardProperties(card, names), names))
+		}
+
+	case carddav.ReportAddressBookQuery:
+		cards := controller.CardDAVService.Cards(ctx, *user, addressBookID, report.Filter)
+		truncated := report.Limit != nil && report.Limit.NResults > 0 && len(cards) > report.Limit.NResults
+		if truncated {
+			cards = cards[:report.Limit.NResults]
+		}
+
+		for _, card := range cards {
+			multistatus.Responses = append(multistatus.Responses, carddav.NewResponse(href+url.PathEscape(card.Name), cardProperties(card, names), names))
+		}
+		if truncated {
+			multistatus.Responses = append(multistatus.Responses, carddav.Response{Href: href, Status: carddav.StatusInsufficientStorage})
+		}
+
+	case carddav.ReportSyncCollection:
+		token := strings.TrimPrefix(report.SyncToken, carddav.SyncTokenPrefix)
+		if token != "" && token == report.SyncToken {
+			panic(helper.NewForbiddenError("sync token is not valid"))
+		}
+
+		changes := controller.CardDAVService.Changes(ctx, *user, addressBookID, token)
+		for _, card := range changes.Cards {
+			multistatus.Responses = append(multistatus.Responses, carddav.NewResponse(href+url.PathEscape(card.Name), cardProperties(card, names), names))
+		}
+		for _, name := range changes.Removed {
+			multistatus.Responses = append(multistatus.Responses, carddav.Response{Href: href + url.PathEscape(name), Status: carddav.StatusNotFound})
+		}
+		multistatus.SyncToken = carddav.SyncTokenPrefix + changes.Token
+
+	default:
+		panic(helper.NewForbiddenError("report must be one of addressbook-query, addressbook-multiget, sync-collection"))
+	}
+
+	return sendMultistatus(ctx, multistatus)
+}
+
+func (controller *CardDAVControllerImpl) PropfindCard(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+	organizationID, addressBookID := addressBookParams(ctx)
+	names := propfindNames(ctx)
+
+	card := controller.CardDAVService.Card(ctx, *user, addressBookID, cardParam(ctx))
+
+	multistatus := carddav.NewMultistatus()
+	multistatus.Responses = append(multistatus.Responses, carddav.NewResponse(addressBookHref(organizationID, addressBookID)+url.PathEscape(card.Name), cardProperties(card, names), names))
+
+	return sendMultistatus(ctx, multistatus)
+}
+
+func (controller *CardDAVControllerImpl) GetCard(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+	_, addressBookID := addressBookParams(ctx)
+
+	card := controller.CardDAVService.Card(ctx, *user, addressBookID, cardParam(ctx))
+
+	ctx.Set(fiber.HeaderETag, card.ETag)
+	ctx.Set(fiber.HeaderContentType, helper.MIMETextVCard)
+	return ctx.Status(fiber.StatusOK).Send(card.Data)
+}
+
+// PutCard creates or replaces a card. It sends no entity tag, as the stored
+// card drops what a contact does not keep and clients must fetch it again.
+func (controller *CardDAVControllerImpl) PutCard(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+	_, addressBookID := addressBookParams(ctx)
+
+	created := controller.CardDAVService.PutCard(ctx, *user, addressBookID, cardParam(ctx), ctx.Body(), ctx.Get(fiber.HeaderIfMatch), ctx.Get(fiber.HeaderIfNoneMatch))
+	if created {
+		return ctx.SendStatus(fiber.StatusCreated)
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func (controller *CardDAVControllerImpl) DeleteCard(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+	_, addressBookID := addressBookParams(ctx)
+
+	controller.CardDAVService.DeleteCard(ctx, *user, addressBookID, cardParam(ctx), ctx.Get(fiber.HeaderIfMatch))
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func addressBookParams(ctx *fiber.Ctx) (int64, int64) {
+	organizationID, err := strconv.ParseInt(ctx.Params("organizationId"), 10, 64)
+	helper.PanicIfError(err)
+
+	addressBookID, err := strconv.ParseInt(ctx.Params("addressBookId"), 10, 64)
+	helper.PanicIfError(err)
+
+	return organizationID, addressBookID
+}
+
+// cardParam returns the name of the card of the request, unescaped
+func cardParam(ctx *fiber.Ctx) string {
+	name, err := url.PathUnescape(ctx.Params("card"))
+	if err != nil {
+		panic(helper.NewBadRequestError("card name is not a valid path segment"))
+	}
+	return name
+}
+
+func addressBookHref(organizationID int64, addressBookID int64) string {
+	return davAddressBooks + strconv.FormatInt(organizationID, 10) + "/" + strconv.FormatInt(addressBookID, 10) + "/"
+}
+
+// cardNameOf returns the name of the card a multiget href names in the
+// address book, empty when it names none of its cards
+func cardNameOf(addressBookHref string, cardHref string) string {
+	parsed, err := url.Parse(cardHref)
+	if err != nil || !strings.HasPrefix(parsed.Path, addressBookHref) {
+		return ""
+	}
+
+	name := strings.TrimPrefix(parsed.Path, addressBookHref)
+	if strings.Contains(name, "/") {
+		return ""
+	}
+	return name
+}
+
+// propfindNames returns the properties a PROPFIND asks for, nil for all
+func propfindNames(ctx *fiber.Ctx) []xml.Name {
+	if len(ctx.Body()) == 0 {
+		return nil
+	}
+
+	propfind := carddav.Propfind{}
+	if err := xml.Unmarshal(ctx.Body(), &propfind); err != nil {
+		panic(helper.NewBadRequestError("body must be a DAV:propfind element"))
+	}
+	return carddav.PropNames(propfind.Prop)
+}
+
+func sendMultistatus(ctx *fiber.Ctx, multistatus *carddav.Multistatus) error {
+	body, err := xml.Marshal(multistatus)
+	helper.PanicIfError(err)
+
+	ctx.Set(fiber.HeaderContentType, fiber.MIMEApplicationXMLCharsetUTF8)
+	return ctx.Status(fiber.StatusMultiStatus).Send(append([]byte(xml.Header), body...))
+}
+
+func davName(local string) xml.Name {
+	return xml.Name{Space: carddav.NamespaceDAV, Local: local}
+}
+
+func cardDAVName(local string) xml.Name {
+	return xml.Name{Space: carddav.NamespaceCardDAV, Local: local}
+}
+
+func davHref(href string) string {
+	return "<D:href>" + escapeXML(href) + "</D:href>"
+}
+
+func escapeXML(text string) string {
+	var builder strings.Builder
+	_ = xml.EscapeText(&builder, []byte(text))
+	return builder.String()
+}
+
+// resourceProperties are the properties every resource has
+func resourceProperties(resourceType string) map[xml.Name]string {
+	return map[xml.Name]string{
+		davName("resourcetype"):           resourceType,
+		davName("current-user-principal"): davHref(davPrincipal),
+	}
+}
+
+func rootProperties() map[xml.Name]string {
+	properties := resourceProperties("<D:collection/>")
+	properties[cardDAVName("addressbook-home-set")] = davHref(davAddressBooks)
+	return properties
+}
+
+func principalProperties(user *domain.User) map[xml.Name]string {
+	properties := resourceProperties("<D:principal/>")
+	properties[davName("displayname")] = escapeXML(user.Name)
+	properties[davName("principal-URL")] = davHref(davPrincipal)
+	properties[cardDAVName("addressbook-home-set")] = davHref(davAddressBooks)
+	return properties
+}
+
+func homeProperties() map[xml.Name]string {
+	return resourceProperties("<D:collection/>")
+}
+
+func addressBookProperties(addressBook carddav.AddressBook) map[xml.Name]string {
+	properties := resourceProperties("<D:collection/><C:addressbook/>")
+	properties[davName("displayname")] = escapeXML(addressBook.Name)
+	properties[davName("sync-token")] = escapeXML(carddav.SyncTokenPrefix + addressBook.SyncToken)
+	properties[davName("supported-report-set")] = "" +
+		"<D:supported-report><D:report><C:addressbook-query/></D:report></D:supported-report>" +
+		"<D:supported-report><D:report><C:addressbook-multiget/></D:report></D:supported-report>" +
+		"<D:supported-report><D:report><D:sync-collection/></D:report></D:supported-report>"
+	properties[cardDAVName("supported-address-data")] = `<C:address-data-type content-type="text/vcard" version="3.0"/>`
+
+	// Clients keep the address books of viewers read only
+	privileges := "<D:privilege><D:read/></D:privilege>"
+	if addressBook.Writable {
+		privileges += "<D:privilege><D:write/></D:privilege><D:privilege><D:write-content/></D:privilege>" +
+			"<D:privilege><D:bind/></D:privilege><D:privilege><D:unbind/></D:privilege>"
+	}
+	properties[davName("current-user-privilege-set")] = privileges
+
+	return properties
+}
+
+// cardProperties are the properties of a card, its data only when asked for
+func cardProperties(card carddav.Card, names []xml.Name) map[xml.Name]string {
+	properties := resourceProperties("")
+	properties[davName("getetag")] = escapeXML(card.ETag)
+	properties[davName("getcontenttype")] = escapeXML(helper.MIMETextVCard)
+
+	for _, name := range names {
+		if name == cardDAVName("address-data") {
+			properties[name] = escapeXML(string(card.Data))
+		}
+	}
+	return properties
+}
@@ -0,0 +1,9 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type CollaborationController interface {
+	Connect(ctx *fiber.Ctx) error
+}
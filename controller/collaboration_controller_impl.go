@@ -0,0 +1,372 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/collaboration"
+	"github.com/sorfian/go-contact-management-api/model/web/stream"
+	"github.com/sorfian/go-contact-management-api/service"
+)
+
+const (
+	// collaborationReadLimit is the largest message a client may send
+	collaborationReadLimit = 4096
+	// collaborationPingInterval is how often a connection is pinged, and how
+	// often it keeps its viewers alive
+	collaborationPingInterval = 10 * time.Second
+	// collaborationPongWait is how long a connection may stay silent
+	collaborationPongWait = 3 * collaborationPingInterval
+	// collaborationWriteWait is how long a write may take
+	collaborationWriteWait = 10 * time.Second
+)
+
+type CollaborationControllerImpl struct {
+	CollaborationService service.CollaborationService
+	EventStreamService   service.EventStreamService
+	Options              service.EventStreamOptions
+	upgrade              fiber.Handler
+}
+
+func NewCollaborationController(collaborationService service.CollaborationService, eventStreamService service.EventStreamService, options service.EventStreamOptions) CollaborationController {
+	controller := &CollaborationControllerImpl{
+		CollaborationService: collaborationService,
+		EventStreamService:   eventStreamService,
+		Options:              options,
+	}
+	controller.upgrade = websocket.New(controller.session, websocket.Config{
+		RecoverHandler: func(conn *websocket.Conn) {
+			if recovered := recover(); recovered != nil {
+				log.Printf("collaboration: %v", recovered)
+			}
+		},
+	})
+	return controller
+}
+
+// Connect upgrades the request to a collaboration connection. It picks up
+// the changes after last_event_id like the live event stream does.
+func (controller *CollaborationControllerImpl) Connect(ctx *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(ctx) {
+		return fiber.ErrUpgradeRequired
+	}
+
+	user := ctx.Locals("user").(*domain.User)
+	ctx.Locals("after", controller.EventStreamService.Resume(ctx, *user, ctx.Query("last_event_id")))
+
+	return controller.upgrade(ctx)
+}
+
+// collaborationSession is the state of one connection. The reader goroutine
+// changes the subscriptions, the session loop reads them.
+type collaborationSession struct {
+	conn         *websocket.Conn
+	user         domain.User
+	connectionID string
+
+	writeMutex sync.Mutex
+
+	mutex    sync.Mutex
+	list     bool
+	contacts map[int64]bool
+	// joined are the contacts subscribed to since the session loop last
+	// sent presence, they are sent it whether it changed or not
+	joined map[int64]bool
+
+	// subscribed wakes the session loop to send the presence of a contact
+	// right after the reply to subscribing to it
+	subscribed chan struct{}
+}
+
+func (session *collaborationSession) send(message interface{}) error {
+	session.writeMutex.Lock()
+	defer session.writeMutex.Unlock()
+
+	_ = session.conn.SetWriteDeadline(time.Now().Add(collaborationWriteWait))
+	return session.conn.WriteJSON(message)
+}
+
+func (session *collaborationSession) ping() error {
+	session.writeMutex.Lock()
+	defer session.writeMutex.Unlock()
+
+	return session.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(collaborationWriteWait))
+}
+
+// subscriptions returns the subscribed contacts and those newly joined
+func (session *collaborationSession) subscriptions() ([]int64, map[int64]bool) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+
+	contactIDs := make([]int64, 0, len(session.contacts))
+	for contactID := range session.contacts {
+		contactIDs = append(contactIDs, contactID)
+	}
+	joined := session.joined
+	session.joined = map[int64]bool{}
+	return contactIDs, joined
+}
+
+func (session *collaborationSession) receives(eventResponse stream.EventResponse) bool {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+
+	return session.list || session.contacts[eventResponse.ContactID]
+}
+
+func (controller *CollaborationControllerImpl) session(conn *websocket.Conn) {
+	session := &collaborationSession{
+		conn:         conn,
+		user:         *conn.Locals("user").(*domain.User),
+		connectionID: uuid.NewString(),
+		contacts:     map[int64]bool{},
+		joined:       map[int64]bool{},
+		subscribed:   make(chan struct{}, 1),
+	}
+	after := conn.Locals("after").(int64)
+
+	// The request context is released on upgrading, the reader and the
+	// session loop each run on a context of their own in the organization of
+	// the request
+	readCtx := helper.NewContext(context.Background())
+	defer helper.ReleaseContext(readCtx)
+	readCtx.Locals("organization", conn.Locals("organization"))
+	loopCtx := helper.NewContext(context.Background())
+	defer helper.ReleaseContext(loopCtx)
+	loopCtx.Locals("organization", conn.Locals("organization"))
+
+	defer controller.call(func() {
+		controller.CollaborationService.Disconnect(loopCtx, session.connectionID)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		controller.read(readCtx, session)
+	}()
+	defer func() {
+		// fasthttp closes a hijacked connection only once the handler
+		// returns, so Close does nothing here. The close message tells the
+		// client and the expired deadline stops the reader.
+		_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(collaborationWriteWait))
+		_ = conn.SetReadDeadline(time.Now())
+		<-done
+	}()
+
+	controller.loop(loopCtx, session, after, done)
+}
+
+// read handles the messages of the client until it disconnects
+func (controller *CollaborationControllerImpl) read(ctx *fiber.Ctx, session *collaborationSession) {
+	session.conn.SetReadLimit(collaborationReadLimit)
+	_ = session.conn.SetReadDeadline(time.Now().Add(collaborationPongWait))
+	session.conn.SetPongHandler(func(string) error {
+		return session.conn.SetReadDeadline(time.Now().Add(collaborationPongWait))
+	})
+
+	for {
+		_, data, err := session.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		_ = session.conn.SetReadDeadline(time.Now().Add(collaborationPongWait))
+
+		var message collaboration.ClientMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			err = session.send(collaboration.ErrorMessage{Type: collaboration.MessageError, Message: "message must be a JSON object"})
+		} else {
+			err = session.send(controller.handle(ctx, session, message))
+		}
+		if err != nil {
+			return
+		}
+
+		// Presence follows the reply
+		select {
+		case session.subscribed <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// handle applies a client message and returns the reply to it
+func (controller *CollaborationControllerImpl) handle(ctx *fiber.Ctx, session *collaborationSession, message collaboration.ClientMessage) interface{} {
+	switch message.Type {
+	case collaboration.MessageSubscribe:
+		if message.ContactID != 0 {
+			if err := controller.call(func() {
+				controller.CollaborationService.Join(ctx, session.user, session.connectionID, message.ContactID)
+			}); err != nil {
+				return collaboration.ErrorMessage{Type: collaboration.MessageError, ContactID: message.ContactID, Message: err.Error()}
+			}
+		}
+
+		session.mutex.Lock()
+		if message.ContactID == 0 {
+			session.list = true
+		} else {
+			session.contacts[message.ContactID] = true
+			session.joined[message.ContactID] = true
+		}
+		session.mutex.Unlock()
+
+		return collaboration.SubscriptionMessage{Type: collaboration.MessageSubscribed, ContactID: message.ContactID}
+
+	case collaboration.MessageUnsubscribe:
+		session.mutex.Lock()
+		if message.ContactID == 0 {
+			session.list = false
+		} else {
+			delete(session.contacts, message.ContactID)
+		}
+		session.mutex.Unlock()
+
+		if message.ContactID != 0 {
+			if err := controller.call(func() {
+				controller.CollaborationService.Leave(ctx, session.connectionID, message.ContactID)
+			}); err != nil {
+				return collaboration.ErrorMessage{Type: collaboration.MessageError, ContactID: message.ContactID, Message: err.Error()}
+			}
+		}
+		return collaboration.SubscriptionMessage{Type: collaboration.MessageUnsubscribed, ContactID: message.ContactID}
+	}
+
+	return collaboration.ErrorMessage{Type: collaboration.MessageError, Message: "type must be one of subscribe, unsubscribe"}
+}
+
+// loop sends the changes and the presence of the subscribed contacts until
+// the client disconnects or the token is no longer valid
+func (controller *CollaborationControllerImpl) loop(ctx *fiber.Ctx, session *collaborationSession, after int64, done <-chan struct{}) {
+	pollTicker := time.NewTicker(controller.Options.PollInterval)
+	defer pollTicker.Stop()
+	pingTicker := time.NewTicker(collaborationPingInterval)
+	defer pingTicker.Stop()
+
+	presence := map[int64][]collaboration.ViewerResponse{}
+
+	for {
+		signedIn := false
+		var eventResponses []stream.EventResponse
+		if err := controller.call(func() {
+			signedIn = controller.EventStreamService.SignedIn(ctx, session.user)
+			if signedIn {
+				eventResponses = controller.EventStreamService.Poll(ctx, session.user, after, streamBatchSize)
+			}
+		}); err != nil || !signedIn {
+			return
+		}
+
+		for _, eventResponse := range eventResponses {
+			after = eventResponse.ID
+			if !session.receives(eventResponse) {
+				continue
+			}
+			if session.send(collaboration.ChangeMessage{
+				Type:      collaboration.MessageChange,
+				ID:        eventResponse.ID,
+				Event:     eventResponse.Event,
+				ContactID: eventResponse.ContactID,
+				Data:      eventResponse.Data,
+			}) != nil {
+				return
+			}
+		}
+
+		if !controller.sendPresence(ctx, session, presence) {
+			return
+		}
+
+		// A full batch means more are waiting
+		if len(eventResponses) == streamBatchSize {
+			continue
+		}
+
+		select {
+		case <-done:
+			return
+		case <-pollTicker.C:
+		case <-session.subscribed:
+		case <-pingTicker.C:
+			if session.ping() != nil {
+				return
+			}
+			if controller.call(func() {
+				controller.CollaborationService.KeepAlive(ctx, session.connectionID)
+			}) != nil {
+				return
+			}
+		}
+	}
+}
+
+// sendPresence sends the viewers of the subscribed contacts that were just
+// joined or whose viewers changed since last sent, and forgets the contacts
+// no longer subscribed
+func (controller *CollaborationControllerImpl) sendPresence(ctx *fiber.Ctx, session *collaborationSession, presence map[int64][]collaboration.ViewerResponse) bool {
+	contactIDs, joined := session.subscriptions()
+
+	var viewers map[int64][]collaboration.ViewerResponse
+	if controller.call(func() {
+		viewers = controller.CollaborationService.Viewers(ctx, session.user, contactIDs)
+	}) != nil {
+		return false
+	}
+
+	for contactID := range presence {
+		if _, ok := viewers[contactID]; !ok {
+			delete(presence, contactID)
+		}
+	}
+
+	for contactID, contactViewers := range viewers {
+		if sent, ok := presence[contactID]; ok && !joined[contactID] && reflect.DeepEqual(sent, contactViewers) {
+			continue
+		}
+		presence[contactID] = contactViewers
+
+		if session.send(collaboration.PresenceMessage{
+			Type:      collaboration.MessagePresence,
+			ContactID: contactID,
+			Viewers:   contactViewers,
+		}) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// call runs a service call. Services report failures by panicking, call
+// returns the error the client may see, the message of the error response.
+func (controller *CollaborationControllerImpl) call(fn func()) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			recoveredErr, ok := recovered.(error)
+			if !ok {
+				log.Printf("collaboration: %v", recovered)
+				err = errors.New("Internal Server Error")
+				return
+			}
+
+			response := helper.NewErrorResponse(recoveredErr)
+			if response.Code == fiber.StatusInternalServerError {
+				log.Printf("collaboration: %v", recoveredErr)
+				err = errors.New(response.Status)
+				return
+			}
+			err = errors.New(response.Data.(string))
+		}
+	}()
+
+	fn()
+	return nil
+}
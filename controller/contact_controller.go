@@ -9,5 +9,11 @@ type ContactController interface {
 	Get(ctx *fiber.Ctx) error
 	GetAll(ctx *fiber.Ctx) error
 	Update(ctx *fiber.Ctx) error
+	Replace(ctx *fiber.Ctx) error
 	Delete(ctx *fiber.Ctx) error
+	Bulk(ctx *fiber.Ctx) error
+	GetHistory(ctx *fiber.Ctx) error
+	GetVersions(ctx *fiber.Ctx) error
+	GetVersion(ctx *fiber.Ctx) error
+	Revert(ctx *fiber.Ctx) error
 }
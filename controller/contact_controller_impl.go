@@ -27,6 +27,7 @@ func (controller *ContactControllerImpl) Create(ctx *fiber.Ctx) error {
 	helper.PanicIfError(err)
 
 	contactResponse := controller.ContactService.Create(ctx, *user, &request)
+	ctx.Set(fiber.HeaderETag, helper.FormatETag(contactResponse.Version))
 
 	webResponse := web.Response{
 		Code:   201,
@@ -43,12 +44,26 @@ func (controller *ContactControllerImpl) Get(ctx *fiber.Ctx) error {
 	contactID, err := strconv.ParseInt(ctx.Params("contactId"), 10, 64)
 	helper.PanicIfError(err)
 
-	contactResponse := controller.ContactService.Get(ctx, *user, contactID)
+	include, err := helper.ParseQueryList("include", ctx.Query("include"), contact.Includes)
+	helper.PanicIfError(err)
+
+	fields, err := helper.ParseQueryList("fields", ctx.Query("fields"), contact.Fields)
+	helper.PanicIfError(err)
+
+	contactResponse := controller.ContactService.Get(ctx, *user, contactID, include, fields)
+
+	// Embedded sub-resources change without the contact's version, so a
+	// response with them is never reported as not modified
+	etag := helper.FormatETag(contactResponse.Version)
+	ctx.Set(fiber.HeaderETag, etag)
+	if len(include) == 0 && helper.MatchesETag(ctx.Get(fiber.HeaderIfNoneMatch), etag) {
+		return ctx.SendStatus(fiber.StatusNotModified)
+	}
 
 	webResponse := web.Response{
 		Code:   200,
 		Status: "OK",
-		Data:   contactResponse,
+		Data:   helper.SparseFields(contactResponse, responseFields(fields, include)),
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(webResponse)
@@ -63,20 +78,38 @@ func (controller *ContactControllerImpl) GetAll(ctx *fiber.Ctx) error {
 	page := ctx.QueryInt("page", 1)
 	size := ctx.QueryInt("size", 10)
 
+	include, err := helper.ParseQueryList("include", ctx.Query("include"), contact.Includes)
+	helper.PanicIfError(err)
+
+	fields, err := helper.ParseQueryList("fields", ctx.Query("fields"), contact.Fields)
+	helper.PanicIfError(err)
+
 	searchParams := contact.SearchParams{
-		Name:  name,
-		Phone: phone,
-		Email: email,
-		Page:  page,
-		Size:  size,
+		AddressBookID: int64(ctx.QueryInt("address_book_id", 0)),
+		Name:          name,
+		Phone:         phone,
+		Email:         email,
+		Page:          page,
+		Size:          size,
+		Include:       include,
+		Fields:        fields,
 	}
 
-	contactResponses := controller.ContactService.GetAll(ctx, *user, searchParams)
+	searchResult := controller.ContactService.GetAll(ctx, *user, searchParams)
+
+	// Only the contacts are limited, the paging is kept whole
+	var data interface{} = searchResult
+	if len(fields) > 0 {
+		data = fiber.Map{
+			"contacts": helper.SparseFields(searchResult.Contacts, responseFields(fields, include)),
+			"paging":   searchResult.Paging,
+		}
+	}
 
 	webResponse := web.Response{
 		Code:   200,
 		Status: "OK",
-		Data:   contactResponses,
+		Data:   data,
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(webResponse)
@@ -88,11 +121,39 @@ func (controller *ContactControllerImpl) Update(ctx *fiber.Ctx) error {
 	contactID, err := strconv.ParseInt(ctx.Params("contactId"), 10, 64)
 	helper.PanicIfError(err)
 
-	var request contact.ContactUpdateRequest
+	expectedVersion, err := helper.ParseIfMatch(ctx.Get(fiber.HeaderIfMatch))
+	helper.PanicIfError(err)
+
+	patch, err := helper.ReadMergePatch(ctx)
+	helper.PanicIfError(err)
+
+	contactResponse := controller.ContactService.Update(ctx, *user, contactID, patch, expectedVersion)
+	ctx.Set(fiber.HeaderETag, helper.FormatETag(contactResponse.Version))
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   contactResponse,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *ContactControllerImpl) Replace(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	contactID, err := strconv.ParseInt(ctx.Params("contactId"), 10, 64)
+	helper.PanicIfError(err)
+
+	expectedVersion, err := helper.ParseIfMatch(ctx.Get(fiber.HeaderIfMatch))
+	helper.PanicIfError(err)
+
+	request := contact.ContactCreateRequest{}
 	err = ctx.BodyParser(&request)
 	helper.PanicIfError(err)
 
-	contactResponse := controller.ContactService.Update(ctx, *user, contactID, request)
+	contactResponse := controller.ContactService.Replace(ctx, *user, contactID, &request, expectedVersion)
+	ctx.Set(fiber.HeaderETag, helper.FormatETag(contactResponse.Version))
 
 	webResponse := web.Response{
 		Code:   200,
@@ -109,7 +170,10 @@ func (controller *ContactControllerImpl) Delete(ctx *fiber.Ctx) error {
 	contactID, err := strconv.ParseInt(ctx.Params("contactId"), 10, 64)
 	helper.PanicIfError(err)
 
-	controller.ContactService.Delete(ctx, *user, contactID)
+	expectedVersion, err := helper.ParseIfMatch(ctx.Get(fiber.HeaderIfMatch))
+	helper.PanicIfError(err)
+
+	controller.ContactService.Delete(ctx, *user, contactID, expectedVersion)
 
 	webResponse := web.Response{
 		Code:   200,
@@ -119,3 +183,108 @@ func (controller *ContactControllerImpl) Delete(ctx *fiber.Ctx) error {
 
 	return ctx.Status(fiber.StatusOK).JSON(webResponse)
 }
+
+func (controller *ContactControllerImpl) Bulk(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	request := contact.BulkRequest{}
+	err := ctx.BodyParser(&request)
+	helper.PanicIfError(err)
+
+	bulkResult := controller.ContactService.Bulk(ctx, *user, &request)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   bulkResult,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *ContactControllerImpl) GetHistory(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	contactID, err := strconv.ParseInt(ctx.Params("contactId"), 10, 64)
+	helper.PanicIfError(err)
+
+	historyResult := controller.ContactService.GetHistory(ctx, *user, contactID, ctx.QueryInt("page", 1), ctx.QueryInt("size", 10))
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   historyResult,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *ContactControllerImpl) GetVersions(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	contactID, err := strconv.ParseInt(ctx.Params("contactId"), 10, 64)
+	helper.PanicIfError(err)
+
+	versionsResult := controller.ContactService.GetVersions(ctx, *user, contactID, ctx.QueryInt("page", 1), ctx.QueryInt("size", 10))
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   versionsResult,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *ContactControllerImpl) GetVersion(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	contactID, err := strconv.ParseInt(ctx.Params("contactId"), 10, 64)
+	helper.PanicIfError(err)
+
+	number, err := strconv.Atoi(ctx.Params("number"))
+	helper.PanicIfError(err)
+
+	versionResponse := controller.ContactService.GetVersion(ctx, *user, contactID, number)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   versionResponse,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *ContactControllerImpl) Revert(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	contactID, err := strconv.ParseInt(ctx.Params("contactId"), 10, 64)
+	helper.PanicIfError(err)
+
+	number, err := strconv.Atoi(ctx.Params("number"))
+	helper.PanicIfError(err)
+
+	expectedVersion, err := helper.ParseIfMatch(ctx.Get(fiber.HeaderIfMatch))
+	helper.PanicIfError(err)
+
+	contactResponse := controller.ContactService.Revert(ctx, *user, contactID, number, expectedVersion)
+	ctx.Set(fiber.HeaderETag, helper.FormatETag(contactResponse.Version))
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   contactResponse,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+// responseFields are the members of a contact response limited to fields,
+// which keeps the sub-resources it includes
+func responseFields(fields []string, include []string) []string {
+	if len(fields) == 0 {
+		return nil
+	}
+	return append(append([]string{}, fields...), include...)
+}
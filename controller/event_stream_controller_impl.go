@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/stream"
+	"github.com/sorfian/go-contact-management-api/service"
+)
+
+// streamBatchSize is how many events a connection reads at once
+const streamBatchSize = 100
+
+// streamRetry is the reconnection delay suggested to clients
+const streamRetry = 3 * time.Second
+
+type EventStreamControllerImpl struct {
+	EventStreamService service.EventStreamService
+	Options            service.EventStreamOptions
+}
+
+func NewEventStreamController(eventStreamService service.EventStreamService, options service.EventStreamOptions) EventStreamController {
+	return &EventStreamControllerImpl{EventStreamService: eventStreamService, Options: options}
+}
+
+// Stream sends the contact and address changes of the user as server-sent
+// events until the client disconnects or the token is no longer valid
+func (controller *EventStreamControllerImpl) Stream(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+	after := controller.EventStreamService.Resume(ctx, *user, ctx.Get("Last-Event-ID"))
+
+	// The request context is released when the handler returns, the stream
+	// runs on a context of its own in the organization of the request
+	streamCtx := helper.NewContext(context.Background())
+	streamCtx.Locals("organization", ctx.Locals("organization"))
+	streamUser := *user
+
+	ctx.Set(fiber.HeaderContentType, "text/event-stream")
+	ctx.Set(fiber.HeaderCacheControl, "no-cache")
+	ctx.Set(fiber.HeaderConnection, "keep-alive")
+	// Keeps nginx from buffering the stream
+	ctx.Set("X-Accel-Buffering", "no")
+
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer helper.ReleaseContext(streamCtx)
+		controller.stream(streamCtx, streamUser, after, w)
+	})
+	return nil
+}
+
+func (controller *EventStreamControllerImpl) stream(ctx *fiber.Ctx, user domain.User, after int64, w *bufio.Writer) {
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetry.Milliseconds())
+	if w.Flush() != nil {
+		return
+	}
+
+	pollTicker := time.NewTicker(controller.Options.PollInterval)
+	defer pollTicker.Stop()
+	heartbeatTicker := time.NewTicker(controller.Options.HeartbeatInterval)
+	defer heartbeatTicker.Stop()
+
+	for controller.signedIn(ctx, user) {
+		eventResponses, ok := controller.poll(ctx, user, after)
+		if !ok {
+			return
+		}
+
+		for _, eventResponse := range eventResponses {
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", eventResponse.ID, eventResponse.Event, eventResponse.Data)
+			after = eventResponse.ID
+		}
+		if len(eventResponses) > 0 {
+			if w.Flush() != nil {
+				return
+			}
+			heartbeatTicker.Reset(controller.Options.HeartbeatInterval)
+		}
+
+		// A full batch means more are waiting
+		if len(eventResponses) == streamBatchSize {
+			continue
+		}
+
+		select {
+		case <-pollTicker.C:
+		case <-heartbeatTicker.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			if w.Flush() != nil {
+				return
+			}
+		}
+	}
+}
+
+// signedIn checks the token of the stream again, so that it ends once the
+// token expires, the user logs out or the user is disabled. A failure ends the
+// stream too.
+func (controller *EventStreamControllerImpl) signedIn(ctx *fiber.Ctx, user domain.User) (ok bool) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			log.Printf("event stream: %v", recovered)
+			ok = false
+		}
+	}()
+
+	return controller.EventStreamService.SignedIn(ctx, user)
+}
+
+// poll reads the next events. Services report failures by panicking, a
+// failure here ends the stream and the client reconnects.
+func (controller *EventStreamControllerImpl) poll(ctx *fiber.Ctx, user domain.User, after int64) (eventResponses []stream.EventResponse, ok bool) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			log.Printf("event stream: %v", recovered)
+			ok = false
+		}
+	}()
+
+	return controller.EventStreamService.Poll(ctx, user, after, streamBatchSize), true
+}
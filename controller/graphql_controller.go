@@ -0,0 +1,7 @@
+package controller
+
+import "github.com/gofiber/fiber/v2"
+
+type GraphQLController interface {
+	Execute(ctx *fiber.Ctx) error
+}
@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/sorfian/go-contact-management-api/graph"
+	"github.com/sorfian/go-contact-management-api/helper"
+	graphqlweb "github.com/sorfian/go-contact-management-api/model/web/graphql"
+	"github.com/sorfian/go-contact-management-api/service"
+)
+
+type GraphQLControllerImpl struct {
+	Schema *graphql.Schema
+}
+
+func NewGraphQLController(contactService service.ContactService, addressService service.AddressService, userService service.UserService) GraphQLController {
+	return &GraphQLControllerImpl{Schema: graph.NewSchema(contactService, addressService, userService)}
+}
+
+// Execute runs a query or mutation. The response is a GraphQL response rather
+// than the envelope of the REST API, errors of single fields come in its
+// errors with the rest of the data.
+func (controller *GraphQLControllerImpl) Execute(ctx *fiber.Ctx) error {
+	request := graphqlweb.Request{}
+	err := ctx.BodyParser(&request)
+	helper.PanicIfError(err)
+
+	response := controller.Schema.Exec(graph.NewContext(ctx), request.Query, request.OperationName, request.Variables)
+
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
@@ -0,0 +1,13 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type InvitationController interface {
+	Create(ctx *fiber.Ctx) error
+	GetAllByAddressBook(ctx *fiber.Ctx) error
+	GetAllReceived(ctx *fiber.Ctx) error
+	Accept(ctx *fiber.Ctx) error
+	Decline(ctx *fiber.Ctx) error
+}
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/addressbook"
+	"github.com/sorfian/go-contact-management-api/service"
+)
+
+type InvitationControllerImpl struct {
+	InvitationService service.InvitationService
+}
+
+func NewInvitationController(invitationService service.InvitationService) InvitationController {
+	return &InvitationControllerImpl{InvitationService: invitationService}
+}
+
+func (controller *InvitationControllerImpl) Create(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	addressBookID, err := strconv.ParseInt(ctx.Params("addressBookId"), 10, 64)
+	helper.PanicIfError(err)
+
+	request := addressbook.InvitationCreateRequest{}
+	err = ctx.BodyParser(&request)
+	helper.PanicIfError(err)
+
+	invitationResponse := controller.InvitationService.Create(ctx, *user, addressBookID, &request)
+
+	webResponse := web.Response{
+		Code:   201,
+		Status: "Created",
+		Data:   invitationResponse,
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(webResponse)
+}
+
+func (controller *InvitationControllerImpl) GetAllByAddressBook(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	addressBookID, err := strconv.ParseInt(ctx.Params("addressBookId"), 10, 64)
+	helper.PanicIfError(err)
+
+	invitationResponses := controller.InvitationService.GetAllByAddressBook(ctx, *user, addressBookID)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   invitationResponses,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *InvitationControllerImpl) GetAllReceived(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	invitationResponses := controller.InvitationService.GetAllReceived(ctx, *user)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   invitationResponses,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *InvitationControllerImpl) Accept(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	invitationID, err := strconv.ParseInt(ctx.Params("invitationId"), 10, 64)
+	helper.PanicIfError(err)
+
+	addressBookResponse := controller.InvitationService.Accept(ctx, *user, invitationID)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   addressBookResponse,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *InvitationControllerImpl) Decline(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	invitationID, err := strconv.ParseInt(ctx.Params("invitationId"), 10, 64)
+	helper.PanicIfError(err)
+
+	invitationResponse := controller.InvitationService.Decline(ctx, *user, invitationID)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   invitationResponse,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
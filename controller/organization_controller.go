@@ -0,0 +1,16 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type OrganizationController interface {
+	Create(ctx *fiber.Ctx) error
+	GetAll(ctx *fiber.Ctx) error
+	Get(ctx *fiber.Ctx) error
+	Update(ctx *fiber.Ctx) error
+	GetMembers(ctx *fiber.Ctx) error
+	AddMember(ctx *fiber.Ctx) error
+	UpdateMember(ctx *fiber.Ctx) error
+	RemoveMember(ctx *fiber.Ctx) error
+}
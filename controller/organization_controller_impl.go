@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/organization"
+	"github.com/sorfian/go-contact-management-api/service"
+)
+
+// OrganizationControllerImpl serves the organization routes. The organization
+// in the route is resolved by middleware.OrganizationMiddleware beforehand.
+type OrganizationControllerImpl struct {
+	OrganizationService service.OrganizationService
+}
+
+func NewOrganizationController(organizationService service.OrganizationService) OrganizationController {
+	return &OrganizationControllerImpl{OrganizationService: organizationService}
+}
+
+func (controller *OrganizationControllerImpl) Create(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	request := organization.OrganizationCreateRequest{}
+	err := ctx.BodyParser(&request)
+	helper.PanicIfError(err)
+
+	organizationResponse := controller.OrganizationService.Create(ctx, *user, &request)
+
+	webResponse := web.Response{
+		Code:   201,
+		Status: "Created",
+		Data:   organizationResponse,
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(webResponse)
+}
+
+func (controller *OrganizationControllerImpl) GetAll(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	organizationResponses := controller.OrganizationService.GetAll(ctx, *user)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   organizationResponses,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *OrganizationControllerImpl) Get(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	organizationResponse := controller.OrganizationService.Get(ctx, *user)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   organizationResponse,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *OrganizationControllerImpl) Update(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	patch, err := helper.ReadMergePatch(ctx)
+	helper.PanicIfError(err)
+
+	organizationResponse := controller.OrganizationService.Update(ctx, *user, patch)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   organizationResponse,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *OrganizationControllerImpl) GetMembers(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	memberResponses := controller.OrganizationService.GetMembers(ctx, *user)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   memberResponses,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *OrganizationControllerImpl) AddMember(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	request := organization.MemberCreateRequest{}
+	err := ctx.BodyParser(&request)
+	helper.PanicIfError(err)
+
+	memberResponse := controller.OrganizationService.AddMember(ctx, *user, &request)
+
+	webResponse := web.Response{
+		Code:   201,
+		Status: "Created",
+		Data:   memberResponse,
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(webResponse)
+}
+
+func (controller *OrganizationControllerImpl) UpdateMember(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	memberUserID, err := strconv.Atoi(ctx.Params("userId"))
+	helper.PanicIfError(err)
+
+	request := organization.MemberUpdateRequest{}
+	err = ctx.BodyParser(&request)
+	helper.PanicIfError(err)
+
+	memberResponse := controller.OrganizationService.UpdateMember(ctx, *user, memberUserID, &request)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   memberResponse,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *OrganizationControllerImpl) RemoveMember(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	memberUserID, err := strconv.Atoi(ctx.Params("userId"))
+	helper.PanicIfError(err)
+
+	controller.OrganizationService.RemoveMember(ctx, *user, memberUserID)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   "Member removed successfully",
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
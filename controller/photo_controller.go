@@ -0,0 +1,10 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type PhotoController interface {
+	Upload(ctx *fiber.Ctx) error
+	Serve(ctx *fiber.Ctx) error
+}
@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/service"
+)
+
+type PhotoControllerImpl struct {
+	PhotoService service.PhotoService
+}
+
+func NewPhotoController(photoService service.PhotoService) PhotoController {
+	return &PhotoControllerImpl{PhotoService: photoService}
+}
+
+// Upload takes the photo as the raw request body. Its type is sniffed from
+// the data, the Content-Type of the request is not trusted.
+func (controller *PhotoControllerImpl) Upload(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	contactID, err := strconv.ParseInt(ctx.Params("contactId"), 10, 64)
+	helper.PanicIfError(err)
+
+	expectedVersion, err := helper.ParseIfMatch(ctx.Get(fiber.HeaderIfMatch))
+	helper.PanicIfError(err)
+
+	contactResponse := controller.PhotoService.Upload(ctx, *user, contactID, ctx.Body(), expectedVersion)
+	ctx.Set(fiber.HeaderETag, helper.FormatETag(contactResponse.Version))
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   contactResponse,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+// Serve sends a thumbnail. A new photo is stored under a new key, so what is
+// under a key never changes and may be cached for good.
+func (controller *PhotoControllerImpl) Serve(ctx *fiber.Ctx) error {
+	data := controller.PhotoService.Read(ctx, ctx.Params("*"))
+
+	ctx.Set(fiber.HeaderContentType, helper.MIMEImageJPEG)
+	ctx.Set(fiber.HeaderCacheControl, "public, max-age=31536000, immutable")
+	return ctx.Send(data)
+}
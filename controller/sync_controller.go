@@ -0,0 +1,10 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type SyncController interface {
+	Pull(ctx *fiber.Ctx) error
+	Push(ctx *fiber.Ctx) error
+}
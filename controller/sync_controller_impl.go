@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/delta"
+	"github.com/sorfian/go-contact-management-api/service"
+)
+
+type SyncControllerImpl struct {
+	SyncService service.SyncService
+}
+
+func NewSyncController(syncService service.SyncService) SyncController {
+	return &SyncControllerImpl{SyncService: syncService}
+}
+
+func (controller *SyncControllerImpl) Pull(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	pullResponse := controller.SyncService.Pull(ctx, *user, ctx.Query("since"))
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   pullResponse,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *SyncControllerImpl) Push(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	request := delta.PushRequest{}
+	err := ctx.BodyParser(&request)
+	helper.PanicIfError(err)
+
+	pushResult := controller.SyncService.Push(ctx, *user, &request)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   pushResult,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
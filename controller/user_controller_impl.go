@@ -82,11 +82,10 @@ func (controller *UserControllerImpl) Update(ctx *fiber.Ctx) error {
 	// Get user from context (should be set by auth middleware)
 	newUser := ctx.Locals("user").(*domain.User)
 
-	var request user.UserUpdateRequest
-	err := ctx.BodyParser(&request)
+	patch, err := helper.ReadMergePatch(ctx)
 	helper.PanicIfError(err)
 
-	userResponse := controller.UserService.Update(ctx, *newUser, request)
+	userResponse := controller.UserService.Update(ctx, *newUser, patch)
 
 	webResponse := web.Response{
 		Code:   200,
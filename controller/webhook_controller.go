@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type WebhookController interface {
+	Create(ctx *fiber.Ctx) error
+	GetAll(ctx *fiber.Ctx) error
+	Get(ctx *fiber.Ctx) error
+	Update(ctx *fiber.Ctx) error
+	Delete(ctx *fiber.Ctx) error
+	GetDeliveries(ctx *fiber.Ctx) error
+	Redeliver(ctx *fiber.Ctx) error
+}
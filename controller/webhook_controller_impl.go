@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/webhook"
+	"github.com/sorfian/go-contact-management-api/service"
+)
+
+type WebhookControllerImpl struct {
+	WebhookService service.WebhookService
+}
+
+func NewWebhookController(webhookService service.WebhookService) WebhookController {
+	return &WebhookControllerImpl{WebhookService: webhookService}
+}
+
+func (controller *WebhookControllerImpl) Create(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	request := webhook.WebhookCreateRequest{}
+	err := ctx.BodyParser(&request)
+	helper.PanicIfError(err)
+
+	webhookResponse := controller.WebhookService.Create(ctx, *user, &request)
+
+	webResponse := web.Response{
+		Code:   201,
+		Status: "Created",
+		Data:   webhookResponse,
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(webResponse)
+}
+
+func (controller *WebhookControllerImpl) GetAll(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	webhookResponses := controller.WebhookService.GetAll(ctx, *user)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   webhookResponses,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *WebhookControllerImpl) Get(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	webhookID, err := strconv.ParseInt(ctx.Params("webhookId"), 10, 64)
+	helper.PanicIfError(err)
+
+	webhookResponse := controller.WebhookService.Get(ctx, *user, webhookID)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   webhookResponse,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *WebhookControllerImpl) Update(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	webhookID, err := strconv.ParseInt(ctx.Params("webhookId"), 10, 64)
+	helper.PanicIfError(err)
+
+	patch, err := helper.ReadMergePatch(ctx)
+	helper.PanicIfError(err)
+
+	webhookResponse := controller.WebhookService.Update(ctx, *user, webhookID, patch)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   webhookResponse,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *WebhookControllerImpl) Delete(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	webhookID, err := strconv.ParseInt(ctx.Params("webhookId"), 10, 64)
+	helper.PanicIfError(err)
+
+	controller.WebhookService.Delete(ctx, *user, webhookID)
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   "Webhook deleted successfully",
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *WebhookControllerImpl) GetDeliveries(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	webhookID, err := strconv.ParseInt(ctx.Params("webhookId"), 10, 64)
+	helper.PanicIfError(err)
+
+	deliveriesResult := controller.WebhookService.GetDeliveries(ctx, *user, webhookID, ctx.Query("status"), ctx.QueryInt("page", 1), ctx.QueryInt("size", 10))
+
+	webResponse := web.Response{
+		Code:   200,
+		Status: "OK",
+		Data:   deliveriesResult,
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(webResponse)
+}
+
+func (controller *WebhookControllerImpl) Redeliver(ctx *fiber.Ctx) error {
+	user := ctx.Locals("user").(*domain.User)
+
+	webhookID, err := strconv.ParseInt(ctx.Params("webhookId"), 10, 64)
+	helper.PanicIfError(err)
+
+	deliveryID, err := strconv.ParseInt(ctx.Params("deliveryId"), 10, 64)
+	helper.PanicIfError(err)
+
+	deliveryResponse := controller.WebhookService.Redeliver(ctx, *user, webhookID, deliveryID)
+
+	webResponse := web.Response{
+		Code:   201,
+		Status: "Created",
+		Data:   deliveryResponse,
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(webResponse)
+}
@@ -7,4 +7,15 @@ var Set = wire.NewSet(
 	NewUserController,
 	NewContactController,
 	NewAddressController,
+	NewAdminController,
+	NewAddressBookController,
+	NewInvitationController,
+	NewOrganizationController,
+	NewWebhookController,
+	NewEventStreamController,
+	NewCollaborationController,
+	NewSyncController,
+	NewCardDAVController,
+	NewGraphQLController,
+	NewPhotoController,
 )
@@ -0,0 +1,7 @@
+ALTER TABLE addresses
+    DROP INDEX idx_deletion_batch_id,
+    DROP COLUMN deletion_batch_id;
+
+ALTER TABLE contacts
+    DROP INDEX idx_deletion_batch_id,
+    DROP COLUMN deletion_batch_id;
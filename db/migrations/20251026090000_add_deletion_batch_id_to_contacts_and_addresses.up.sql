@@ -0,0 +1,7 @@
+ALTER TABLE contacts
+    ADD COLUMN deletion_batch_id CHAR(36) NULL AFTER deleted_at,
+    ADD INDEX idx_deletion_batch_id (deletion_batch_id);
+
+ALTER TABLE addresses
+    ADD COLUMN deletion_batch_id CHAR(36) NULL AFTER deleted_at,
+    ADD INDEX idx_deletion_batch_id (deletion_batch_id);
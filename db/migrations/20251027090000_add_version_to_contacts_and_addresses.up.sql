@@ -0,0 +1,5 @@
+ALTER TABLE contacts
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1 AFTER phone;
+
+ALTER TABLE addresses
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1 AFTER postal_code;
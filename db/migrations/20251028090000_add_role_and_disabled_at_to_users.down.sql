@@ -0,0 +1,4 @@
+ALTER TABLE users
+    DROP INDEX idx_role,
+    DROP COLUMN disabled_at,
+    DROP COLUMN role;
@@ -0,0 +1,4 @@
+ALTER TABLE users
+    ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user' AFTER `name`,
+    ADD COLUMN disabled_at TIMESTAMP NULL AFTER token_exp,
+    ADD INDEX idx_role (role);
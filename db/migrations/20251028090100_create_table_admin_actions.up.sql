@@ -0,0 +1,15 @@
+-- No foreign keys, the log outlives the users it mentions
+CREATE TABLE admin_actions
+(
+    id             BIGINT AUTO_INCREMENT PRIMARY KEY,
+    admin_id       INT          NULL,
+    target_user_id INT          NOT NULL,
+    action         VARCHAR(50)  NOT NULL,
+    details        VARCHAR(255) NOT NULL DEFAULT '',
+    created_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_admin_id (admin_id),
+    INDEX idx_target_user_id (target_user_id),
+    INDEX idx_created_at (created_at)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
@@ -0,0 +1,5 @@
+DROP TABLE address_book_invitations;
+
+DROP TABLE address_book_members;
+
+DROP TABLE address_books;
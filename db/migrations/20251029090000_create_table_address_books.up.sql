@@ -0,0 +1,47 @@
+CREATE TABLE address_books
+(
+    id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+    owner_id   INT          NOT NULL,
+    name       VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (owner_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    INDEX idx_owner_id (owner_id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
+
+CREATE TABLE address_book_members
+(
+    id              BIGINT AUTO_INCREMENT PRIMARY KEY,
+    address_book_id BIGINT      NOT NULL,
+    user_id         INT         NOT NULL,
+    permission      VARCHAR(10) NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (address_book_id) REFERENCES address_books (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    UNIQUE INDEX idx_address_book_user (address_book_id, user_id),
+    INDEX idx_user_id (user_id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
+
+CREATE TABLE address_book_invitations
+(
+    id              BIGINT AUTO_INCREMENT PRIMARY KEY,
+    address_book_id BIGINT      NOT NULL,
+    inviter_id      INT         NOT NULL,
+    invitee_id      INT         NOT NULL,
+    permission      VARCHAR(10) NOT NULL,
+    status          VARCHAR(10) NOT NULL DEFAULT 'pending',
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (address_book_id) REFERENCES address_books (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (inviter_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (invitee_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    INDEX idx_address_book_status (address_book_id, status),
+    INDEX idx_invitee_status (invitee_id, status)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
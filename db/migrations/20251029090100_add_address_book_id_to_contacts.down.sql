@@ -0,0 +1,5 @@
+ALTER TABLE contacts
+    DROP FOREIGN KEY fk_contacts_address_book,
+    DROP COLUMN address_book_id;
+
+DELETE FROM address_books;
@@ -0,0 +1,18 @@
+-- Every user gets an address book owning the contacts they already have
+INSERT INTO address_books (owner_id, name)
+SELECT id, 'Contacts'
+FROM users;
+
+INSERT INTO address_book_members (address_book_id, user_id, permission)
+SELECT id, owner_id, 'owner'
+FROM address_books;
+
+ALTER TABLE contacts
+    ADD COLUMN address_book_id BIGINT NULL AFTER user_id;
+
+UPDATE contacts
+SET address_book_id = (SELECT address_books.id FROM address_books WHERE address_books.owner_id = contacts.user_id);
+
+ALTER TABLE contacts
+    MODIFY address_book_id BIGINT NOT NULL,
+    ADD CONSTRAINT fk_contacts_address_book FOREIGN KEY (address_book_id) REFERENCES address_books (id) ON DELETE CASCADE ON UPDATE CASCADE;
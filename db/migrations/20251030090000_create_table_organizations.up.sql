@@ -0,0 +1,36 @@
+CREATE TABLE organizations
+(
+    id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name       VARCHAR(100) NOT NULL,
+    settings   JSON         NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
+
+CREATE TABLE organization_members
+(
+    id              BIGINT AUTO_INCREMENT PRIMARY KEY,
+    organization_id BIGINT      NOT NULL,
+    user_id         INT         NOT NULL,
+    role            VARCHAR(10) NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    UNIQUE INDEX idx_organization_user (organization_id, user_id),
+    INDEX idx_user_id (user_id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
+
+-- Every user gets a personal organization, numbered like the user so that
+-- the next migration can move their data into it
+INSERT INTO organizations (id, name)
+SELECT id, 'Personal'
+FROM users;
+
+INSERT INTO organization_members (organization_id, user_id, role)
+SELECT id, id, 'owner'
+FROM users;
@@ -0,0 +1,19 @@
+ALTER TABLE addresses
+    DROP FOREIGN KEY fk_addresses_organization,
+    DROP COLUMN organization_id;
+
+ALTER TABLE contacts
+    DROP FOREIGN KEY fk_contacts_organization,
+    DROP COLUMN organization_id;
+
+ALTER TABLE address_book_invitations
+    DROP FOREIGN KEY fk_address_book_invitations_organization,
+    DROP COLUMN organization_id;
+
+ALTER TABLE address_book_members
+    DROP FOREIGN KEY fk_address_book_members_organization,
+    DROP COLUMN organization_id;
+
+ALTER TABLE address_books
+    DROP FOREIGN KEY fk_address_books_organization,
+    DROP COLUMN organization_id;
@@ -0,0 +1,51 @@
+-- Address books move into the personal organization of their owner, and
+-- everything inside them follows
+ALTER TABLE address_books
+    ADD COLUMN organization_id BIGINT NULL AFTER id;
+
+UPDATE address_books
+SET organization_id = owner_id;
+
+ALTER TABLE address_book_members
+    ADD COLUMN organization_id BIGINT NULL AFTER id;
+
+UPDATE address_book_members
+SET organization_id = (SELECT address_books.organization_id FROM address_books WHERE address_books.id = address_book_members.address_book_id);
+
+ALTER TABLE address_book_invitations
+    ADD COLUMN organization_id BIGINT NULL AFTER id;
+
+UPDATE address_book_invitations
+SET organization_id = (SELECT address_books.organization_id FROM address_books WHERE address_books.id = address_book_invitations.address_book_id);
+
+ALTER TABLE contacts
+    ADD COLUMN organization_id BIGINT NULL AFTER id;
+
+UPDATE contacts
+SET organization_id = (SELECT address_books.organization_id FROM address_books WHERE address_books.id = contacts.address_book_id);
+
+ALTER TABLE addresses
+    ADD COLUMN organization_id BIGINT NULL AFTER id;
+
+UPDATE addresses
+SET organization_id = (SELECT contacts.organization_id FROM contacts WHERE contacts.id = addresses.contact_id);
+
+ALTER TABLE address_books
+    MODIFY organization_id BIGINT NOT NULL,
+    ADD CONSTRAINT fk_address_books_organization FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE address_book_members
+    MODIFY organization_id BIGINT NOT NULL,
+    ADD CONSTRAINT fk_address_book_members_organization FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE address_book_invitations
+    MODIFY organization_id BIGINT NOT NULL,
+    ADD CONSTRAINT fk_address_book_invitations_organization FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE contacts
+    MODIFY organization_id BIGINT NOT NULL,
+    ADD CONSTRAINT fk_contacts_organization FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE addresses
+    MODIFY organization_id BIGINT NOT NULL,
+    ADD CONSTRAINT fk_addresses_organization FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE ON UPDATE CASCADE;
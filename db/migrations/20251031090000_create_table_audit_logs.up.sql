@@ -0,0 +1,32 @@
+-- No foreign keys, the log outlives the users and organizations it mentions
+CREATE TABLE audit_logs
+(
+    id              BIGINT AUTO_INCREMENT PRIMARY KEY,
+    organization_id BIGINT       NULL,
+    actor_id        INT          NULL,
+    action          VARCHAR(10)  NOT NULL,
+    entity_type     VARCHAR(20)  NOT NULL,
+    entity_id       BIGINT       NOT NULL,
+    changes         JSON         NOT NULL,
+    request_id      VARCHAR(100) NOT NULL DEFAULT '',
+    ip              VARCHAR(45)  NOT NULL DEFAULT '',
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_organization_id (organization_id),
+    INDEX idx_actor_id (actor_id),
+    INDEX idx_entity (entity_type, entity_id),
+    INDEX idx_request_id (request_id),
+    INDEX idx_created_at (created_at)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
+
+-- Audit logs are append only
+CREATE TRIGGER audit_logs_no_update
+    BEFORE UPDATE
+    ON audit_logs
+    FOR EACH ROW BEGIN SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit logs cannot be changed'; END;
+
+CREATE TRIGGER audit_logs_no_delete
+    BEFORE DELETE
+    ON audit_logs
+    FOR EACH ROW BEGIN SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit logs cannot be deleted'; END;
@@ -0,0 +1,41 @@
+CREATE TABLE contact_versions
+(
+    id              BIGINT AUTO_INCREMENT PRIMARY KEY,
+    organization_id BIGINT    NOT NULL,
+    contact_id      BIGINT    NOT NULL,
+    number          INT       NOT NULL,
+    contact_version BIGINT    NOT NULL,
+    actor_id        INT       NULL,
+    snapshot        JSON      NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE INDEX uq_contact_versions_contact_number (contact_id, number),
+    CONSTRAINT fk_contact_versions_contact FOREIGN KEY (contact_id) REFERENCES contacts (id) ON DELETE CASCADE,
+    CONSTRAINT fk_contact_versions_organization FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
+
+-- Existing contacts start with their current state as version 1
+INSERT INTO contact_versions (organization_id, contact_id, number, contact_version, snapshot)
+SELECT c.organization_id,
+       c.id,
+       1,
+       c.version,
+       JSON_OBJECT(
+               'address_book_id', c.address_book_id,
+               'first_name', c.first_name,
+               'last_name', c.last_name,
+               'email', c.email,
+               'phone', c.phone,
+               'addresses', COALESCE((SELECT JSON_ARRAYAGG(JSON_OBJECT(
+                                                     'id', a.id,
+                                                     'street', a.street,
+                                                     'city', a.city,
+                                                     'province', a.province,
+                                                     'country', a.country,
+                                                     'postal_code', a.postal_code))
+                                      FROM addresses a
+                                      WHERE a.contact_id = c.id
+                                        AND a.deleted_at IS NULL), JSON_ARRAY()))
+FROM contacts c
+WHERE c.deleted_at IS NULL;
@@ -0,0 +1,43 @@
+CREATE TABLE webhooks
+(
+    id              BIGINT AUTO_INCREMENT PRIMARY KEY,
+    organization_id BIGINT        NOT NULL,
+    created_by      INT           NULL,
+    url             VARCHAR(2048) NOT NULL,
+    secret          VARCHAR(255)  NOT NULL,
+    events          JSON          NOT NULL,
+    active          BOOLEAN       NOT NULL DEFAULT TRUE,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_organization_id (organization_id),
+    CONSTRAINT fk_webhooks_organization FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE,
+    CONSTRAINT fk_webhooks_created_by FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
+
+-- The delivery queue and log. Pending deliveries are sent once next_attempt_at
+-- has passed, finished ones keep the outcome of their last attempt.
+CREATE TABLE webhook_deliveries
+(
+    id              BIGINT AUTO_INCREMENT PRIMARY KEY,
+    organization_id BIGINT       NOT NULL,
+    webhook_id      BIGINT       NOT NULL,
+    event_id        VARCHAR(36)  NOT NULL,
+    event           VARCHAR(50)  NOT NULL,
+    payload         JSON         NOT NULL,
+    status          VARCHAR(10)  NOT NULL DEFAULT 'pending',
+    attempts        INT          NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP    NULL,
+    response_status INT          NOT NULL DEFAULT 0,
+    error           VARCHAR(255) NOT NULL DEFAULT '',
+    delivered_at    TIMESTAMP    NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_webhook_id (webhook_id),
+    INDEX idx_status_next_attempt_at (status, next_attempt_at),
+    CONSTRAINT fk_webhook_deliveries_webhook FOREIGN KEY (webhook_id) REFERENCES webhooks (id) ON DELETE CASCADE,
+    CONSTRAINT fk_webhook_deliveries_organization FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
@@ -0,0 +1,20 @@
+-- Domain events recorded with the change that raised them. No foreign keys,
+-- an event outlives the rows it mentions until it is dispatched.
+CREATE TABLE outbox_events
+(
+    id              BIGINT AUTO_INCREMENT PRIMARY KEY,
+    event_id        VARCHAR(36)  NOT NULL,
+    name            VARCHAR(50)  NOT NULL,
+    organization_id BIGINT       NULL,
+    actor_id        INT          NULL,
+    payload         JSON         NOT NULL,
+    attempts        INT          NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP    NULL,
+    error           VARCHAR(255) NOT NULL DEFAULT '',
+    dispatched_at   TIMESTAMP    NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE INDEX uq_outbox_events_event_id (event_id),
+    INDEX idx_dispatched_at_next_attempt_at (dispatched_at, next_attempt_at)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
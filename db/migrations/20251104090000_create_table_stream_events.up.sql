@@ -0,0 +1,18 @@
+-- The contact and address changes served by the live event stream, in the
+-- order they were dispatched. The ID is the SSE event ID clients resume from.
+-- No foreign keys, a change is recorded even when its address book or
+-- organization was deleted in the meantime.
+CREATE TABLE stream_events
+(
+    id              BIGINT AUTO_INCREMENT PRIMARY KEY,
+    event_id        VARCHAR(36) NOT NULL,
+    organization_id BIGINT      NOT NULL,
+    address_book_id BIGINT      NOT NULL,
+    name            VARCHAR(50) NOT NULL,
+    payload         JSON        NOT NULL,
+    occurred_at     TIMESTAMP   NOT NULL,
+    UNIQUE INDEX uq_stream_events_event_id (event_id),
+    INDEX idx_organization_id_address_book_id (organization_id, address_book_id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
@@ -0,0 +1,19 @@
+-- Who is looking at which contact over a collaboration connection. A row is
+-- kept alive by its connection and ignored once expires_at has passed, so a
+-- process that died does not leave viewers behind.
+CREATE TABLE contact_viewers
+(
+    id              BIGINT AUTO_INCREMENT PRIMARY KEY,
+    organization_id BIGINT      NOT NULL,
+    contact_id      BIGINT      NOT NULL,
+    user_id         INT         NOT NULL,
+    connection_id   VARCHAR(36) NOT NULL,
+    expires_at      TIMESTAMP   NOT NULL,
+    UNIQUE INDEX uq_contact_viewers_connection_id_contact_id (connection_id, contact_id),
+    INDEX idx_contact_id_expires_at (contact_id, expires_at),
+    CONSTRAINT fk_contact_viewers_organization FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE,
+    CONSTRAINT fk_contact_viewers_contact FOREIGN KEY (contact_id) REFERENCES contacts (id) ON DELETE CASCADE,
+    CONSTRAINT fk_contact_viewers_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
@@ -0,0 +1,4 @@
+ALTER TABLE contacts
+    DROP INDEX idx_contacts_vcard_name,
+    DROP COLUMN vcard_uid,
+    DROP COLUMN vcard_name;
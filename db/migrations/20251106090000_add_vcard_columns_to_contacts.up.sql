@@ -0,0 +1,6 @@
+-- CardDAV clients name the cards they create and give them a UID of their
+-- own. Contacts created otherwise have neither and are named by their ID.
+ALTER TABLE contacts
+    ADD COLUMN vcard_name VARCHAR(255) NULL AFTER `version`,
+    ADD COLUMN vcard_uid  VARCHAR(255) NULL AFTER vcard_name,
+    ADD INDEX idx_contacts_vcard_name (address_book_id, vcard_name);
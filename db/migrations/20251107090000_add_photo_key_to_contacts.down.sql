@@ -0,0 +1,2 @@
+ALTER TABLE contacts
+    DROP COLUMN photo_key;
@@ -0,0 +1,5 @@
+-- The thumbnails of a contact's photo are stored as blobs under this key,
+-- a new one for every upload. Added as the last column, which MySQL can add
+-- without copying the table.
+ALTER TABLE contacts
+    ADD COLUMN photo_key VARCHAR(255) NULL;
@@ -0,0 +1,22 @@
+UPDATE contacts
+SET email = COALESCE(email, ''),
+    phone = COALESCE(phone, '')
+WHERE email IS NULL
+   OR phone IS NULL;
+
+UPDATE addresses
+SET street      = COALESCE(street, ''),
+    province    = COALESCE(province, ''),
+    postal_code = COALESCE(postal_code, '')
+WHERE street IS NULL
+   OR province IS NULL
+   OR postal_code IS NULL;
+
+ALTER TABLE contacts
+    MODIFY email VARCHAR(100) NOT NULL,
+    MODIFY phone VARCHAR(20)  NOT NULL;
+
+ALTER TABLE addresses
+    MODIFY street      VARCHAR(200) NOT NULL,
+    MODIFY province    VARCHAR(100) NOT NULL,
+    MODIFY postal_code VARCHAR(10)  NOT NULL;
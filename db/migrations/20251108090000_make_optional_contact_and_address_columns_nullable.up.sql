@@ -0,0 +1,10 @@
+-- Email and phone of a contact and street, province and postal code of an
+-- address are optional, NULL when they are left out or cleared.
+ALTER TABLE contacts
+    MODIFY email VARCHAR(100) NULL,
+    MODIFY phone VARCHAR(20)  NULL;
+
+ALTER TABLE addresses
+    MODIFY street      VARCHAR(200) NULL,
+    MODIFY province    VARCHAR(100) NULL,
+    MODIFY postal_code VARCHAR(10)  NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE contacts
+    DROP INDEX idx_contacts_previous_address_book_id,
+    DROP COLUMN previous_address_book_id;
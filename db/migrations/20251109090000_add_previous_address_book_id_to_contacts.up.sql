@@ -0,0 +1,5 @@
+-- The address book a contact was last moved out of. Members of it learn from
+-- a sync that the contact is gone, without reading other address books.
+ALTER TABLE contacts
+    ADD COLUMN previous_address_book_id BIGINT NULL,
+    ADD INDEX idx_contacts_previous_address_book_id (previous_address_book_id);
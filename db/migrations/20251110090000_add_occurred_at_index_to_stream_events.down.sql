@@ -0,0 +1,2 @@
+ALTER TABLE stream_events
+    DROP INDEX idx_stream_events_occurred_at;
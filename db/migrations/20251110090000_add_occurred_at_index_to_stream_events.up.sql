@@ -0,0 +1,3 @@
+-- The dispatcher deletes the stream events past the retention by occurred_at
+ALTER TABLE stream_events
+    ADD INDEX idx_stream_events_occurred_at (occurred_at);
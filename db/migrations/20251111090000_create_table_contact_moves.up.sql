@@ -0,0 +1,23 @@
+-- Every move of a contact between address books. Members of each address
+-- book a contact left learn from a sync that it is gone, however often it
+-- moved on since.
+CREATE TABLE contact_moves
+(
+    id                   BIGINT AUTO_INCREMENT PRIMARY KEY,
+    organization_id      BIGINT    NOT NULL,
+    contact_id           BIGINT    NOT NULL,
+    from_address_book_id BIGINT    NOT NULL,
+    to_address_book_id   BIGINT    NOT NULL,
+    moved_at             TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_contact_moves_from_address_book_id_moved_at (from_address_book_id, moved_at),
+    CONSTRAINT fk_contact_moves_contact FOREIGN KEY (contact_id) REFERENCES contacts (id) ON DELETE CASCADE,
+    CONSTRAINT fk_contact_moves_organization FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
+
+-- The last move of a contact is all that was recorded so far
+INSERT INTO contact_moves (organization_id, contact_id, from_address_book_id, to_address_book_id, moved_at)
+SELECT organization_id, id, previous_address_book_id, address_book_id, updated_at
+FROM contacts
+WHERE previous_address_book_id IS NOT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE contacts
+    ADD COLUMN previous_address_book_id BIGINT NULL,
+    ADD INDEX idx_contacts_previous_address_book_id (previous_address_book_id);
@@ -0,0 +1,70 @@
+package event
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Handler handles one event. Like the services it reports failures by
+// panicking, the dispatcher then delivers the event again later. Events can
+// be delivered more than once, so handlers have to be idempotent.
+type Handler func(ctx *fiber.Ctx, message Message)
+
+// Bus delivers events to the handlers subscribed to them, in the order they
+// subscribed
+type Bus struct {
+	mutex       sync.RWMutex
+	nextID      int
+	subscribers map[string]map[int]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{subscribers: map[string]map[int]Handler{}}
+}
+
+// Subscribe has handler called for every event named name and returns a
+// function that cancels the subscription
+func (bus *Bus) Subscribe(name string, handler Handler) func() {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	bus.nextID++
+	id := bus.nextID
+	if bus.subscribers[name] == nil {
+		bus.subscribers[name] = map[int]Handler{}
+	}
+	bus.subscribers[name][id] = handler
+
+	return func() {
+		bus.mutex.Lock()
+		defer bus.mutex.Unlock()
+		delete(bus.subscribers[name], id)
+	}
+}
+
+// Publish calls the handlers of the event one after the other. A panicking
+// handler stops the handlers after it.
+func (bus *Bus) Publish(ctx *fiber.Ctx, message Message) {
+	for _, handler := range bus.handlers(message.Event.Name()) {
+		handler(ctx, message)
+	}
+}
+
+func (bus *Bus) handlers(name string) []Handler {
+	bus.mutex.RLock()
+	defer bus.mutex.RUnlock()
+
+	ids := make([]int, 0, len(bus.subscribers[name]))
+	for id := range bus.subscribers[name] {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	handlers := make([]Handler, 0, len(ids))
+	for _, id := range ids {
+		handlers = append(handlers, bus.subscribers[name][id])
+	}
+	return handlers
+}
@@ -0,0 +1,112 @@
+// Package event defines the domain events services record in the outbox and
+// the bus that delivers them to in-process subscribers.
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sorfian/go-contact-management-api/model/web/address"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+)
+
+// Event names, <entity>.<what happened>
+const (
+	NameContactCreated = "contact.created"
+	NameContactUpdated = "contact.updated"
+	NameContactDeleted = "contact.deleted"
+	NameAddressCreated = "address.created"
+	NameAddressUpdated = "address.updated"
+	NameAddressDeleted = "address.deleted"
+	NameUserLoggedIn   = "user.logged_in"
+)
+
+// Event is something that happened in a service. It is stored as JSON, so it
+// has to round trip through encoding/json.
+type Event interface {
+	Name() string
+}
+
+// Message is an event as the bus delivers it, with what the outbox recorded
+// about it. ID is the same every time the event is delivered again.
+type Message struct {
+	ID             string
+	OrganizationID *int64
+	ActorID        *int
+	OccurredAt     time.Time
+	Event          Event
+}
+
+// ContactCreated, ContactUpdated and ContactDeleted carry the contact as it
+// is after the change, or as it was before it was deleted
+type ContactCreated struct {
+	contact.ContactResponse
+}
+
+type ContactUpdated struct {
+	contact.ContactResponse
+}
+
+type ContactDeleted struct {
+	contact.ContactResponse
+}
+
+// AddressCreated, AddressUpdated and AddressDeleted carry the address like the
+// contact events, and the contact and address book the address belongs to
+type AddressCreated struct {
+	ContactID     int64                   `json:"contact_id"`
+	AddressBookID int64                   `json:"address_book_id"`
+	Address       address.AddressResponse `json:"address"`
+}
+
+type AddressUpdated struct {
+	ContactID     int64                   `json:"contact_id"`
+	AddressBookID int64                   `json:"address_book_id"`
+	Address       address.AddressResponse `json:"address"`
+}
+
+type AddressDeleted struct {
+	ContactID     int64                   `json:"contact_id"`
+	AddressBookID int64                   `json:"address_book_id"`
+	Address       address.AddressResponse `json:"address"`
+}
+
+type UserLoggedIn struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+}
+
+func (ContactCreated) Name() string { return NameContactCreated }
+func (ContactUpdated) Name() string { return NameContactUpdated }
+func (ContactDeleted) Name() string { return NameContactDeleted }
+func (AddressCreated) Name() string { return NameAddressCreated }
+func (AddressUpdated) Name() string { return NameAddressUpdated }
+func (AddressDeleted) Name() string { return NameAddressDeleted }
+func (UserLoggedIn) Name() string   { return NameUserLoggedIn }
+
+// decoders returns an empty event for every name, to decode the outbox into
+var decoders = map[string]func() Event{
+	NameContactCreated: func() Event { return &ContactCreated{} },
+	NameContactUpdated: func() Event { return &ContactUpdated{} },
+	NameContactDeleted: func() Event { return &ContactDeleted{} },
+	NameAddressCreated: func() Event { return &AddressCreated{} },
+	NameAddressUpdated: func() Event { return &AddressUpdated{} },
+	NameAddressDeleted: func() Event { return &AddressDeleted{} },
+	NameUserLoggedIn:   func() Event { return &UserLoggedIn{} },
+}
+
+// Decode turns a stored payload back into the event named name. Subscribers
+// get a pointer to the event type.
+func Decode(name string, payload []byte) (Event, error) {
+	decoder, ok := decoders[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown event %q", name)
+	}
+
+	decoded := decoder()
+	if err := json.Unmarshal(payload, decoded); err != nil {
+		return nil, fmt.Errorf("decode event %q: %w", name, err)
+	}
+	return decoded, nil
+}
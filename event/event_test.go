@@ -0,0 +1,40 @@
+package event
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/web/address"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	recorded := AddressUpdated{ContactID: 7, Address: address.AddressResponse{ID: 3, City: "Jakarta"}}
+	payload, err := json.Marshal(recorded)
+	assert.NoError(t, err)
+
+	decoded, err := Decode(NameAddressUpdated, payload)
+	assert.NoError(t, err)
+	assert.Equal(t, &recorded, decoded)
+
+	_, err = Decode("contact.archived", payload)
+	assert.Error(t, err)
+}
+
+func TestBusPublishesToSubscribersInOrder(t *testing.T) {
+	bus := NewBus()
+	var calls []string
+	bus.Subscribe(NameUserLoggedIn, func(ctx *fiber.Ctx, message Message) { calls = append(calls, "first") })
+	unsubscribe := bus.Subscribe(NameUserLoggedIn, func(ctx *fiber.Ctx, message Message) { calls = append(calls, "second") })
+	bus.Subscribe(NameUserLoggedIn, func(ctx *fiber.Ctx, message Message) { calls = append(calls, "third") })
+	bus.Subscribe(NameContactCreated, func(ctx *fiber.Ctx, message Message) { calls = append(calls, "contact") })
+
+	bus.Publish(nil, Message{Event: UserLoggedIn{UserID: 1}})
+	assert.Equal(t, []string{"first", "second", "third"}, calls)
+
+	calls = nil
+	unsubscribe()
+	bus.Publish(nil, Message{Event: &UserLoggedIn{UserID: 1}})
+	assert.Equal(t, []string{"first", "third"}, calls)
+}
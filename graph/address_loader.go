@@ -0,0 +1,63 @@
+package graph
+
+import (
+	"sync"
+
+	"github.com/sorfian/go-contact-management-api/model/web/address"
+)
+
+// addressLoader batches loading the addresses of contacts. The contacts a
+// field returns share one loader and are added to it together, then the first
+// of them asked for its addresses loads those of all of them at once.
+type addressLoader struct {
+	fetch func(contactIDs []int64) map[int64][]address.AddressResponse
+
+	mutex   sync.Mutex
+	pending []int64
+	loaded  map[int64][]address.AddressResponse
+}
+
+func newAddressLoader(fetch func(contactIDs []int64) map[int64][]address.AddressResponse) *addressLoader {
+	return &addressLoader{fetch: fetch, loaded: map[int64][]address.AddressResponse{}}
+}
+
+// Add queues contacts for the next batch
+func (loader *addressLoader) Add(contactIDs ...int64) {
+	loader.mutex.Lock()
+	defer loader.mutex.Unlock()
+
+	loader.pending = append(loader.pending, contactIDs...)
+}
+
+// Load returns the addresses of the contact, loading them with the queued
+// contacts unless an earlier batch did
+func (loader *addressLoader) Load(contactID int64) []address.AddressResponse {
+	loader.mutex.Lock()
+	defer loader.mutex.Unlock()
+
+	if addresses, ok := loader.loaded[contactID]; ok {
+		return addresses
+	}
+
+	batch := []int64{contactID}
+	batched := map[int64]bool{contactID: true}
+	for _, pendingID := range loader.pending {
+		if _, ok := loader.loaded[pendingID]; !ok && !batched[pendingID] {
+			batch = append(batch, pendingID)
+			batched[pendingID] = true
+		}
+	}
+	loader.pending = nil
+
+	addresses := loader.fetch(batch)
+	for _, batchID := range batch {
+		// Contacts without addresses are loaded too
+		batchAddresses := addresses[batchID]
+		if batchAddresses == nil {
+			batchAddresses = []address.AddressResponse{}
+		}
+		loader.loaded[batchID] = batchAddresses
+	}
+
+	return loader.loaded[contactID]
+}
@@ -0,0 +1,34 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/sorfian/go-contact-management-api/model/web/address"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressLoaderBatches(t *testing.T) {
+	batches := [][]int64{}
+	loader := newAddressLoader(func(contactIDs []int64) map[int64][]address.AddressResponse {
+		batches = append(batches, contactIDs)
+		addresses := map[int64][]address.AddressResponse{}
+		for _, contactID := range contactIDs {
+			if contactID != 2 {
+				addresses[contactID] = []address.AddressResponse{{ID: contactID * 10}}
+			}
+		}
+		return addresses
+	})
+
+	loader.Add(1, 2, 3, 3)
+	assert.Equal(t, []address.AddressResponse{}, loader.Load(2))
+	assert.Equal(t, []address.AddressResponse{{ID: 10}}, loader.Load(1))
+	assert.Equal(t, []address.AddressResponse{{ID: 30}}, loader.Load(3))
+	assert.Equal(t, [][]int64{{2, 1, 3}}, batches)
+
+	// Contacts added later make a batch of their own
+	loader.Add(4, 1)
+	assert.Equal(t, []address.AddressResponse{{ID: 40}}, loader.Load(4))
+	assert.Equal(t, []address.AddressResponse{{ID: 50}}, loader.Load(5))
+	assert.Equal(t, [][]int64{{2, 1, 3}, {4}, {5}}, batches)
+}
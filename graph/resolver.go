@@ -0,0 +1,259 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/web/address"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+	"github.com/sorfian/go-contact-management-api/service"
+)
+
+// Resolver resolves the fields of Query and Mutation with the same services
+// the REST controllers call, so authorization, auditing and events are the
+// same for both
+type Resolver struct {
+	ContactService service.ContactService
+	AddressService service.AddressService
+	UserService    service.UserService
+}
+
+type contactInput struct {
+	AddressBookID *graphql.ID
+	FirstName     string
+	LastName      string
+	Email         string
+	Phone         string
+}
+
+type contactUpdateInput struct {
+	AddressBookID *graphql.ID
+	FirstName     *string
+	LastName      *string
+	Email         *string
+	Phone         *string
+}
+
+type addressInput struct {
+	Street     string
+	City       string
+	Province   string
+	Country    string
+	PostalCode string
+}
+
+type addressUpdateInput struct {
+	Street     *string
+	City       *string
+	Province   *string
+	Country    *string
+	PostalCode *string
+}
+
+func (resolver *Resolver) Me(ctx context.Context) *userResolver {
+	fiberCtx, user := request(ctx)
+	return &userResolver{user: resolver.UserService.Get(fiberCtx, user)}
+}
+
+func (resolver *Resolver) Contact(ctx context.Context, args struct{ ID graphql.ID }) *contactResolver {
+	fiberCtx, user := request(ctx)
+	contactResponse := resolver.ContactService.Get(fiberCtx, user, parseID(args.ID), nil, nil)
+	return resolver.contactResolvers(ctx, contactResponse)[0]
+}
+
+func (resolver *Resolver) Contacts(ctx context.Context, args struct {
+	AddressBookID *graphql.ID
+	Name          *string
+	Phone         *string
+	Email         *string
+	Page          int32
+	Size          int32
+}) *contactPageResolver {
+	fiberCtx, user := request(ctx)
+
+	// The REST API has these checked against its spec
+	if args.Page < 1 {
+		panic(helper.NewBadRequestError("page must be at least 1"))
+	}
+	if args.Size < 1 || args.Size > 100 {
+		panic(helper.NewBadRequestError("size must be between 1 and 100"))
+	}
+
+	searchParams := contact.SearchParams{
+		Name:  stringValue(args.Name),
+		Phone: stringValue(args.Phone),
+		Email: stringValue(args.Email),
+		Page:  int(args.Page),
+		Size:  int(args.Size),
+	}
+	if args.AddressBookID != nil {
+		searchParams.AddressBookID = parseID(*args.AddressBookID)
+	}
+
+	searchResult := resolver.ContactService.GetAll(fiberCtx, user, searchParams)
+	return &contactPageResolver{
+		contacts: resolver.contactResolvers(ctx, searchResult.Contacts...),
+		paging:   searchResult.Paging,
+	}
+}
+
+func (resolver *Resolver) Address(ctx context.Context, args struct {
+	ContactID graphql.ID
+	ID        graphql.ID
+}) *addressResolver {
+	fiberCtx, user := request(ctx)
+	return &addressResolver{address: resolver.AddressService.Get(fiberCtx, user, parseID(args.ContactID), parseID(args.ID), nil)}
+}
+
+func (resolver *Resolver) CreateContact(ctx context.Context, args struct{ Input contactInput }) *contactResolver {
+	fiberCtx, user := request(ctx)
+
+	createRequest := contact.ContactCreateRequest{
+		FirstName: args.Input.FirstName,
+		LastName:  args.Input.LastName,
+		Email:     args.Input.Email,
+		Phone:     args.Input.Phone,
+	}
+	if args.Input.AddressBookID != nil {
+		createRequest.AddressBookID = parseID(*args.Input.AddressBookID)
+	}
+
+	contactResponse := resolver.ContactService.Create(fiberCtx, user, &createRequest)
+	return resolver.contactResolvers(ctx, contactResponse)[0]
+}
+
+func (resolver *Resolver) UpdateContact(ctx context.Context, args struct {
+	ID      graphql.ID
+	Input   contactUpdateInput
+	Version *int32
+}) *contactResolver {
+	fiberCtx, user := request(ctx)
+
+	// The fields given are applied like a JSON merge patch
+	patch := map[string]interface{}{}
+	if args.Input.AddressBookID != nil {
+		patch["address_book_id"] = parseID(*args.Input.AddressBookID)
+	}
+	setPatchField(patch, "first_name", args.Input.FirstName)
+	setPatchField(patch, "last_name", args.Input.LastName)
+	setPatchField(patch, "email", args.Input.Email)
+	setPatchField(patch, "phone", args.Input.Phone)
+
+	contactResponse := resolver.ContactService.Update(fiberCtx, user, parseID(args.ID), marshalPatch(patch), versionValue(args.Version))
+	return resolver.contactResolvers(ctx, contactResponse)[0]
+}
+
+func (resolver *Resolver) DeleteContact(ctx context.Context, args struct {
+	ID      graphql.ID
+	Version *int32
+}) bool {
+	fiberCtx, user := request(ctx)
+	resolver.ContactService.Delete(fiberCtx, user, parseID(args.ID), versionValue(args.Version))
+	return true
+}
+
+func (resolver *Resolver) CreateAddress(ctx context.Context, args struct {
+	ContactID graphql.ID
+	Input     addressInput
+}) *addressResolver {
+	fiberCtx, user := request(ctx)
+
+	createRequest := address.AddressCreateRequest{
+		Street:     args.Input.Street,
+		City:       args.Input.City,
+		Province:   args.Input.Province,
+		Country:    args.Input.Country,
+		PostalCode: args.Input.PostalCode,
+	}
+
+	return &addressResolver{address: resolver.AddressService.Create(fiberCtx, user, parseID(args.ContactID), &createRequest)}
+}
+
+func (resolver *Resolver) UpdateAddress(ctx context.Context, args struct {
+	ContactID graphql.ID
+	ID        graphql.ID
+	Input     addressUpdateInput
+	Version   *int32
+}) *addressResolver {
+	fiberCtx, user := request(ctx)
+
+	patch := map[string]interface{}{}
+	setPatchField(patch, "street", args.Input.Street)
+	setPatchField(patch, "city", args.Input.City)
+	setPatchField(patch, "province", args.Input.Province)
+	setPatchField(patch, "country", args.Input.Country)
+	setPatchField(patch, "postal_code", args.Input.PostalCode)
+
+	addressResponse := resolver.AddressService.Update(fiberCtx, user, parseID(args.ContactID), parseID(args.ID), marshalPatch(patch), versionValue(args.Version))
+	return &addressResolver{address: addressResponse}
+}
+
+func (resolver *Resolver) DeleteAddress(ctx context.Context, args struct {
+	ContactID graphql.ID
+	ID        graphql.ID
+	Version   *int32
+}) bool {
+	fiberCtx, user := request(ctx)
+	resolver.AddressService.Delete(fiberCtx, user, parseID(args.ContactID), parseID(args.ID), versionValue(args.Version))
+	return true
+}
+
+// contactResolvers resolves contacts returned together, which load their
+// addresses in one batch
+func (resolver *Resolver) contactResolvers(ctx context.Context, contactResponses ...contact.ContactResponse) []*contactResolver {
+	fiberCtx, user := request(ctx)
+	loader := newAddressLoader(func(contactIDs []int64) map[int64][]address.AddressResponse {
+		return resolver.AddressService.GetAllByContacts(fiberCtx, user, contactIDs)
+	})
+
+	contactResolvers := make([]*contactResolver, 0, len(contactResponses))
+	for _, contactResponse := range contactResponses {
+		loader.Add(contactResponse.ID)
+		contactResolvers = append(contactResolvers, &contactResolver{contact: contactResponse, addresses: loader})
+	}
+	return contactResolvers
+}
+
+// parseID parses the ID of a contact, address or address book. IDs that are
+// not numbers match nothing.
+func parseID(id graphql.ID) int64 {
+	value, err := strconv.ParseInt(string(id), 10, 64)
+	if err != nil {
+		panic(helper.NewNotFoundError("invalid id " + strconv.Quote(string(id))))
+	}
+	return value
+}
+
+func formatID(id int64) graphql.ID {
+	return graphql.ID(strconv.FormatInt(id, 10))
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// versionValue returns the version a change expects, 0 for any
+func versionValue(version *int32) int64 {
+	if version == nil {
+		return 0
+	}
+	return int64(*version)
+}
+
+func setPatchField(patch map[string]interface{}, name string, value *string) {
+	if value != nil {
+		patch[name] = *value
+	}
+}
+
+func marshalPatch(patch map[string]interface{}) []byte {
+	data, err := json.Marshal(patch)
+	helper.PanicIfError(err)
+	return data
+}
@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/service"
+)
+
+//go:embed schema.graphql
+var schema string
+
+// MaxDepth is how deeply fields of a query can nest
+const MaxDepth = 10
+
+// NewSchema parses the schema with the resolvers backed by the services. The
+// resolvers report failures by panicking with the same errors as the REST
+// controllers, which become GraphQL errors carrying the HTTP code.
+func NewSchema(contactService service.ContactService, addressService service.AddressService, userService service.UserService) *graphql.Schema {
+	resolver := &Resolver{
+		ContactService: contactService,
+		AddressService: addressService,
+		UserService:    userService,
+	}
+
+	handler := panicHandler{}
+	return graphql.MustParseSchema(schema, resolver,
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(MaxDepth),
+		// The services share the fiber.Ctx of the request, which is not safe
+		// for concurrent use
+		graphql.MaxParallelism(1),
+		graphql.PanicHandler(handler),
+		graphql.Logger(handler),
+	)
+}
+
+type contextKey struct{}
+
+// NewContext returns the context queries are executed with, which carries the
+// request for the resolvers
+func NewContext(ctx *fiber.Ctx) context.Context {
+	return context.WithValue(ctx.UserContext(), contextKey{}, ctx)
+}
+
+// request returns the request a resolver runs for and the user making it
+func request(ctx context.Context) (*fiber.Ctx, domain.User) {
+	fiberCtx := ctx.Value(contextKey{}).(*fiber.Ctx)
+	return fiberCtx, *fiberCtx.Locals("user").(*domain.User)
+}
+
+// panicHandler turns the errors the services panic with into GraphQL errors
+// with the code and status of the REST error response
+type panicHandler struct{}
+
+func (panicHandler) MakePanicError(ctx context.Context, value any) *errors.QueryError {
+	err, ok := value.(error)
+	if !ok {
+		err = fmt.Errorf("%v", value)
+	}
+
+	response := helper.NewErrorResponse(err)
+	queryError := errors.Errorf("%v", response.Data)
+	queryError.Err = err
+	queryError.Extensions = map[string]any{
+		"code":   response.Code,
+		"status": response.Status,
+	}
+	return queryError
+}
+
+// LogPanic logs the panics that are not client errors
+func (panicHandler) LogPanic(ctx context.Context, value any) {
+	if err, ok := value.(error); ok && helper.NewErrorResponse(err).Code < fiber.StatusInternalServerError {
+		return
+	}
+	log.Printf("graphql: panic while resolving: %v", value)
+}
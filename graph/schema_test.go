@@ -0,0 +1,12 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSchema(t *testing.T) {
+	// Parsing checks every field of the schema has a resolver
+	assert.NotPanics(t, func() { NewSchema(nil, nil, nil) })
+}
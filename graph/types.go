@@ -0,0 +1,133 @@
+package graph
+
+import (
+	"github.com/graph-gophers/graphql-go"
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/address"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+	"github.com/sorfian/go-contact-management-api/model/web/user"
+)
+
+type userResolver struct {
+	user user.UserResponse
+}
+
+func (resolver *userResolver) Username() string {
+	return resolver.user.Username
+}
+
+func (resolver *userResolver) Name() string {
+	return resolver.user.Name
+}
+
+func (resolver *userResolver) Role() string {
+	return resolver.user.Role
+}
+
+type contactResolver struct {
+	contact   contact.ContactResponse
+	addresses *addressLoader
+}
+
+func (resolver *contactResolver) ID() graphql.ID {
+	return formatID(resolver.contact.ID)
+}
+
+func (resolver *contactResolver) AddressBookID() graphql.ID {
+	return formatID(resolver.contact.AddressBookID)
+}
+
+func (resolver *contactResolver) FirstName() string {
+	return resolver.contact.FirstName
+}
+
+func (resolver *contactResolver) LastName() string {
+	return resolver.contact.LastName
+}
+
+func (resolver *contactResolver) Email() string {
+	return resolver.contact.Email
+}
+
+func (resolver *contactResolver) Phone() string {
+	return resolver.contact.Phone
+}
+
+func (resolver *contactResolver) Version() int32 {
+	return int32(resolver.contact.Version)
+}
+
+func (resolver *contactResolver) Addresses() []*addressResolver {
+	addresses := resolver.addresses.Load(resolver.contact.ID)
+
+	addressResolvers := make([]*addressResolver, 0, len(addresses))
+	for _, addressResponse := range addresses {
+		addressResolvers = append(addressResolvers, &addressResolver{address: addressResponse})
+	}
+	return addressResolvers
+}
+
+type contactPageResolver struct {
+	contacts []*contactResolver
+	paging   web.PagingResponse
+}
+
+func (resolver *contactPageResolver) Contacts() []*contactResolver {
+	return resolver.contacts
+}
+
+func (resolver *contactPageResolver) Paging() *pagingResolver {
+	return &pagingResolver{paging: resolver.paging}
+}
+
+type pagingResolver struct {
+	paging web.PagingResponse
+}
+
+func (resolver *pagingResolver) Page() int32 {
+	return int32(resolver.paging.Page)
+}
+
+func (resolver *pagingResolver) Size() int32 {
+	return int32(resolver.paging.Size)
+}
+
+func (resolver *pagingResolver) TotalPage() int32 {
+	return int32(resolver.paging.TotalPage)
+}
+
+func (resolver *pagingResolver) TotalItem() int32 {
+	return int32(resolver.paging.TotalItem)
+}
+
+type addressResolver struct {
+	address address.AddressResponse
+}
+
+func (resolver *addressResolver) ID() graphql.ID {
+	return formatID(resolver.address.ID)
+}
+
+func (resolver *addressResolver) Street() string {
+	return resolver.address.Street
+}
+
+func (resolver *addressResolver) City() string {
+	return resolver.address.City
+}
+
+func (resolver *addressResolver) Province() string {
+	return resolver.address.Province
+}
+
+func (resolver *addressResolver) Country() string {
+	return resolver.address.Country
+}
+
+func (resolver *addressResolver) PostalCode() string {
+	return resolver.address.PostalCode
+}
+
+func (resolver *addressResolver) Version() int32 {
+	return int32(resolver.address.Version)
+}
@@ -0,0 +1,32 @@
+package helper
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+var (
+	detachedApp     *fiber.App
+	detachedAppOnce sync.Once
+)
+
+// NewContext returns a fiber context that is not tied to an HTTP request, so
+// that services and repositories can be called from the command line
+func NewContext(ctx context.Context) *fiber.Ctx {
+	detachedAppOnce.Do(func() {
+		detachedApp = fiber.New()
+	})
+
+	fiberCtx := detachedApp.AcquireCtx(&fasthttp.RequestCtx{})
+	fiberCtx.SetUserContext(ctx)
+	return fiberCtx
+}
+
+// ReleaseContext returns a context of NewContext to the pool once it is no
+// longer used
+func ReleaseContext(fiberCtx *fiber.Ctx) {
+	detachedApp.ReleaseCtx(fiberCtx)
+}
@@ -1,5 +1,11 @@
 package helper
 
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/web"
+)
+
 func PanicIfError(err error) {
 	if err != nil {
 		panic(err)
@@ -42,6 +48,18 @@ func NewBadRequestError(error string) BadRequestError {
 	return BadRequestError{Err: error}
 }
 
+type PreconditionFailedError struct {
+	Err string
+}
+
+func (e PreconditionFailedError) Error() string {
+	return e.Err
+}
+
+func NewPreconditionFailedError(error string) PreconditionFailedError {
+	return PreconditionFailedError{Err: error}
+}
+
 type UnauthorizedError struct {
 	Err string
 }
@@ -53,3 +71,64 @@ func (e UnauthorizedError) Error() string {
 func NewUnauthorizedError(error string) UnauthorizedError {
 	return UnauthorizedError{Err: error}
 }
+
+type ForbiddenError struct {
+	Err string
+}
+
+func (e ForbiddenError) Error() string {
+	return e.Err
+}
+
+func NewForbiddenError(error string) ForbiddenError {
+	return ForbiddenError{Err: error}
+}
+
+// NewErrorResponse maps an error to the response envelope returned by the API
+func NewErrorResponse(err error) web.Response {
+	code := fiber.StatusInternalServerError
+	status := "Internal Server Error"
+	message := err.Error()
+
+	// Handle custom errors
+	switch e := err.(type) {
+	case *fiber.Error:
+		code = e.Code
+		status = GetStatusText(e.Code)
+		message = e.Message
+	case validator.ValidationErrors:
+		code = fiber.StatusBadRequest
+		status = "Bad Request"
+		message = "Validation failed: " + e.Error()
+	case NotFoundError:
+		code = fiber.StatusNotFound
+		status = "Not Found"
+		message = e.Err
+	case ResourceConflictError:
+		code = fiber.StatusConflict
+		status = "Resource Conflict"
+		message = e.Err
+	case BadRequestError:
+		code = fiber.StatusBadRequest
+		status = "Bad Request"
+		message = e.Err
+	case UnauthorizedError:
+		code = fiber.StatusUnauthorized
+		status = "Unauthorized"
+		message = e.Err
+	case ForbiddenError:
+		code = fiber.StatusForbidden
+		status = "Forbidden"
+		message = e.Err
+	case PreconditionFailedError:
+		code = fiber.StatusPreconditionFailed
+		status = "Precondition Failed"
+		message = e.Err
+	}
+
+	return web.Response{
+		Code:   code,
+		Status: status,
+		Data:   message,
+	}
+}
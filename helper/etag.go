@@ -0,0 +1,52 @@
+package helper
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FormatETag renders a resource version as a strong entity tag
+func FormatETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ParseIfMatch returns the version required by an If-Match header.
+// Zero means the header is absent or "*", so any version is accepted.
+func ParseIfMatch(header string) (int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	// If-Match uses strong comparison, so weak tags never match
+	if strings.HasPrefix(header, "W/") || strings.Contains(header, ",") {
+		return 0, NewPreconditionFailedError("If-Match must be a single strong entity tag")
+	}
+
+	version, err := strconv.ParseInt(strings.Trim(header, `"`), 10, 64)
+	if err != nil || version < 1 {
+		return 0, NewPreconditionFailedError("If-Match does not match the current version")
+	}
+
+	return version, nil
+}
+
+// MatchesETag reports whether an If-None-Match header matches the given tag
+func MatchesETag(header string, etag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+
+	// If-None-Match uses weak comparison
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
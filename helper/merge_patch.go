@@ -0,0 +1,81 @@
+package helper
+
+import (
+	"encoding/json"
+	"mime"
+	"reflect"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const MIMEApplicationMergePatchJSON = "application/merge-patch+json"
+
+// ReadMergePatch returns the body of a PATCH request as a JSON merge patch,
+// accepting both application/merge-patch+json and application/json.
+func ReadMergePatch(ctx *fiber.Ctx) ([]byte, error) {
+	mediaType, _, err := mime.ParseMediaType(ctx.Get(fiber.HeaderContentType))
+	if err != nil || (mediaType != MIMEApplicationMergePatchJSON && mediaType != fiber.MIMEApplicationJSON) {
+		return nil, fiber.NewError(fiber.StatusUnsupportedMediaType, "content type must be "+MIMEApplicationMergePatchJSON+" or "+fiber.MIMEApplicationJSON)
+	}
+
+	patch := ctx.Body()
+	if !json.Valid(patch) {
+		return nil, NewBadRequestError("request body is not valid JSON")
+	}
+
+	return patch, nil
+}
+
+// ApplyMergePatch applies an RFC 7396 merge patch to target, a pointer to a
+// struct, so that members set to null are cleared and absent members are kept.
+func ApplyMergePatch(target interface{}, patch []byte) error {
+	document, err := json.Marshal(target)
+	if err != nil {
+		return err
+	}
+
+	var documentValue, patchValue interface{}
+	if err := json.Unmarshal(document, &documentValue); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(patch, &patchValue); err != nil {
+		return NewBadRequestError("merge patch is not valid JSON")
+	}
+
+	merged, err := json.Marshal(MergePatch(documentValue, patchValue))
+	if err != nil {
+		return err
+	}
+
+	// Reset target first, json.Unmarshal keeps fields missing from the input
+	value := reflect.ValueOf(target).Elem()
+	value.Set(reflect.Zero(value.Type()))
+	if err := json.Unmarshal(merged, target); err != nil {
+		return NewBadRequestError("merge patch does not match the resource: " + err.Error())
+	}
+
+	return nil
+}
+
+// MergePatch implements the RFC 7396 MergePatch algorithm on decoded JSON values
+func MergePatch(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = MergePatch(targetObject[name], value)
+	}
+
+	return targetObject
+}
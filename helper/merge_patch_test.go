@@ -0,0 +1,59 @@
+package helper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test cases from RFC 7396 Appendix A
+func TestMergePatch(t *testing.T) {
+	cases := []struct {
+		target string
+		patch  string
+		result string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, c := range cases {
+		var target, patch interface{}
+		assert.NoError(t, json.Unmarshal([]byte(c.target), &target))
+		assert.NoError(t, json.Unmarshal([]byte(c.patch), &patch))
+
+		result, err := json.Marshal(MergePatch(target, patch))
+		assert.NoError(t, err)
+		assert.JSONEq(t, c.result, string(result), "target %s patch %s", c.target, c.patch)
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	type document struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+		Phone string `json:"phone"`
+	}
+
+	target := document{Name: "John", Email: "john@example.com", Phone: "0812"}
+	err := ApplyMergePatch(&target, []byte(`{"name":"Jane","email":null}`))
+	assert.NoError(t, err)
+	assert.Equal(t, document{Name: "Jane", Email: "", Phone: "0812"}, target)
+
+	err = ApplyMergePatch(&target, []byte(`"not an object"`))
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"time"
 
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -31,3 +32,8 @@ func GenerateToken() (string, error) {
 func GetTokenExpiration(days int) int64 {
 	return time.Now().UnixMilli() + int64(days)*24*60*60*1000
 }
+
+// GenerateBatchID returns an identifier shared by rows soft-deleted together
+func GenerateBatchID() string {
+	return uuid.NewString()
+}
@@ -0,0 +1,85 @@
+package helper
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// MIMEImageJPEG is the media type photos are stored in
+const MIMEImageJPEG = "image/jpeg"
+
+// maxPhotoPixels keeps a small file from decoding into a huge image
+const maxPhotoPixels = 50_000_000
+
+// PhotoSize is a thumbnail a photo is stored in, its longer side Pixels long
+type PhotoSize struct {
+	Name   string
+	Pixels int
+}
+
+// PhotoSizes are the thumbnails stored of every photo
+var PhotoSizes = []PhotoSize{
+	{Name: "small", Pixels: 64},
+	{Name: "medium", Pixels: 256},
+	{Name: "large", Pixels: 1024},
+}
+
+// photoMediaTypes are the media types a photo is accepted in
+var photoMediaTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+// DecodePhoto decodes a JPEG, PNG or WebP photo. The format is sniffed from
+// the data, whatever type the client claimed.
+func DecodePhoto(data []byte) (image.Image, error) {
+	if !photoMediaTypes[http.DetectContentType(data)] {
+		return nil, fiber.NewError(fiber.StatusUnsupportedMediaType, "photo must be a JPEG, PNG or WebP image")
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, NewBadRequestError("photo is not a valid image")
+	}
+	if config.Width*config.Height > maxPhotoPixels {
+		return nil, NewBadRequestError("photo has too many pixels")
+	}
+
+	photo, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, NewBadRequestError("photo is not a valid image")
+	}
+	return photo, nil
+}
+
+// EncodeThumbnail scales the photo down to fit a square of the pixels and
+// encodes it as JPEG. Smaller photos keep their size, transparent parts
+// turn white.
+func EncodeThumbnail(photo image.Image, pixels int) []byte {
+	bounds := photo.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > pixels || height > pixels {
+		if width >= height {
+			width, height = pixels, max(1, height*pixels/width)
+		} else {
+			width, height = max(1, width*pixels/height), pixels
+		}
+	}
+
+	thumbnail := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(thumbnail, thumbnail.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(thumbnail, thumbnail.Bounds(), photo, bounds, draw.Over, nil)
+
+	var buffer bytes.Buffer
+	PanicIfError(jpeg.Encode(&buffer, thumbnail, &jpeg.Options{Quality: 85}))
+	return buffer.Bytes()
+}
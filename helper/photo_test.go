@@ -0,0 +1,73 @@
+package helper
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func encodeTestPNG(width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, 0, color.NRGBA{R: 255, A: 255})
+	}
+
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		panic(err)
+	}
+	return buffer.Bytes()
+}
+
+func TestDecodePhoto(t *testing.T) {
+	photo, err := DecodePhoto(encodeTestPNG(300, 200))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 300, 200), photo.Bounds())
+
+	webp, err := os.ReadFile("testdata/photo.webp")
+	assert.NoError(t, err)
+	photo, err = DecodePhoto(webp)
+	assert.NoError(t, err)
+	assert.False(t, photo.Bounds().Empty())
+
+	_, err = DecodePhoto([]byte("GIF89a not accepted"))
+	assert.Equal(t, fiber.StatusUnsupportedMediaType, err.(*fiber.Error).Code)
+
+	// Sniffed as PNG but cut short
+	_, err = DecodePhoto(encodeTestPNG(10, 10)[:20])
+	assert.IsType(t, BadRequestError{}, err)
+}
+
+func TestEncodeThumbnail(t *testing.T) {
+	photo, err := DecodePhoto(encodeTestPNG(300, 200))
+	assert.NoError(t, err)
+
+	cases := []struct {
+		pixels int
+		size   image.Point
+	}{
+		{64, image.Pt(64, 42)},
+		{256, image.Pt(256, 170)},
+		// Not scaled up
+		{1024, image.Pt(300, 200)},
+	}
+	for _, c := range cases {
+		thumbnail, err := jpeg.Decode(bytes.NewReader(EncodeThumbnail(photo, c.pixels)))
+		assert.NoError(t, err)
+		assert.Equal(t, c.size, thumbnail.Bounds().Size(), c.pixels)
+	}
+
+	// Portrait photos fit by their height
+	photo, err = DecodePhoto(encodeTestPNG(100, 400))
+	assert.NoError(t, err)
+	thumbnail, err := jpeg.Decode(bytes.NewReader(EncodeThumbnail(photo, 64)))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Pt(16, 64), thumbnail.Bounds().Size())
+}
@@ -0,0 +1,139 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrNotPublicAddress refuses a connection to a loopback, private, link-local,
+// reserved or otherwise internal address
+var ErrNotPublicAddress = errors.New("address is not public")
+
+// nonPublicPrefixes are the special-purpose ranges of the IANA IPv4 and IPv6
+// registries that are not reachable unicast on the public internet, beyond
+// what the netip.Addr methods cover
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // this network, 0.0.0.0 reaches localhost on Linux
+	netip.MustParsePrefix("100.64.0.0/10"),   // shared address space (CGNAT), some clouds serve metadata from it
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+	netip.MustParsePrefix("192.88.99.0/24"),  // 6to4 relay anycast
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved and limited broadcast
+	netip.MustParsePrefix("::/96"),           // IPv4-compatible, deprecated
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // local-use NAT64
+	netip.MustParsePrefix("100::/64"),        // discard
+	netip.MustParsePrefix("2001::/23"),       // IETF protocol assignments, Teredo among them
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+	netip.MustParsePrefix("fec0::/10"),       // site-local, deprecated
+}
+
+// Prefixes of IPv6 addresses that embed an IPv4 address, which is checked
+var (
+	nat64Prefix     = netip.MustParsePrefix("64:ff9b::/96")
+	sixToFourPrefix = netip.MustParsePrefix("2002::/16")
+)
+
+// IsPublicIP reports whether ip is a unicast address on the public internet
+func IsPublicIP(ip net.IP) bool {
+	addr, ok := netip.AddrFromSlice(ip)
+	return ok && isPublicAddr(addr)
+}
+
+func isPublicAddr(addr netip.Addr) bool {
+	// IPv4-mapped addresses are checked as IPv4
+	addr = addr.Unmap()
+
+	if !addr.IsValid() ||
+		addr.IsUnspecified() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsMulticast() {
+		return false
+	}
+
+	bytes := addr.As16()
+	switch {
+	case nat64Prefix.Contains(addr):
+		return isPublicAddr(netip.AddrFrom4([4]byte(bytes[12:16])))
+	case sixToFourPrefix.Contains(addr):
+		return isPublicAddr(netip.AddrFrom4([4]byte(bytes[2:6])))
+	}
+
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// PublicOnlyControl is a net.Dialer Control that refuses connections to
+// addresses that are not public. It sees the address after the host name was
+// resolved, so a name resolving to an internal address is refused too.
+func PublicOnlyControl(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	if !isPublicAddr(addrPort.Addr()) {
+		return ErrNotPublicAddress
+	}
+	return nil
+}
+
+// CheckPublicHost returns ErrNotPublicAddress when the host is, or resolves
+// to, an address that is not public, and an error when it does not resolve
+func CheckPublicHost(ctx context.Context, host string) error {
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("resolve %q: %w", host, err)
+	}
+
+	for _, address := range addresses {
+		if !IsPublicIP(address.IP) {
+			return ErrNotPublicAddress
+		}
+	}
+	return nil
+}
+
+// checkPublicRedirect follows up to 10 redirects like the default client,
+// but not to hosts that are not public
+func checkPublicRedirect(request *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	return CheckPublicHost(request.Context(), request.URL.Hostname())
+}
+
+// NewPublicHTTPClient returns a client that only connects to public addresses
+// and follows redirects only to them. It connects directly, not through a
+// proxy of the environment.
+func NewPublicHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   PublicOnlyControl,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:       timeout,
+		Transport:     transport,
+		CheckRedirect: checkPublicRedirect,
+	}
+}
@@ -0,0 +1,103 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	for _, test := range []struct {
+		address string
+		public  bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:4700::1111", true},
+		{"0.0.0.0", false},
+		{"0.1.2.3", false},
+		{"10.1.2.3", false},
+		{"100.64.0.1", false},
+		{"100.100.100.200", false},
+		{"127.0.0.1", false},
+		{"169.254.169.254", false},
+		{"172.16.0.1", false},
+		{"192.0.0.8", false},
+		{"192.0.2.1", false},
+		{"192.88.99.1", false},
+		{"192.168.1.1", false},
+		{"198.18.0.1", false},
+		{"198.19.255.254", false},
+		{"198.51.100.1", false},
+		{"203.0.113.1", false},
+		{"224.0.0.1", false},
+		{"240.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::", false},
+		{"::1", false},
+		{"::127.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:169.254.169.254", false},
+		{"::ffff:93.184.216.34", true},
+		{"64:ff9b::7f00:1", false},
+		{"64:ff9b::a9fe:a9fe", false},
+		{"64:ff9b::5db8:d822", true},
+		{"64:ff9b:1::1", false},
+		{"100::1", false},
+		{"2001::1", false},
+		{"2001:db8::1", false},
+		{"2002:7f00:1::1", false},
+		{"2002:5db8:d822::1", true},
+		{"fc00::1", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+		{"fec0::1", false},
+		{"ff02::1", false},
+	} {
+		assert.Equal(t, test.public, IsPublicIP(net.ParseIP(test.address)), test.address)
+	}
+}
+
+func TestPublicOnlyControl(t *testing.T) {
+	assert.NoError(t, PublicOnlyControl("tcp4", "93.184.216.34:443", nil))
+	assert.ErrorIs(t, PublicOnlyControl("tcp4", "0.0.0.0:80", nil), ErrNotPublicAddress)
+	assert.ErrorIs(t, PublicOnlyControl("tcp6", "[::ffff:10.0.0.1]:80", nil), ErrNotPublicAddress)
+	assert.ErrorIs(t, PublicOnlyControl("tcp6", "[fe80::1%eth0]:80", nil), ErrNotPublicAddress)
+}
+
+func TestCheckPublicHost(t *testing.T) {
+	ctx := context.Background()
+	assert.ErrorIs(t, CheckPublicHost(ctx, "169.254.169.254"), ErrNotPublicAddress)
+	assert.ErrorIs(t, CheckPublicHost(ctx, "localhost"), ErrNotPublicAddress)
+	assert.NoError(t, CheckPublicHost(ctx, "93.184.216.34"))
+
+	// A host that does not resolve is refused, not let through
+	err := CheckPublicHost(ctx, "webhook.invalid")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNotPublicAddress)
+}
+
+func TestPublicHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewPublicHTTPClient(time.Second)
+
+	// The local server is refused when connecting, whatever the URL names
+	_, err := client.Get(server.URL)
+	assert.True(t, errors.Is(err, ErrNotPublicAddress), err)
+
+	// Redirects to hosts that are not public are not followed
+	redirect := httptest.NewRequest("GET", "http://169.254.169.254/latest/meta-data/", nil)
+	assert.ErrorIs(t, checkPublicRedirect(redirect, nil), ErrNotPublicAddress)
+	redirect = httptest.NewRequest("GET", "http://93.184.216.34/", nil)
+	assert.NoError(t, checkPublicRedirect(redirect, nil))
+	assert.Error(t, checkPublicRedirect(redirect, make([]*http.Request, 10)))
+}
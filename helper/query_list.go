@@ -0,0 +1,37 @@
+package helper
+
+import (
+	"strings"
+)
+
+// ParseQueryList parses a comma separated query parameter whose values must
+// be among allowed. Each value is returned once, in the order given.
+func ParseQueryList(name string, value string, allowed []string) ([]string, error) {
+	values := []string{}
+	if strings.TrimSpace(value) == "" {
+		return values, nil
+	}
+
+	seen := map[string]bool{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if !containsString(allowed, item) {
+			return nil, NewBadRequestError(name + " must be a comma separated list of " + strings.Join(allowed, ", "))
+		}
+		if !seen[item] {
+			seen[item] = true
+			values = append(values, item)
+		}
+	}
+
+	return values, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQueryList(t *testing.T) {
+	allowed := []string{"addresses", "photos"}
+
+	values, err := ParseQueryList("include", "", allowed)
+	assert.NoError(t, err)
+	assert.Empty(t, values)
+
+	values, err = ParseQueryList("include", "photos, addresses,photos", allowed)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"photos", "addresses"}, values)
+
+	_, err = ParseQueryList("include", "addresses,phones", allowed)
+	assert.Equal(t, NewBadRequestError("include must be a comma separated list of addresses, photos"), err)
+
+	_, err = ParseQueryList("include", "addresses,", allowed)
+	assert.Error(t, err)
+}
@@ -0,0 +1,38 @@
+package helper
+
+import (
+	"encoding/json"
+)
+
+// SparseFields renders value as JSON keeping only the given members of the
+// object, or of each object of an array. Without fields value is returned as
+// it is.
+func SparseFields(value interface{}, fields []string) interface{} {
+	if len(fields) == 0 {
+		return value
+	}
+
+	data, err := json.Marshal(value)
+	PanicIfError(err)
+
+	var objects []map[string]json.RawMessage
+	if json.Unmarshal(data, &objects) == nil {
+		for _, object := range objects {
+			keepMembers(object, fields)
+		}
+		return objects
+	}
+
+	object := map[string]json.RawMessage{}
+	PanicIfError(json.Unmarshal(data, &object))
+	keepMembers(object, fields)
+	return object
+}
+
+func keepMembers(object map[string]json.RawMessage, fields []string) {
+	for name := range object {
+		if !containsString(fields, name) {
+			delete(object, name)
+		}
+	}
+}
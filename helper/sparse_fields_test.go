@@ -0,0 +1,32 @@
+package helper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sparseFieldsValue struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	City string `json:"city"`
+}
+
+func TestSparseFields(t *testing.T) {
+	value := sparseFieldsValue{ID: 1, Name: "John", City: "Jakarta"}
+	assert.Equal(t, value, SparseFields(value, nil))
+
+	data, err := json.Marshal(SparseFields(value, []string{"name", "id"}))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":1,"name":"John"}`, string(data))
+
+	values := []sparseFieldsValue{value, {ID: 2, Name: "Jane", City: "Bandung"}}
+	data, err = json.Marshal(SparseFields(values, []string{"city"}))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"city":"Jakarta"},{"city":"Bandung"}]`, string(data))
+
+	data, err = json.Marshal(SparseFields([]sparseFieldsValue{}, []string{"city"}))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(data))
+}
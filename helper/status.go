@@ -8,6 +8,8 @@ func GetStatusText(code int) string {
 		return "OK"
 	case fiber.StatusCreated:
 		return "Created"
+	case fiber.StatusNotModified:
+		return "Not Modified"
 	case fiber.StatusBadRequest:
 		return "Bad Request"
 	case fiber.StatusUnauthorized:
@@ -16,6 +18,16 @@ func GetStatusText(code int) string {
 		return "Forbidden"
 	case fiber.StatusNotFound:
 		return "Not Found"
+	case fiber.StatusConflict:
+		return "Resource Conflict"
+	case fiber.StatusPreconditionFailed:
+		return "Precondition Failed"
+	case fiber.StatusRequestEntityTooLarge:
+		return "Request Entity Too Large"
+	case fiber.StatusUnsupportedMediaType:
+		return "Unsupported Media Type"
+	case fiber.StatusFailedDependency:
+		return "Failed Dependency"
 	case fiber.StatusInternalServerError:
 		return "Internal Server Error"
 	default:
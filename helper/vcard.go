@@ -0,0 +1,282 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// MIMETextVCard is the media type of vCard data
+const MIMETextVCard = "text/vcard; charset=utf-8"
+
+// vCardLineLength is the length in octets lines are folded at
+const vCardLineLength = 75
+
+// VCard holds the properties of a vCard that a contact keeps, others are
+// dropped when a card is decoded. Photo, JPEG data, is only encoded.
+type VCard struct {
+	UID       string
+	FirstName string
+	LastName  string
+	Email     string
+	Phone     string
+	Addresses []VCardAddress
+	Photo     []byte
+}
+
+type VCardAddress struct {
+	Street     string
+	City       string
+	Province   string
+	PostalCode string
+	Country    string
+}
+
+// EncodeVCard renders the card as vCard 3.0, which every CardDAV client reads
+func EncodeVCard(card VCard) []byte {
+	var buffer bytes.Buffer
+	writeLine := func(line string) {
+		buffer.WriteString(foldVCardLine(line))
+		buffer.WriteString("\r\n")
+	}
+
+	writeLine("BEGIN:VCARD")
+	writeLine("VERSION:3.0")
+	writeLine("UID:" + escapeVCardText(card.UID))
+	writeLine("N:" + escapeVCardText(card.LastName) + ";" + escapeVCardText(card.FirstName) + ";;;")
+	writeLine("FN:" + escapeVCardText(strings.TrimSpace(card.FirstName+" "+card.LastName)))
+	if card.Email != "" {
+		writeLine("EMAIL;TYPE=INTERNET:" + escapeVCardText(card.Email))
+	}
+	if card.Phone != "" {
+		writeLine("TEL:" + escapeVCardText(card.Phone))
+	}
+	for _, address := range card.Addresses {
+		writeLine("ADR:;;" + strings.Join([]string{
+			escapeVCardText(address.Street),
+			escapeVCardText(address.City),
+			escapeVCardText(address.Province),
+			escapeVCardText(address.PostalCode),
+			escapeVCardText(address.Country),
+		}, ";"))
+	}
+	if len(card.Photo) > 0 {
+		writeLine("PHOTO;ENCODING=b;TYPE=JPEG:" + base64.StdEncoding.EncodeToString(card.Photo))
+	}
+	writeLine("END:VCARD")
+
+	return buffer.Bytes()
+}
+
+// DecodeVCard reads the first vCard of data, version 3.0 or 4.0. The
+// preferred email and phone are kept, or else the first ones. Without an N
+// property the name is split from FN, the last word being the last name.
+func DecodeVCard(data []byte) (VCard, error) {
+	card := VCard{}
+	var fullName string
+	var hasName, begun, ended, emailPreferred, phonePreferred bool
+
+	for _, line := range unfoldVCardLines(data) {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		name, params, value, ok := parseVCardLine(line)
+		if !ok {
+			return VCard{}, errors.New("vCard line has no value: " + line)
+		}
+
+		if !begun {
+			if name != "BEGIN" || !strings.EqualFold(value, "VCARD") {
+				return VCard{}, errors.New("vCard must start with BEGIN:VCARD")
+			}
+			begun = true
+			continue
+		}
+
+		preferred := isPreferredVCardValue(params)
+		switch name {
+		case "END":
+			ended = true
+		case "UID":
+			card.UID = unescapeVCardText(value)
+		case "FN":
+			fullName = unescapeVCardText(value)
+		case "N":
+			components := splitVCardComponents(value)
+			card.LastName = vCardComponent(components, 0)
+			card.FirstName = vCardComponent(components, 1)
+			hasName = true
+		case "EMAIL":
+			if card.Email == "" || (preferred && !emailPreferred) {
+				card.Email = unescapeVCardText(value)
+				emailPreferred = preferred
+			}
+		case "TEL":
+			if card.Phone == "" || (preferred && !phonePreferred) {
+				card.Phone = strings.TrimPrefix(unescapeVCardText(value), "tel:")
+				phonePreferred = preferred
+			}
+		case "ADR":
+			components := splitVCardComponents(value)
+			card.Addresses = append(card.Addresses, VCardAddress{
+				Street:     vCardComponent(components, 2),
+				City:       vCardComponent(components, 3),
+				Province:   vCardComponent(components, 4),
+				PostalCode: vCardComponent(components, 5),
+				Country:    vCardComponent(components, 6),
+			})
+		}
+		if ended {
+			break
+		}
+	}
+
+	if !begun {
+		return VCard{}, errors.New("vCard must start with BEGIN:VCARD")
+	}
+	if !ended {
+		return VCard{}, errors.New("vCard must end with END:VCARD")
+	}
+
+	if !hasName || (card.FirstName == "" && card.LastName == "") {
+		words := strings.Fields(fullName)
+		if len(words) > 0 {
+			card.LastName = words[len(words)-1]
+			card.FirstName = strings.Join(words[:len(words)-1], " ")
+		}
+	}
+
+	return card, nil
+}
+
+// unfoldVCardLines splits data into lines, joining those continued on the
+// next line by a leading space or tab
+func unfoldVCardLines(data []byte) []string {
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// foldVCardLine breaks a line longer than the limit into continuation lines,
+// never inside a UTF-8 sequence
+func foldVCardLine(line string) string {
+	if len(line) <= vCardLineLength {
+		return line
+	}
+
+	var builder strings.Builder
+	limit := vCardLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		builder.WriteString(line[:cut])
+		builder.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with the space
+		limit = vCardLineLength - 1
+	}
+	builder.WriteString(line)
+	return builder.String()
+}
+
+// parseVCardLine splits a content line into its upper cased name without
+// group, its parameters and its value
+func parseVCardLine(line string) (string, []string, string, bool) {
+	quoted := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if quoted {
+				continue
+			}
+			parts := strings.Split(line[:i], ";")
+			name := strings.ToUpper(parts[0])
+			if dot := strings.LastIndex(name, "."); dot >= 0 {
+				name = name[dot+1:]
+			}
+			return name, parts[1:], line[i+1:], true
+		}
+	}
+	return "", nil, "", false
+}
+
+// isPreferredVCardValue reports whether the parameters mark the value as
+// preferred, TYPE=pref in vCard 3.0 and PREF in 4.0
+func isPreferredVCardValue(params []string) bool {
+	for _, param := range params {
+		key, value, _ := strings.Cut(strings.ToUpper(param), "=")
+		switch key {
+		case "PREF":
+			return true
+		case "TYPE":
+			for _, kind := range strings.Split(strings.Trim(value, `"`), ",") {
+				if kind == "PREF" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// splitVCardComponents splits a structured value at the semicolons that are
+// not escaped, unescaping each component
+func splitVCardComponents(value string) []string {
+	var components []string
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case ';':
+			components = append(components, unescapeVCardText(value[start:i]))
+			start = i + 1
+		}
+	}
+	return append(components, unescapeVCardText(value[start:]))
+}
+
+func vCardComponent(components []string, index int) string {
+	if index < len(components) {
+		return strings.TrimSpace(components[index])
+	}
+	return ""
+}
+
+var vCardEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, ",", `\,`, ";", `\;`)
+
+func escapeVCardText(text string) string {
+	return vCardEscaper.Replace(strings.ReplaceAll(text, "\r\n", "\n"))
+}
+
+func unescapeVCardText(text string) string {
+	var builder strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] != '\\' || i == len(text)-1 {
+			builder.WriteByte(text[i])
+			continue
+		}
+		i++
+		switch text[i] {
+		case 'n', 'N':
+			builder.WriteByte('\n')
+		default:
+			builder.WriteByte(text[i])
+		}
+	}
+	return builder.String()
+}
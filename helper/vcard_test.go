@@ -0,0 +1,88 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVCardRoundTrip(t *testing.T) {
+	card := VCard{
+		UID:       "2f0e7b1c-uid",
+		FirstName: "John, Jr.",
+		LastName:  "Doe; Smith",
+		Email:     "john@example.com",
+		Phone:     "08123456789",
+		Addresses: []VCardAddress{
+			{Street: "Jl. Test 1\nBlok A", City: "Jakarta", Province: "DKI Jakarta", PostalCode: "12345", Country: "Indonesia"},
+			{Street: strings.Repeat("Jalan Panjang Sekali ", 10) + "No. 1", City: "Bandung", Province: "Jawa Barat", PostalCode: "40111", Country: "Indonesia"},
+		},
+	}
+
+	data := EncodeVCard(card)
+	for _, line := range strings.Split(string(data), "\r\n") {
+		assert.LessOrEqual(t, len(line), vCardLineLength)
+	}
+	assert.Contains(t, string(data), "N:Doe\\; Smith;John\\, Jr.;;;\r\n")
+
+	decoded, err := DecodeVCard(data)
+	assert.NoError(t, err)
+	assert.Equal(t, card, decoded)
+}
+
+func TestDecodeVCard4(t *testing.T) {
+	data := "BEGIN:VCARD\n" +
+		"VERSION:4.0\n" +
+		"UID:urn:uuid:4fbe8971-0bc3-424c-9c26-36c3e1eff6b1\n" +
+		"FN:Jane Mary Doe\n" +
+		"item1.EMAIL;TYPE=work:jane@work.example.com\n" +
+		"EMAIL;PREF=1:jane@example.com\n" +
+		"TEL;VALUE=uri;TYPE=\"voice,cell\":tel:+62-812-3456\n" +
+		"ADR;LABEL=\"Jl. Test: 1\":;;Jl. Test;Jakarta;\n" +
+		" DKI Jakarta;12345;Indonesia\n" +
+		"NOTE:dropped\n" +
+		"END:VCARD\n"
+
+	card, err := DecodeVCard([]byte(data))
+	assert.NoError(t, err)
+	assert.Equal(t, VCard{
+		UID:       "urn:uuid:4fbe8971-0bc3-424c-9c26-36c3e1eff6b1",
+		FirstName: "Jane Mary",
+		LastName:  "Doe",
+		Email:     "jane@example.com",
+		Phone:     "+62-812-3456",
+		Addresses: []VCardAddress{{Street: "Jl. Test", City: "Jakarta", Province: "DKI Jakarta", PostalCode: "12345", Country: "Indonesia"}},
+	}, card)
+}
+
+func TestDecodeVCardInvalid(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"FN:John Doe\r\n",
+		"BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VCARD\r\nFN:John Doe\r\n",
+		"BEGIN:VCARD\r\nFN John Doe\r\nEND:VCARD\r\n",
+	} {
+		_, err := DecodeVCard([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
+
+func TestEncodeVCardPhoto(t *testing.T) {
+	photo := bytes.Repeat([]byte{0xff, 0xd8, 0x00, 0x7f}, 100)
+	data := EncodeVCard(VCard{UID: "photo-uid", FirstName: "John", Photo: photo})
+
+	for _, line := range strings.Split(string(data), "\r\n") {
+		assert.LessOrEqual(t, len(line), vCardLineLength)
+	}
+	lines := unfoldVCardLines(data)
+	assert.Contains(t, lines, "PHOTO;ENCODING=b;TYPE=JPEG:"+base64.StdEncoding.EncodeToString(photo))
+
+	// Photos are not decoded
+	card, err := DecodeVCard(data)
+	assert.NoError(t, err)
+	assert.Nil(t, card.Photo)
+}
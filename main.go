@@ -1,20 +1,49 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net"
+	"os"
 
+	"github.com/gofiber/fiber/v2"
 	"github.com/sorfian/go-contact-management-api/app"
+	"google.golang.org/grpc"
 )
 
 func main() {
+	// Anything but serve is an operator command, see app help
+	if len(os.Args) > 1 && os.Args[1] != "serve" {
+		os.Exit(InitializeAdmin().Run(os.Args[1:], os.Stdout, os.Stderr))
+	}
+
 	// Load configuration
 	config := app.LoadConfig()
 
 	// Initialize the app with all dependencies using Wire
-	fiberApp := InitializeApp()
+	server := InitializeApp()
+
+	// Prefork runs the API in child processes, the parent alone dispatches the
+	// domain events, sends the webhook deliveries and serves gRPC
+	if !fiber.IsChild() {
+		go server.OutboxDispatcher.Run(context.Background())
+		go server.WebhookWorker.Run(context.Background())
+		go serveGRPC(server.GRPCServer, config.GRPCPort)
+	}
 
 	// Start server
 	log.Printf("Starting server on port %s in %s mode...", config.AppPort, config.AppEnv)
-	log.Fatal(fiberApp.Listen(fmt.Sprintf(":%s", config.AppPort)))
+	log.Fatal(server.App.Listen(fmt.Sprintf(":%s", config.AppPort)))
+}
+
+// serveGRPC serves the gRPC services on their own port
+func serveGRPC(server *grpc.Server, port string) {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+	if err != nil {
+		log.Fatalf("Failed to listen for gRPC on port %s: %v", port, err)
+	}
+
+	log.Printf("Serving gRPC on port %s...", port)
+	log.Fatal(server.Serve(listener))
 }
@@ -1,11 +1,13 @@
 package middleware
 
 import (
+	"encoding/base64"
 	"errors"
 	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
 	"github.com/sorfian/go-contact-management-api/model/web"
 	"github.com/sorfian/go-contact-management-api/repository"
 	"gorm.io/gorm"
@@ -46,19 +48,10 @@ func (middleware *AuthMiddleware) Authenticate() fiber.Handler {
 			})
 		}
 
-		// Begin transaction
-		tx := middleware.DB.Begin()
-		defer func() {
-			if r := recover(); r != nil {
-				tx.Rollback()
-				panic(r)
-			}
-		}()
-
-		// Find a user by token using a repository
-		user, err := middleware.UserRepository.FindByToken(ctx, tx, token)
+		// Find a user by token using a repository, the lookup only reads so it
+		// needs no transaction that would hold a connection for the whole request
+		user, err := middleware.UserRepository.FindByToken(ctx, middleware.DB, token)
 		if err != nil {
-			tx.Rollback()
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return ctx.Status(fiber.StatusUnauthorized).JSON(web.Response{
 					Code:   401,
@@ -83,6 +76,15 @@ func (middleware *AuthMiddleware) Authenticate() fiber.Handler {
 			})
 		}
 
+		// A disabled account has no session, even if its token is still stored
+		if user.DisabledAt != nil {
+			return ctx.Status(fiber.StatusUnauthorized).JSON(web.Response{
+				Code:   401,
+				Status: "Unauthorized",
+				Data:   "Account disabled",
+			})
+		}
+
 		// Set user to context
 		ctx.Locals("user", user)
 
@@ -90,3 +92,68 @@ func (middleware *AuthMiddleware) Authenticate() fiber.Handler {
 		return ctx.Next()
 	}
 }
+
+// AuthenticateBasic authenticates the user by the username and password of
+// HTTP Basic authentication, for clients such as CardDAV address books that
+// cannot log in for a token. Failures ask the client for credentials of the
+// realm.
+func (middleware *AuthMiddleware) AuthenticateBasic(realm string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		unauthorized := func(message string) error {
+			ctx.Set(fiber.HeaderWWWAuthenticate, `Basic realm="`+realm+`", charset="UTF-8"`)
+			return ctx.Status(fiber.StatusUnauthorized).JSON(web.Response{
+				Code:   401,
+				Status: "Unauthorized",
+				Data:   message,
+			})
+		}
+
+		username, password, ok := parseBasicAuth(ctx.Get("Authorization"))
+		if !ok {
+			return unauthorized("Missing basic credentials")
+		}
+
+		user, err := middleware.UserRepository.FindByUsername(ctx, middleware.DB, username)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return unauthorized("Invalid username or password")
+			}
+			return ctx.Status(fiber.StatusInternalServerError).JSON(web.Response{
+				Code:   500,
+				Status: "Internal Server Error",
+				Data:   "Failed to authenticate",
+			})
+		}
+
+		if helper.VerifyPassword(user.Password, password) != nil {
+			return unauthorized("Invalid username or password")
+		}
+
+		if user.DisabledAt != nil {
+			return unauthorized("Account disabled")
+		}
+
+		ctx.Locals("user", user)
+
+		return ctx.Next()
+	}
+}
+
+// parseBasicAuth returns the credentials of a Basic Authorization header
+func parseBasicAuth(header string) (string, string, bool) {
+	const prefix = "Basic "
+	if len(header) < len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", "", false
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(header[len(prefix):]))
+	if err != nil {
+		return "", "", false
+	}
+
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok || username == "" {
+		return "", "", false
+	}
+	return username, password, true
+}
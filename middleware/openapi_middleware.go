@@ -0,0 +1,217 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/sorfian/go-contact-management-api/helper"
+)
+
+// OpenAPIMiddleware validates requests, and optionally responses, against docs/apispec.yaml
+type OpenAPIMiddleware struct {
+	Router            routers.Router
+	ValidateResponses bool
+}
+
+// Photos are uploaded as raw images, the photo service checks their data
+func init() {
+	for _, mediaType := range []string{"image/jpeg", "image/png", "image/webp"} {
+		openapi3filter.RegisterBodyDecoder(mediaType, openapi3filter.FileBodyDecoder)
+	}
+}
+
+func NewOpenAPIMiddleware(specPath string, validateResponses bool) *OpenAPIMiddleware {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile(specPath)
+	helper.PanicIfError(err)
+
+	err = doc.Validate(loader.Context)
+	helper.PanicIfError(err)
+
+	// Match on the server base path only, the spec lists absolute URLs of
+	// deployments while this app may be reached through any host
+	servers := openapi3.Servers{}
+	seen := map[string]bool{}
+	for _, server := range doc.Servers {
+		serverURL, err := url.Parse(server.URL)
+		helper.PanicIfError(err)
+		if !seen[serverURL.Path] {
+			seen[serverURL.Path] = true
+			servers = append(servers, &openapi3.Server{URL: serverURL.Path})
+		}
+	}
+	doc.Servers = servers
+
+	router, err := gorillamux.NewRouter(doc)
+	helper.PanicIfError(err)
+
+	return &OpenAPIMiddleware{Router: router, ValidateResponses: validateResponses}
+}
+
+func (middleware *OpenAPIMiddleware) Validate() fiber.Handler {
+	options := &openapi3filter.Options{
+		// Authentication is enforced by AuthMiddleware
+		AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+		IncludeResponseStatus: true,
+		SkipSettingDefaults:   true,
+	}
+
+	return func(ctx *fiber.Ctx) error {
+		request, err := adaptor.ConvertRequest(ctx, false)
+		if err != nil {
+			return err
+		}
+		// fiber routes match with or without a trailing slash, the spec has none
+		if len(request.URL.Path) > 1 {
+			request.URL.Path = strings.TrimSuffix(request.URL.Path, "/")
+			request.URL.RawPath = ""
+		}
+
+		route, pathParams, err := middleware.Router.FindRoute(request)
+		if err != nil {
+			// Not documented, let fiber answer 404 or 405
+			return ctx.Next()
+		}
+
+		requestInput := &openapi3filter.RequestValidationInput{
+			Request:    request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		}
+		if err := validateContentType(requestInput); err != nil {
+			return err
+		}
+		if err := openapi3filter.ValidateRequest(context.Background(), requestInput); err != nil {
+			return helper.NewBadRequestError(requestErrorMessage(err))
+		}
+
+		if !middleware.ValidateResponses {
+			return ctx.Next()
+		}
+
+		// Render errors here so that error responses are validated as well
+		if err := nextRecovered(ctx); err != nil {
+			if err := ctx.App().Config().ErrorHandler(ctx, err); err != nil {
+				return err
+			}
+		}
+
+		// A streamed body is written after the handler returned and may never
+		// end, like the event stream, there is nothing to read here
+		if ctx.Response().IsBodyStream() {
+			return nil
+		}
+
+		header := http.Header{}
+		ctx.Response().Header.VisitAll(func(key, value []byte) {
+			header.Add(string(key), string(value))
+		})
+		responseInput := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: requestInput,
+			Status:                 ctx.Response().StatusCode(),
+			Header:                 header,
+			Options:                options,
+		}
+		responseInput.SetBodyBytes(ctx.Response().Body())
+
+		if err := openapi3filter.ValidateResponse(context.Background(), responseInput); err != nil {
+			log.Printf("response for %s %s does not match the API spec: %v", ctx.Method(), ctx.Path(), err)
+			return fiber.NewError(fiber.StatusInternalServerError, "response does not match the API spec: "+err.Error())
+		}
+
+		return nil
+	}
+}
+
+// validateContentType answers 415 for request bodies the operation does not accept
+func validateContentType(input *openapi3filter.RequestValidationInput) error {
+	requestBody := input.Route.Operation.RequestBody
+	if requestBody == nil || requestBody.Value == nil || input.Request.ContentLength == 0 {
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(input.Request.Header.Get(fiber.HeaderContentType))
+	if err != nil || requestBody.Value.Content.Get(mediaType) == nil {
+		mediaTypes := make([]string, 0, len(requestBody.Value.Content))
+		for mediaType := range requestBody.Value.Content {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+		sort.Strings(mediaTypes)
+		return fiber.NewError(fiber.StatusUnsupportedMediaType, "content type must be one of "+strings.Join(mediaTypes, ", "))
+	}
+
+	return nil
+}
+
+// requestErrorMessage words a request validation error like the validator
+// errors the services report
+func requestErrorMessage(err error) string {
+	var requestError *openapi3filter.RequestError
+	if !errors.As(err, &requestError) {
+		return "Validation failed: " + err.Error()
+	}
+
+	reasons := strings.Join(schemaErrorReasons(requestError.Err), "; ")
+	if requestError.Parameter != nil {
+		return fmt.Sprintf("Validation failed: parameter %q in %s: %s", requestError.Parameter.Name, requestError.Parameter.In, reasons)
+	}
+
+	return "Validation failed: " + reasons
+}
+
+// schemaErrorReasons flattens nested schema errors into their reasons,
+// leaving out the schema and value dumps of SchemaError.Error
+func schemaErrorReasons(err error) []string {
+	var multiError openapi3.MultiError
+	if errors.As(err, &multiError) {
+		var reasons []string
+		for _, cause := range multiError {
+			reasons = append(reasons, schemaErrorReasons(cause)...)
+		}
+		return reasons
+	}
+
+	var schemaError *openapi3.SchemaError
+	if !errors.As(err, &schemaError) {
+		return []string{err.Error()}
+	}
+	if schemaError.Origin != nil {
+		return schemaErrorReasons(schemaError.Origin)
+	}
+
+	// The JSON Schema 2020-12 validator already puts the location in the reason
+	if pointer := schemaError.JSONPointer(); len(pointer) > 0 && !strings.HasPrefix(schemaError.Reason, "error at") {
+		return []string{fmt.Sprintf("error at \"/%s\": %s", strings.Join(pointer, "/"), schemaError.Reason)}
+	}
+
+	return []string{schemaError.Reason}
+}
+
+// nextRecovered turns a panic further down the chain into an error, the way
+// the recover middleware does, so the resulting response can still be checked
+func nextRecovered(ctx *fiber.Ctx) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			if err, ok = r.(error); !ok {
+				err = fmt.Errorf("%v", r)
+			}
+		}
+	}()
+
+	return ctx.Next()
+}
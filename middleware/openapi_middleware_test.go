@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupOpenAPITestApp(contactHandler fiber.Handler) *fiber.App {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(ctx *fiber.Ctx, err error) error {
+			response := helper.NewErrorResponse(err)
+			return ctx.Status(response.Code).JSON(response)
+		},
+	})
+
+	openAPIMiddleware := NewOpenAPIMiddleware("../docs/apispec.yaml", true)
+	app.Use("/api", openAPIMiddleware.Validate())
+	app.Get("/api/contacts/:contactId", contactHandler)
+	app.Patch("/api/contacts/:contactId", contactHandler)
+	app.Get("/api/undocumented", func(ctx *fiber.Ctx) error {
+		return ctx.SendStatus(fiber.StatusNoContent)
+	})
+
+	return app
+}
+
+func validContactHandler(ctx *fiber.Ctx) error {
+	return ctx.JSON(web.Response{
+		Code:   200,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"id":         1,
+			"first_name": "John",
+			"last_name":  "Doe",
+			"email":      "john@example.com",
+			"phone":      "08123456789",
+			"version":    1,
+		},
+	})
+}
+
+func TestOpenAPIMiddlewareAcceptsValidExchange(t *testing.T) {
+	app := setupOpenAPITestApp(validContactHandler)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/contacts/1", nil), -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestOpenAPIMiddlewareRejectsInvalidPathParam(t *testing.T) {
+	app := setupOpenAPITestApp(validContactHandler)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/contacts/abc", nil), -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+func TestOpenAPIMiddlewareRejectsInvalidBody(t *testing.T) {
+	app := setupOpenAPITestApp(validContactHandler)
+
+	req := httptest.NewRequest("PATCH", "/api/contacts/1", strings.NewReader(`{"first_name":123}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+func TestOpenAPIMiddlewareRejectsUnsupportedMediaType(t *testing.T) {
+	app := setupOpenAPITestApp(validContactHandler)
+
+	req := httptest.NewRequest("PATCH", "/api/contacts/1", strings.NewReader(`first_name=Jane`))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := app.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 415, resp.StatusCode)
+}
+
+func TestOpenAPIMiddlewareReportsResponseDrift(t *testing.T) {
+	app := setupOpenAPITestApp(func(ctx *fiber.Ctx) error {
+		return ctx.JSON(web.Response{
+			Code:   200,
+			Status: "OK",
+			Data:   map[string]interface{}{"id": "not a number"},
+		})
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/contacts/1", nil), -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 500, resp.StatusCode)
+}
+
+func TestOpenAPIMiddlewareReportsUndocumentedStatus(t *testing.T) {
+	app := setupOpenAPITestApp(func(ctx *fiber.Ctx) error {
+		panic(helper.NewResourceConflictError("conflict"))
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/contacts/1", nil), -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 500, resp.StatusCode)
+}
+
+func TestOpenAPIMiddlewareSkipsUndocumentedRoutes(t *testing.T) {
+	app := setupOpenAPITestApp(validContactHandler)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/undocumented", nil), -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 204, resp.StatusCode)
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/service"
+)
+
+// OrganizationHeader selects the organization a request runs in
+const OrganizationHeader = "X-Organization-ID"
+
+type OrganizationMiddleware struct {
+	OrganizationService service.OrganizationService
+}
+
+func NewOrganizationMiddleware(organizationService service.OrganizationService) *OrganizationMiddleware {
+	return &OrganizationMiddleware{OrganizationService: organizationService}
+}
+
+// Resolve sets the organization the request runs in, which every tenant
+// scoped repository query is limited to. It is taken from the organizationId
+// route parameter, or else the X-Organization-ID header, or else it is the
+// user's first organization. It runs after AuthMiddleware.Authenticate.
+func (middleware *OrganizationMiddleware) Resolve() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		user := ctx.Locals("user").(*domain.User)
+
+		value := ctx.Params("organizationId")
+		if value == "" {
+			value = ctx.Get(OrganizationHeader)
+		}
+
+		var organizationID int64
+		if value != "" {
+			var err error
+			organizationID, err = strconv.ParseInt(value, 10, 64)
+			if err != nil || organizationID < 1 {
+				return ctx.Status(fiber.StatusBadRequest).JSON(web.Response{
+					Code:   400,
+					Status: "Bad Request",
+					Data:   "Invalid organization ID",
+				})
+			}
+		}
+
+		organization := middleware.OrganizationService.Resolve(ctx, *user, organizationID)
+		ctx.Locals("organization", organization)
+
+		return ctx.Next()
+	}
+}
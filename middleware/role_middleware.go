@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web"
+)
+
+// RequireRole lets the request through when the authenticated user has one of
+// the roles. It runs after AuthMiddleware.Authenticate.
+func RequireRole(roles ...string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		user, ok := ctx.Locals("user").(*domain.User)
+		if !ok {
+			return ctx.Status(fiber.StatusUnauthorized).JSON(web.Response{
+				Code:   401,
+				Status: "Unauthorized",
+				Data:   "Missing authorization header",
+			})
+		}
+
+		if !slices.Contains(roles, user.Role) {
+			return ctx.Status(fiber.StatusForbidden).JSON(web.Response{
+				Code:   403,
+				Status: "Forbidden",
+				Data:   "Insufficient role",
+			})
+		}
+
+		return ctx.Next()
+	}
+}
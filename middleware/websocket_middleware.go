@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// WebSocketCredentials lets a WebSocket handshake give the API token and the
+// organization as the token and organization_id query parameters, browsers
+// cannot set headers on it. Headers the client did set take precedence. It
+// runs before AuthMiddleware.Authenticate.
+func WebSocketCredentials() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if token := ctx.Query("token"); token != "" && ctx.Get(fiber.HeaderAuthorization) == "" {
+			ctx.Request().Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+		}
+		if organizationID := ctx.Query("organization_id"); organizationID != "" && ctx.Get(OrganizationHeader) == "" {
+			ctx.Request().Header.Set(OrganizationHeader, organizationID)
+		}
+		return ctx.Next()
+	}
+}
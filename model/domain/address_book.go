@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+type AddressBook struct {
+	ID             int64     `gorm:"column:id;primaryKey;autoIncrement;<-:create"`
+	OrganizationID int64     `gorm:"column:organization_id"`
+	OwnerID        int       `gorm:"column:owner_id"`
+	Name           string    `gorm:"column:name"`
+	CreatedAt      time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;<-:create"`
+	UpdatedAt      time.Time `gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;autoUpdateTime:true"`
+}
+
+func (addressBook *AddressBook) TableName() string {
+	return "address_books"
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationDeclined = "declined"
+)
+
+type AddressBookInvitation struct {
+	ID             int64       `gorm:"column:id;primaryKey;autoIncrement;<-:create"`
+	OrganizationID int64       `gorm:"column:organization_id"`
+	AddressBookID  int64       `gorm:"column:address_book_id"`
+	InviterID      int         `gorm:"column:inviter_id"`
+	InviteeID      int         `gorm:"column:invitee_id"`
+	Permission     string      `gorm:"column:permission"`
+	Status         string      `gorm:"column:status"`
+	CreatedAt      time.Time   `gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;<-:create"`
+	UpdatedAt      time.Time   `gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;autoUpdateTime:true"`
+	AddressBook    AddressBook `gorm:"foreignKey:AddressBookID;references:ID"`
+	Inviter        User        `gorm:"foreignKey:InviterID;references:ID"`
+	Invitee        User        `gorm:"foreignKey:InviteeID;references:ID"`
+}
+
+func (invitation *AddressBookInvitation) TableName() string {
+	return "address_book_invitations"
+}
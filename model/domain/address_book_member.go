@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// Address book permissions, each one allows everything the previous one does
+const (
+	PermissionViewer = "viewer"
+	PermissionEditor = "editor"
+	PermissionOwner  = "owner"
+)
+
+var permissionRanks = map[string]int{
+	PermissionViewer: 1,
+	PermissionEditor: 2,
+	PermissionOwner:  3,
+}
+
+type AddressBookMember struct {
+	ID             int64       `gorm:"column:id;primaryKey;autoIncrement;<-:create"`
+	OrganizationID int64       `gorm:"column:organization_id"`
+	AddressBookID  int64       `gorm:"column:address_book_id"`
+	UserID         int         `gorm:"column:user_id"`
+	Permission     string      `gorm:"column:permission"`
+	CreatedAt      time.Time   `gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;<-:create"`
+	UpdatedAt      time.Time   `gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;autoUpdateTime:true"`
+	AddressBook    AddressBook `gorm:"foreignKey:AddressBookID;references:ID"`
+	User           User        `gorm:"foreignKey:UserID;references:ID"`
+}
+
+func (member *AddressBookMember) TableName() string {
+	return "address_book_members"
+}
+
+// Allows reports whether the member's permission includes permission
+func (member *AddressBookMember) Allows(permission string) bool {
+	return permissionRanks[member.Permission] >= permissionRanks[permission]
+}
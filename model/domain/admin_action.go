@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+const (
+	AdminActionDisable       = "disable"
+	AdminActionEnable        = "enable"
+	AdminActionForceLogout   = "force_logout"
+	AdminActionSetRole       = "set_role"
+	AdminActionResetPassword = "reset_password"
+	AdminActionRevokeTokens  = "revoke_tokens"
+	AdminActionDelete        = "delete"
+	AdminActionHardDelete    = "hard_delete"
+)
+
+// AdminAction records a change an admin made to a user account. AdminID is nil
+// for changes made by an operator with the admin command line.
+type AdminAction struct {
+	ID           int64     `gorm:"column:id;primaryKey;autoIncrement;<-:create"`
+	AdminID      *int      `gorm:"column:admin_id"`
+	TargetUserID int       `gorm:"column:target_user_id"`
+	Action       string    `gorm:"column:action"`
+	Details      string    `gorm:"column:details"`
+	CreatedAt    time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;<-:create"`
+}
+
+func (adminAction *AdminAction) TableName() string {
+	return "admin_actions"
+}
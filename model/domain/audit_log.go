@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+
+	AuditEntityUser    = "user"
+	AuditEntityContact = "contact"
+	AuditEntityAddress = "address"
+)
+
+// AuditLog records one change to a user, contact or address. OrganizationID is
+// nil for changes to users, which belong to no organization. Every column is
+// written once, the table rejects updates and deletes.
+type AuditLog struct {
+	ID             int64                  `gorm:"column:id;primaryKey;autoIncrement;<-:create"`
+	OrganizationID *int64                 `gorm:"column:organization_id;<-:create"`
+	ActorID        *int                   `gorm:"column:actor_id;<-:create"`
+	Action         string                 `gorm:"column:action;<-:create"`
+	EntityType     string                 `gorm:"column:entity_type;<-:create"`
+	EntityID       int64                  `gorm:"column:entity_id;<-:create"`
+	Changes        map[string]AuditChange `gorm:"column:changes;serializer:json;<-:create"`
+	RequestID      string                 `gorm:"column:request_id;<-:create"`
+	IP             string                 `gorm:"column:ip;<-:create"`
+	CreatedAt      time.Time              `gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;<-:create"`
+}
+
+// AuditChange is the value of a field before and after the change, Before is
+// nil for a create and After is nil for a delete
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+func (auditLog *AuditLog) TableName() string {
+	return "audit_logs"
+}
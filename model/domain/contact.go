@@ -7,17 +7,25 @@ import (
 )
 
 type Contact struct {
-	ID        int64          `gorm:"column:id;primaryKey;autoIncrement;<-:create"`
-	UserID    int            `gorm:"column:user_id"`
-	FirstName string         `gorm:"column:first_name"`
-	LastName  string         `gorm:"column:last_name"`
-	Email     string         `gorm:"column:email"`
-	Phone     string         `gorm:"column:phone"`
-	CreatedAt time.Time      `gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;<-:create"`
-	UpdatedAt time.Time      `gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;autoUpdateTime:true"`
-	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at"`
-	User      User           `gorm:"foreignKey:UserID;references:ID"`
-	Addresses []Address      `gorm:"foreignKey:ContactID;references:ID"`
+	ID              int64          `gorm:"column:id;primaryKey;autoIncrement;<-:create"`
+	OrganizationID  int64          `gorm:"column:organization_id"`
+	UserID          int            `gorm:"column:user_id"`
+	AddressBookID   int64          `gorm:"column:address_book_id"`
+	FirstName       string         `gorm:"column:first_name"`
+	LastName        string         `gorm:"column:last_name"`
+	Email           string         `gorm:"column:email"`
+	Phone           string         `gorm:"column:phone"`
+	Version         int64          `gorm:"column:version"`
+	VCardName       *string        `gorm:"column:vcard_name"`
+	VCardUID        *string        `gorm:"column:vcard_uid"`
+	PhotoKey        *string        `gorm:"column:photo_key"`
+	CreatedAt       time.Time      `gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;<-:create"`
+	UpdatedAt       time.Time      `gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;autoUpdateTime:true"`
+	DeletedAt       gorm.DeletedAt `gorm:"column:deleted_at"`
+	DeletionBatchID *string        `gorm:"column:deletion_batch_id"`
+	User            User           `gorm:"foreignKey:UserID;references:ID"`
+	AddressBook     AddressBook    `gorm:"foreignKey:AddressBookID;references:ID"`
+	Addresses       []Address      `gorm:"foreignKey:ContactID;references:ID"`
 }
 
 func (contact *Contact) TableName() string {
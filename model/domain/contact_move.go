@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// ContactMove records a contact moving from one address book to another
+type ContactMove struct {
+	ID                int64     `gorm:"column:id;primaryKey;autoIncrement;<-:create"`
+	OrganizationID    int64     `gorm:"column:organization_id;<-:create"`
+	ContactID         int64     `gorm:"column:contact_id;<-:create"`
+	FromAddressBookID int64     `gorm:"column:from_address_book_id;<-:create"`
+	ToAddressBookID   int64     `gorm:"column:to_address_book_id;<-:create"`
+	MovedAt           time.Time `gorm:"column:moved_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;<-:create"`
+}
+
+func (contactMove *ContactMove) TableName() string {
+	return "contact_moves"
+}
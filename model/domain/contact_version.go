@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// ContactVersion is a snapshot of a contact and its addresses, taken after
+// every change to either. Number counts the versions of one contact from 1,
+// ContactVersion is the version the contact itself had at the time.
+type ContactVersion struct {
+	ID             int64           `gorm:"column:id;primaryKey;autoIncrement;<-:create"`
+	OrganizationID int64           `gorm:"column:organization_id;<-:create"`
+	ContactID      int64           `gorm:"column:contact_id;<-:create"`
+	Number         int             `gorm:"column:number;<-:create"`
+	ContactVersion int64           `gorm:"column:contact_version;<-:create"`
+	ActorID        *int            `gorm:"column:actor_id;<-:create"`
+	Snapshot       ContactSnapshot `gorm:"column:snapshot;serializer:json;<-:create"`
+	CreatedAt      time.Time       `gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;<-:create"`
+}
+
+type ContactSnapshot struct {
+	AddressBookID int64             `json:"address_book_id"`
+	FirstName     string            `json:"first_name"`
+	LastName      string            `json:"last_name"`
+	Email         string            `json:"email"`
+	Phone         string            `json:"phone"`
+	Addresses     []AddressSnapshot `json:"addresses"`
+}
+
+type AddressSnapshot struct {
+	ID         int64  `json:"id"`
+	Street     string `json:"street"`
+	City       string `json:"city"`
+	Province   string `json:"province"`
+	Country    string `json:"country"`
+	PostalCode string `json:"postal_code"`
+}
+
+func (contactVersion *ContactVersion) TableName() string {
+	return "contact_versions"
+}
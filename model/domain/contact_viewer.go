@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// ContactViewer is a user looking at a contact over one collaboration
+// connection. It counts until ExpiresAt, the connection keeps pushing it back.
+type ContactViewer struct {
+	ID             int64     `gorm:"column:id;primaryKey;autoIncrement;<-:create"`
+	OrganizationID int64     `gorm:"column:organization_id;<-:create"`
+	ContactID      int64     `gorm:"column:contact_id;<-:create"`
+	UserID         int       `gorm:"column:user_id;<-:create"`
+	ConnectionID   string    `gorm:"column:connection_id;<-:create"`
+	ExpiresAt      time.Time `gorm:"column:expires_at"`
+	User           User      `gorm:"foreignKey:UserID;references:ID"`
+}
+
+func (viewer *ContactViewer) TableName() string {
+	return "contact_viewers"
+}
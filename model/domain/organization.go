@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// DefaultAddressBookName names the address book users get when an
+// organization does not set one
+const DefaultAddressBookName = "Contacts"
+
+type Organization struct {
+	ID        int64                `gorm:"column:id;primaryKey;autoIncrement;<-:create"`
+	Name      string               `gorm:"column:name"`
+	Settings  OrganizationSettings `gorm:"column:settings;serializer:json"`
+	CreatedAt time.Time            `gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;<-:create"`
+	UpdatedAt time.Time            `gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;autoUpdateTime:true"`
+}
+
+func (organization *Organization) TableName() string {
+	return "organizations"
+}
+
+// OrganizationSettings are stored as JSON, zero values mean the default
+type OrganizationSettings struct {
+	// MaxContacts limits the contacts of the organization, 0 is no limit
+	MaxContacts int `json:"max_contacts"`
+	// DefaultAddressBookName names the address book created for members
+	DefaultAddressBookName string `json:"default_address_book_name"`
+}
+
+// AddressBookName returns the name of the address book members get
+func (settings OrganizationSettings) AddressBookName() string {
+	if settings.DefaultAddressBookName == "" {
+		return DefaultAddressBookName
+	}
+	return settings.DefaultAddressBookName
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// Organization roles, each one allows everything the previous one does
+const (
+	OrganizationRoleMember = "member"
+	OrganizationRoleAdmin  = "admin"
+	OrganizationRoleOwner  = "owner"
+)
+
+var organizationRoleRanks = map[string]int{
+	OrganizationRoleMember: 1,
+	OrganizationRoleAdmin:  2,
+	OrganizationRoleOwner:  3,
+}
+
+type OrganizationMember struct {
+	ID             int64        `gorm:"column:id;primaryKey;autoIncrement;<-:create"`
+	OrganizationID int64        `gorm:"column:organization_id"`
+	UserID         int          `gorm:"column:user_id"`
+	Role           string       `gorm:"column:role"`
+	CreatedAt      time.Time    `gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;<-:create"`
+	UpdatedAt      time.Time    `gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;autoUpdateTime:true"`
+	Organization   Organization `gorm:"foreignKey:OrganizationID;references:ID"`
+	User           User         `gorm:"foreignKey:UserID;references:ID"`
+}
+
+func (member *OrganizationMember) TableName() string {
+	return "organization_members"
+}
+
+// Allows reports whether the member's role includes role
+func (member *OrganizationMember) Allows(role string) bool {
+	return organizationRoleRanks[member.Role] >= organizationRoleRanks[role]
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OutboxEvent is a domain event waiting to be dispatched to its subscribers,
+// or a dispatched one when DispatchedAt is set. It is written in the
+// transaction of the change that raised it, so it exists if and only if the
+// change committed.
+type OutboxEvent struct {
+	ID             int64           `gorm:"column:id;primaryKey;autoIncrement;<-:create"`
+	EventID        string          `gorm:"column:event_id;<-:create"`
+	Name           string          `gorm:"column:name;<-:create"`
+	OrganizationID *int64          `gorm:"column:organization_id;<-:create"`
+	ActorID        *int            `gorm:"column:actor_id;<-:create"`
+	Payload        json.RawMessage `gorm:"column:payload;<-:create"`
+	Attempts       int             `gorm:"column:attempts"`
+	NextAttemptAt  *time.Time      `gorm:"column:next_attempt_at"`
+	Error          string          `gorm:"column:error"`
+	DispatchedAt   *time.Time      `gorm:"column:dispatched_at"`
+	CreatedAt      time.Time       `gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;<-:create"`
+}
+
+func (outboxEvent *OutboxEvent) TableName() string {
+	return "outbox_events"
+}
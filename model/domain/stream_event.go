@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// StreamEvent is a contact or address change as the live event stream sends
+// it. ID orders the changes and is the event ID clients resume from.
+type StreamEvent struct {
+	ID             int64           `gorm:"column:id;primaryKey;autoIncrement;<-:create"`
+	EventID        string          `gorm:"column:event_id;<-:create"`
+	OrganizationID int64           `gorm:"column:organization_id;<-:create"`
+	AddressBookID  int64           `gorm:"column:address_book_id;<-:create"`
+	Name           string          `gorm:"column:name;<-:create"`
+	Payload        json.RawMessage `gorm:"column:payload;<-:create"`
+	OccurredAt     time.Time       `gorm:"column:occurred_at;<-:create"`
+}
+
+func (streamEvent *StreamEvent) TableName() string {
+	return "stream_events"
+}
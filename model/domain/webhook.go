@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Webhook is an endpoint of the organization that is sent the events it
+// subscribes to, named like the domain events. Secret signs the deliveries and is never shown again.
+type Webhook struct {
+	ID             int64     `gorm:"column:id;primaryKey;autoIncrement;<-:create"`
+	OrganizationID int64     `gorm:"column:organization_id;<-:create"`
+	CreatedBy      *int      `gorm:"column:created_by;<-:create"`
+	URL            string    `gorm:"column:url"`
+	Secret         string    `gorm:"column:secret"`
+	Events         []string  `gorm:"column:events;serializer:json"`
+	Active         bool      `gorm:"column:active"`
+	CreatedAt      time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;<-:create"`
+	UpdatedAt      time.Time `gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;autoUpdateTime:true"`
+}
+
+func (webhook *Webhook) TableName() string {
+	return "webhooks"
+}
+
+// Subscribes reports whether the webhook is sent event
+func (webhook *Webhook) Subscribes(event string) bool {
+	for _, subscribed := range webhook.Events {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event queued for one webhook. Payload is the exact
+// body that is signed and sent, so a redelivery sends the same bytes.
+type WebhookDelivery struct {
+	ID             int64           `gorm:"column:id;primaryKey;autoIncrement;<-:create"`
+	OrganizationID int64           `gorm:"column:organization_id;<-:create"`
+	WebhookID      int64           `gorm:"column:webhook_id;<-:create"`
+	EventID        string          `gorm:"column:event_id;<-:create"`
+	Event          string          `gorm:"column:event;<-:create"`
+	Payload        json.RawMessage `gorm:"column:payload;<-:create"`
+	Status         string          `gorm:"column:status"`
+	Attempts       int             `gorm:"column:attempts"`
+	NextAttemptAt  *time.Time      `gorm:"column:next_attempt_at"`
+	ResponseStatus int             `gorm:"column:response_status"`
+	Error          string          `gorm:"column:error"`
+	DeliveredAt    *time.Time      `gorm:"column:delivered_at"`
+	CreatedAt      time.Time       `gorm:"column:created_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;<-:create"`
+	UpdatedAt      time.Time       `gorm:"column:updated_at;default:CURRENT_TIMESTAMP;autoCreateTime:true;autoUpdateTime:true"`
+	Webhook        Webhook         `gorm:"foreignKey:WebhookID;references:ID"`
+}
+
+func (delivery *WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
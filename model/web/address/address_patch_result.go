@@ -0,0 +1,12 @@
+package address
+
+// AddressPatchResult is an address with a merge patch applied. It is validated
+// like AddressCreateRequest, except that Street, Province and PostalCode,
+// which a patch can clear, may be empty.
+type AddressPatchResult struct {
+	Street     string `json:"street" validate:"omitempty,max=200"`
+	City       string `json:"city" validate:"required,min=1,max=100"`
+	Province   string `json:"province" validate:"omitempty,max=100"`
+	Country    string `json:"country" validate:"required,min=1,max=100"`
+	PostalCode string `json:"postal_code" validate:"omitempty,max=10"`
+}
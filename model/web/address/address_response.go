@@ -1,5 +1,9 @@
 package address
 
+// Fields are the members an address response can be limited to with fields,
+// named like their columns
+var Fields = []string{"id", "street", "city", "province", "country", "postal_code", "version"}
+
 type AddressResponse struct {
 	ID         int64  `json:"id"`
 	Street     string `json:"street"`
@@ -7,4 +11,5 @@ type AddressResponse struct {
 	Province   string `json:"province"`
 	Country    string `json:"country"`
 	PostalCode string `json:"postal_code"`
+	Version    int64  `json:"version"`
 }
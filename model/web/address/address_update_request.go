@@ -1,9 +1,13 @@
 package address
 
+import "github.com/sorfian/go-contact-management-api/model/web"
+
+// AddressUpdateRequest is the JSON merge patch document for an address. Members
+// left unset are kept and members set to web.ClearString() are cleared.
 type AddressUpdateRequest struct {
-	Street     string `json:"street" validate:"omitempty,min=1,max=200"`
-	City       string `json:"city" validate:"omitempty,min=1,max=100"`
-	Province   string `json:"province" validate:"omitempty,min=1,max=100"`
-	Country    string `json:"country" validate:"omitempty,min=1,max=100"`
-	PostalCode string `json:"postal_code" validate:"omitempty,min=1,max=10"`
+	Street     web.PatchString `json:"street,omitzero"`
+	City       web.PatchString `json:"city,omitzero"`
+	Province   web.PatchString `json:"province,omitzero"`
+	Country    web.PatchString `json:"country,omitzero"`
+	PostalCode web.PatchString `json:"postal_code,omitzero"`
 }
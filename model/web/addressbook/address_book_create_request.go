@@ -0,0 +1,5 @@
+package addressbook
+
+type AddressBookCreateRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=100"`
+}
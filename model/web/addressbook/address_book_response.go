@@ -0,0 +1,13 @@
+package addressbook
+
+import "time"
+
+// AddressBookResponse is an address book as one of its members sees it,
+// Permission is the permission of that member
+type AddressBookResponse struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	OwnerID    int       `json:"owner_id"`
+	Permission string    `json:"permission"`
+	CreatedAt  time.Time `json:"created_at"`
+}
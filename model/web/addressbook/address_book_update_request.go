@@ -0,0 +1,6 @@
+package addressbook
+
+// AddressBookUpdateRequest is the JSON merge patch document for an address book
+type AddressBookUpdateRequest struct {
+	Name string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,6 @@
+package addressbook
+
+type InvitationCreateRequest struct {
+	Username   string `json:"username" validate:"required,min=3,max=100"`
+	Permission string `json:"permission" validate:"required,oneof=viewer editor"`
+}
@@ -0,0 +1,14 @@
+package addressbook
+
+import "time"
+
+type InvitationResponse struct {
+	ID              int64     `json:"id"`
+	AddressBookID   int64     `json:"address_book_id"`
+	AddressBookName string    `json:"address_book_name"`
+	Inviter         string    `json:"inviter"`
+	Invitee         string    `json:"invitee"`
+	Permission      string    `json:"permission"`
+	Status          string    `json:"status"`
+	CreatedAt       time.Time `json:"created_at"`
+}
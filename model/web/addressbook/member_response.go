@@ -0,0 +1,8 @@
+package addressbook
+
+type MemberResponse struct {
+	UserID     int    `json:"user_id"`
+	Username   string `json:"username"`
+	Name       string `json:"name"`
+	Permission string `json:"permission"`
+}
@@ -0,0 +1,7 @@
+package addressbook
+
+// MemberUpdateRequest changes what a member may do, an address book has
+// exactly one owner so ownership cannot be given away
+type MemberUpdateRequest struct {
+	Permission string `json:"permission" validate:"required,oneof=viewer editor"`
+}
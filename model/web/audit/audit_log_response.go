@@ -0,0 +1,26 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web"
+)
+
+type AuditLogResponse struct {
+	ID             int64                         `json:"id"`
+	OrganizationID *int64                        `json:"organization_id"`
+	ActorID        *int                          `json:"actor_id"`
+	Action         string                        `json:"action"`
+	EntityType     string                        `json:"entity_type"`
+	EntityID       int64                         `json:"entity_id"`
+	Changes        map[string]domain.AuditChange `json:"changes"`
+	RequestID      string                        `json:"request_id"`
+	IP             string                        `json:"ip"`
+	CreatedAt      time.Time                     `json:"created_at"`
+}
+
+type AuditLogListResult struct {
+	Logs   []AuditLogResponse `json:"logs"`
+	Paging web.PagingResponse `json:"paging"`
+}
@@ -0,0 +1,18 @@
+package audit
+
+import "time"
+
+// AuditLogSearchParams filters the audit logs admins list, zero values match
+// every log
+type AuditLogSearchParams struct {
+	OrganizationID int64
+	ActorID        int
+	Action         string
+	EntityType     string
+	EntityID       int64
+	RequestID      string
+	From           *time.Time
+	To             *time.Time
+	Page           int
+	Size           int
+}
@@ -0,0 +1,94 @@
+package carddav
+
+import (
+	"encoding/xml"
+	"sort"
+)
+
+const (
+	StatusOK       = "HTTP/1.1 200 OK"
+	StatusNotFound = "HTTP/1.1 404 Not Found"
+	// StatusInsufficientStorage answers the collection when an
+	// addressbook-query returns fewer cards than match
+	StatusInsufficientStorage = "HTTP/1.1 507 Insufficient Storage"
+)
+
+// namespacePrefixes are the prefixes the multistatus root declares
+var namespacePrefixes = map[string]string{
+	NamespaceDAV:     "D",
+	NamespaceCardDAV: "C",
+}
+
+type Multistatus struct {
+	XMLName   xml.Name   `xml:"D:multistatus"`
+	DAV       string     `xml:"xmlns:D,attr"`
+	CardDAV   string     `xml:"xmlns:C,attr"`
+	Responses []Response `xml:"D:response"`
+	SyncToken string     `xml:"D:sync-token,omitempty"`
+}
+
+type Response struct {
+	Href      string     `xml:"D:href"`
+	Propstats []Propstat `xml:"D:propstat"`
+	Status    string     `xml:"D:status,omitempty"`
+}
+
+type Propstat struct {
+	Values []Property `xml:"D:prop>property"`
+	Status string     `xml:"D:status"`
+}
+
+// Property is a property of a resource. Value is XML, escaped text for most.
+type Property struct {
+	XMLName xml.Name
+	Value   string `xml:",innerxml"`
+}
+
+func NewMultistatus() *Multistatus {
+	return &Multistatus{DAV: NamespaceDAV, CardDAV: NamespaceCardDAV, Responses: []Response{}}
+}
+
+// NewResponse answers a resource with the properties asked for that it has,
+// and with those it has not as not found. Without names it answers all of
+// them.
+func NewResponse(href string, properties map[xml.Name]string, names []xml.Name) Response {
+	if names == nil {
+		for name := range properties {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if names[i].Space != names[j].Space {
+				return names[i].Space < names[j].Space
+			}
+			return names[i].Local < names[j].Local
+		})
+	}
+
+	found := []Property{}
+	missing := []Property{}
+	for _, name := range names {
+		if value, ok := properties[name]; ok {
+			found = append(found, Property{XMLName: prefixed(name), Value: value})
+		} else {
+			missing = append(missing, Property{XMLName: prefixed(name)})
+		}
+	}
+
+	response := Response{Href: href}
+	if len(found) > 0 || len(missing) == 0 {
+		response.Propstats = append(response.Propstats, Propstat{Values: found, Status: StatusOK})
+	}
+	if len(missing) > 0 {
+		response.Propstats = append(response.Propstats, Propstat{Values: missing, Status: StatusNotFound})
+	}
+	return response
+}
+
+// prefixed writes a name with the prefix the root declares for its
+// namespace, names of other namespaces declare their own
+func prefixed(name xml.Name) xml.Name {
+	if prefix, ok := namespacePrefixes[name.Space]; ok {
+		return xml.Name{Local: prefix + ":" + name.Local}
+	}
+	return name
+}
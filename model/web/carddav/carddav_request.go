@@ -0,0 +1,81 @@
+package carddav
+
+import "encoding/xml"
+
+const (
+	NamespaceDAV     = "DAV:"
+	NamespaceCardDAV = "urn:ietf:params:xml:ns:carddav"
+)
+
+// Reports a collection supports
+var (
+	ReportAddressBookQuery    = xml.Name{Space: NamespaceCardDAV, Local: "addressbook-query"}
+	ReportAddressBookMultiget = xml.Name{Space: NamespaceCardDAV, Local: "addressbook-multiget"}
+	ReportSyncCollection      = xml.Name{Space: NamespaceDAV, Local: "sync-collection"}
+)
+
+// PropName is a property a request asks for
+type PropName struct {
+	XMLName xml.Name
+}
+
+type Prop struct {
+	Names []PropName `xml:",any"`
+}
+
+// Propfind is the body of a PROPFIND, an empty body asks for all properties
+type Propfind struct {
+	XMLName xml.Name  `xml:"DAV: propfind"`
+	AllProp *struct{} `xml:"DAV: allprop"`
+	Prop    *Prop     `xml:"DAV: prop"`
+}
+
+// Report is the body of a REPORT, the root element tells which one
+type Report struct {
+	XMLName   xml.Name
+	AllProp   *struct{} `xml:"DAV: allprop"`
+	Prop      *Prop     `xml:"DAV: prop"`
+	Hrefs     []string  `xml:"DAV: href"`
+	SyncToken string    `xml:"DAV: sync-token"`
+	Filter    *Filter   `xml:"urn:ietf:params:xml:ns:carddav filter"`
+	Limit     *Limit    `xml:"urn:ietf:params:xml:ns:carddav limit"`
+}
+
+// Filter selects the cards of an addressbook-query. Its test is anyof unless
+// it is allof, as is the test of each prop-filter.
+type Filter struct {
+	Test        string       `xml:"test,attr"`
+	PropFilters []PropFilter `xml:"urn:ietf:params:xml:ns:carddav prop-filter"`
+}
+
+type PropFilter struct {
+	Name         string      `xml:"name,attr"`
+	Test         string      `xml:"test,attr"`
+	IsNotDefined *struct{}   `xml:"urn:ietf:params:xml:ns:carddav is-not-defined"`
+	TextMatches  []TextMatch `xml:"urn:ietf:params:xml:ns:carddav text-match"`
+}
+
+type TextMatch struct {
+	Text            string `xml:",chardata"`
+	Collation       string `xml:"collation,attr"`
+	NegateCondition string `xml:"negate-condition,attr"`
+	MatchType       string `xml:"match-type,attr"`
+}
+
+type Limit struct {
+	NResults int `xml:"urn:ietf:params:xml:ns:carddav nresults"`
+}
+
+// PropNames returns the names of the properties asked for, nil for all of
+// them
+func PropNames(prop *Prop) []xml.Name {
+	if prop == nil {
+		return nil
+	}
+
+	names := make([]xml.Name, 0, len(prop.Names))
+	for _, name := range prop.Names {
+		names = append(names, name.XMLName)
+	}
+	return names
+}
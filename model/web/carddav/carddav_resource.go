@@ -0,0 +1,29 @@
+package carddav
+
+// SyncTokenPrefix makes the sync tokens of a CardDAV collection URIs, the
+// rest is the token of the delta sync
+const SyncTokenPrefix = "urn:x-contact-management:sync:"
+
+// AddressBook is an address book as a CardDAV collection
+type AddressBook struct {
+	OrganizationID int64
+	ID             int64
+	Name           string
+	Writable       bool
+	SyncToken      string
+}
+
+// Card is a contact as a vCard resource of its address book
+type Card struct {
+	Name string
+	ETag string
+	Data []byte
+}
+
+// Changes are the cards changed since a sync token, and the names of those
+// deleted or moved to another address book
+type Changes struct {
+	Token   string
+	Cards   []Card
+	Removed []string
+}
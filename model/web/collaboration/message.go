@@ -0,0 +1,57 @@
+package collaboration
+
+import "encoding/json"
+
+// Message types of a collaboration connection
+const (
+	MessageSubscribe    = "subscribe"
+	MessageUnsubscribe  = "unsubscribe"
+	MessageSubscribed   = "subscribed"
+	MessageUnsubscribed = "unsubscribed"
+	MessageChange       = "change"
+	MessagePresence     = "presence"
+	MessageError        = "error"
+)
+
+// ClientMessage subscribes to or unsubscribes from a contact, or the whole
+// contact list when ContactID is left out
+type ClientMessage struct {
+	Type      string `json:"type"`
+	ContactID int64  `json:"contact_id,omitempty"`
+}
+
+// SubscriptionMessage confirms a subscribe or unsubscribe message
+type SubscriptionMessage struct {
+	Type      string `json:"type"`
+	ContactID int64  `json:"contact_id,omitempty"`
+}
+
+// ChangeMessage is a change to a subscribed contact, the same as a message of
+// the live event stream
+type ChangeMessage struct {
+	Type      string          `json:"type"`
+	ID        int64           `json:"id"`
+	Event     string          `json:"event"`
+	ContactID int64           `json:"contact_id"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// PresenceMessage lists the other users viewing a subscribed contact. It is
+// sent on subscribing and whenever the viewers change.
+type PresenceMessage struct {
+	Type      string           `json:"type"`
+	ContactID int64            `json:"contact_id"`
+	Viewers   []ViewerResponse `json:"viewers"`
+}
+
+type ErrorMessage struct {
+	Type      string `json:"type"`
+	ContactID int64  `json:"contact_id,omitempty"`
+	Message   string `json:"message"`
+}
+
+type ViewerResponse struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
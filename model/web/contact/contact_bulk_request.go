@@ -0,0 +1,24 @@
+package contact
+
+import "encoding/json"
+
+const (
+	BulkModeTransaction = "transaction"
+	BulkModeBestEffort  = "best_effort"
+
+	BulkOperationCreate = "create"
+	BulkOperationUpdate = "update"
+	BulkOperationDelete = "delete"
+)
+
+type BulkOperation struct {
+	Operation string          `json:"operation" validate:"required,oneof=create update delete"`
+	ID        int64           `json:"id" validate:"required_unless=Operation create"`
+	Version   int64           `json:"version" validate:"min=0"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type BulkRequest struct {
+	Mode       string          `json:"mode,omitempty" validate:"omitempty,oneof=transaction best_effort"`
+	Operations []BulkOperation `json:"operations" validate:"required,min=1,max=100,dive"`
+}
@@ -0,0 +1,15 @@
+package contact
+
+type BulkOperationResult struct {
+	Index     int         `json:"index"`
+	Operation string      `json:"operation"`
+	Code      int         `json:"code"`
+	Status    string      `json:"status"`
+	Data      interface{} `json:"data"`
+}
+
+type BulkResult struct {
+	Mode      string                `json:"mode"`
+	Committed bool                  `json:"committed"`
+	Results   []BulkOperationResult `json:"results"`
+}
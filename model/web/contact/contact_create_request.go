@@ -1,8 +1,15 @@
 package contact
 
+// ContactCreateRequest creates a contact in AddressBookID, or in the first
+// address book the user owns when it is left out
 type ContactCreateRequest struct {
-	FirstName string `json:"first_name" validate:"required,min=1,max=100"`
-	LastName  string `json:"last_name" validate:"required,min=1,max=100"`
-	Email     string `json:"email" validate:"required,email,max=100"`
-	Phone     string `json:"phone" validate:"required,min=1,max=20"`
+	AddressBookID int64  `json:"address_book_id,omitempty" validate:"omitempty,min=1"`
+	FirstName     string `json:"first_name" validate:"required,min=1,max=100"`
+	LastName      string `json:"last_name" validate:"required,min=1,max=100"`
+	Email         string `json:"email" validate:"required,email,max=100"`
+	Phone         string `json:"phone" validate:"required,min=1,max=20"`
+	// VCardName and VCardUID name the card a CardDAV client created the
+	// contact as, they are not part of the JSON API
+	VCardName string `json:"-"`
+	VCardUID  string `json:"-"`
 }
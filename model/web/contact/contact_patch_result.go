@@ -0,0 +1,12 @@
+package contact
+
+// ContactPatchResult is a contact with a merge patch applied. It is validated
+// like ContactCreateRequest, except that Email and Phone, which a patch can
+// clear, may be empty.
+type ContactPatchResult struct {
+	AddressBookID int64  `json:"address_book_id,omitempty" validate:"omitempty,min=1"`
+	FirstName     string `json:"first_name" validate:"required,min=1,max=100"`
+	LastName      string `json:"last_name" validate:"required,min=1,max=100"`
+	Email         string `json:"email" validate:"omitempty,email,max=100"`
+	Phone         string `json:"phone" validate:"omitempty,max=20"`
+}
@@ -1,9 +1,28 @@
 package contact
 
+import "github.com/sorfian/go-contact-management-api/model/web/address"
+
+// Sub-resources a contact response can embed with include
+const (
+	IncludeAddresses = "addresses"
+)
+
+var Includes = []string{IncludeAddresses}
+
+// Fields are the members a contact response can be limited to with fields,
+// named like their columns
+var Fields = []string{"id", "address_book_id", "first_name", "last_name", "email", "phone", "version"}
+
 type ContactResponse struct {
-	ID        int64  `json:"id"`
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Email     string `json:"email"`
-	Phone     string `json:"phone"`
+	ID            int64  `json:"id"`
+	AddressBookID int64  `json:"address_book_id"`
+	FirstName     string `json:"first_name"`
+	LastName      string `json:"last_name"`
+	Email         string `json:"email"`
+	Phone         string `json:"phone"`
+	Version       int64  `json:"version"`
+	// URLs of the photo thumbnails by size, absent when the contact has no photo
+	Photo map[string]string `json:"photo,omitempty"`
+	// Only set when included, empty when the contact has none
+	Addresses *[]address.AddressResponse `json:"addresses,omitempty"`
 }
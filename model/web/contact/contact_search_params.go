@@ -1,9 +1,12 @@
 package contact
 
 type SearchParams struct {
-	Name  string
-	Phone string
-	Email string
-	Page  int
-	Size  int
+	AddressBookID int64
+	Name          string
+	Phone         string
+	Email         string
+	Page          int
+	Size          int
+	Include       []string
+	Fields        []string
 }
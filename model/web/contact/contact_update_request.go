@@ -1,8 +1,13 @@
 package contact
 
+import "github.com/sorfian/go-contact-management-api/model/web"
+
+// ContactUpdateRequest is the JSON merge patch document for a contact. Members
+// left unset are kept and members set to web.ClearString() are cleared.
 type ContactUpdateRequest struct {
-	FirstName string `json:"first_name" validate:"omitempty,min=1,max=100"`
-	LastName  string `json:"last_name" validate:"omitempty,min=1,max=100"`
-	Email     string `json:"email" validate:"omitempty,email,max=100"`
-	Phone     string `json:"phone" validate:"omitempty,min=1,max=20"`
+	AddressBookID int64           `json:"address_book_id,omitempty"`
+	FirstName     web.PatchString `json:"first_name,omitzero"`
+	LastName      web.PatchString `json:"last_name,omitzero"`
+	Email         web.PatchString `json:"email,omitzero"`
+	Phone         web.PatchString `json:"phone,omitzero"`
 }
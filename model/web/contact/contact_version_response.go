@@ -0,0 +1,21 @@
+package contact
+
+import (
+	"time"
+
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web"
+)
+
+type ContactVersionResponse struct {
+	Number         int                    `json:"number"`
+	ContactVersion int64                  `json:"contact_version"`
+	ActorID        *int                   `json:"actor_id"`
+	Snapshot       domain.ContactSnapshot `json:"snapshot"`
+	CreatedAt      time.Time              `json:"created_at"`
+}
+
+type ContactVersionListResult struct {
+	Versions []ContactVersionResponse `json:"versions"`
+	Paging   web.PagingResponse       `json:"paging"`
+}
@@ -0,0 +1,25 @@
+package delta
+
+import (
+	"github.com/sorfian/go-contact-management-api/model/web/address"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+)
+
+// PullResponse is what changed since the sync token the client sent. Token
+// is the token for the next pull. Clients drop the contacts of address books
+// not in AddressBookIDs, and the deleted contacts and addresses, which also
+// cover those the user can no longer see.
+type PullResponse struct {
+	Token            string                    `json:"token"`
+	AddressBookIDs   []int64                   `json:"address_book_ids"`
+	Contacts         []contact.ContactResponse `json:"contacts"`
+	Addresses        []AddressResponse         `json:"addresses"`
+	DeletedContacts  []int64                   `json:"deleted_contacts"`
+	DeletedAddresses []int64                   `json:"deleted_addresses"`
+}
+
+// AddressResponse is an address with the contact it belongs to
+type AddressResponse struct {
+	ContactID int64 `json:"contact_id"`
+	address.AddressResponse
+}
@@ -0,0 +1,31 @@
+package delta
+
+import "encoding/json"
+
+const (
+	EntityContact = "contact"
+	EntityAddress = "address"
+
+	OperationCreate = "create"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+)
+
+// Change is one change the client made offline. Updates and deletes name the
+// version the client changed, Data is the contact or address to create or the
+// merge patch to apply. An address of a contact created in the same push
+// names it by ContactClientID, the ClientID of its create.
+type Change struct {
+	Entity          string          `json:"entity" validate:"required,oneof=contact address"`
+	Operation       string          `json:"operation" validate:"required,oneof=create update delete"`
+	ClientID        string          `json:"client_id,omitempty" validate:"max=100"`
+	ID              int64           `json:"id,omitempty" validate:"required_unless=Operation create"`
+	ContactID       int64           `json:"contact_id,omitempty" validate:"min=0"`
+	ContactClientID string          `json:"contact_client_id,omitempty" validate:"max=100"`
+	Version         int64           `json:"version,omitempty" validate:"required_unless=Operation create,min=0"`
+	Data            json.RawMessage `json:"data,omitempty"`
+}
+
+type PushRequest struct {
+	Changes []Change `json:"changes" validate:"required,min=1,max=100,dive"`
+}
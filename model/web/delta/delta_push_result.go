@@ -0,0 +1,26 @@
+package delta
+
+// ChangeResult is the outcome of one change, with the error envelope of the
+// single-item endpoints when it failed
+type ChangeResult struct {
+	Index     int         `json:"index"`
+	Entity    string      `json:"entity"`
+	Operation string      `json:"operation"`
+	ClientID  string      `json:"client_id,omitempty"`
+	Code      int         `json:"code"`
+	Status    string      `json:"status"`
+	Data      interface{} `json:"data"`
+}
+
+// Conflict is the data of a change made to a version the server no longer
+// has. Current is the server copy, to merge with the client copy and retry.
+type Conflict struct {
+	Message string      `json:"message"`
+	Current interface{} `json:"current"`
+}
+
+type PushResult struct {
+	Applied   int            `json:"applied"`
+	Conflicts int            `json:"conflicts"`
+	Results   []ChangeResult `json:"results"`
+}
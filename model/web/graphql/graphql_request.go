@@ -0,0 +1,8 @@
+package graphql
+
+// Request is a GraphQL request sent as JSON
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
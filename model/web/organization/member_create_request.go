@@ -0,0 +1,7 @@
+package organization
+
+// MemberCreateRequest adds an existing user to the organization
+type MemberCreateRequest struct {
+	Username string `json:"username" validate:"required,min=3,max=100"`
+	Role     string `json:"role" validate:"required,oneof=admin member"`
+}
@@ -0,0 +1,8 @@
+package organization
+
+type MemberResponse struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	Role     string `json:"role"`
+}
@@ -0,0 +1,7 @@
+package organization
+
+// MemberUpdateRequest changes the role of a member, an organization has
+// exactly one owner so ownership cannot be given away
+type MemberUpdateRequest struct {
+	Role string `json:"role" validate:"required,oneof=admin member"`
+}
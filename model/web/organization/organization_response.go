@@ -0,0 +1,18 @@
+package organization
+
+import "time"
+
+// OrganizationResponse is an organization as one of its members sees it, Role
+// is the role of that member
+type OrganizationResponse struct {
+	ID        int64            `json:"id"`
+	Name      string           `json:"name"`
+	Role      string           `json:"role"`
+	Settings  SettingsResponse `json:"settings"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+type SettingsResponse struct {
+	MaxContacts            int    `json:"max_contacts" validate:"min=0"`
+	DefaultAddressBookName string `json:"default_address_book_name" validate:"max=100"`
+}
@@ -0,0 +1,8 @@
+package organization
+
+// OrganizationUpdateRequest is the JSON merge patch document for an
+// organization, settings are merged member by member and null resets one
+type OrganizationUpdateRequest struct {
+	Name     string           `json:"name,omitempty" validate:"required,min=1,max=100"`
+	Settings SettingsResponse `json:"settings"`
+}
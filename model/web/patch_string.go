@@ -0,0 +1,41 @@
+package web
+
+import "encoding/json"
+
+// PatchString is a string member of a JSON merge patch. Tagged omitzero, it is
+// left out of the patch until Set, so the member is kept. Set with an empty
+// Value it is sent as null, which clears the member, the API reads cleared
+// members back as empty strings.
+type PatchString struct {
+	Value string
+	Set   bool
+}
+
+// SetString returns a PatchString that sets the member to value
+func SetString(value string) PatchString {
+	return PatchString{Value: value, Set: true}
+}
+
+// ClearString returns a PatchString that clears the member
+func ClearString() PatchString {
+	return PatchString{Set: true}
+}
+
+func (patch PatchString) IsZero() bool {
+	return !patch.Set
+}
+
+func (patch PatchString) MarshalJSON() ([]byte, error) {
+	if patch.Value == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(patch.Value)
+}
+
+func (patch *PatchString) UnmarshalJSON(data []byte) error {
+	*patch = PatchString{Set: true}
+	if string(data) == "null" {
+		return nil
+	}
+	return json.Unmarshal(data, &patch.Value)
+}
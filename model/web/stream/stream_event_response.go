@@ -0,0 +1,13 @@
+package stream
+
+import "encoding/json"
+
+// EventResponse is one message of the live event stream. Data is the domain
+// event, the same as the data of a webhook delivery, ContactID the contact it
+// is about.
+type EventResponse struct {
+	ID        int64
+	Event     string
+	ContactID int64
+	Data      json.RawMessage
+}
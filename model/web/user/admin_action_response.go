@@ -0,0 +1,21 @@
+package user
+
+import (
+	"time"
+
+	"github.com/sorfian/go-contact-management-api/model/web"
+)
+
+type AdminActionResponse struct {
+	ID           int64     `json:"id"`
+	AdminID      *int      `json:"admin_id"`
+	TargetUserID int       `json:"target_user_id"`
+	Action       string    `json:"action"`
+	Details      string    `json:"details"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type AdminActionListResult struct {
+	Actions []AdminActionResponse `json:"actions"`
+	Paging  web.PagingResponse    `json:"paging"`
+}
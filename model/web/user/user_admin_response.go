@@ -0,0 +1,15 @@
+package user
+
+import "time"
+
+// UserAdminResponse is a user as operators see it, including deleted users
+type UserAdminResponse struct {
+	ID         int        `json:"id"`
+	Username   string     `json:"username"`
+	Name       string     `json:"name"`
+	Role       string     `json:"role"`
+	TokenExp   int64      `json:"token_exp"`
+	DisabledAt *time.Time `json:"disabled_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	DeletedAt  *time.Time `json:"deleted_at"`
+}
@@ -0,0 +1,6 @@
+package user
+
+type UserResetPasswordRequest struct {
+	Username string `validate:"required,min=3,max=100" json:"username"`
+	Password string `validate:"required,min=3,max=100" json:"password"`
+}
@@ -3,4 +3,5 @@ package user
 type UserResponse struct {
 	Username string `json:"username"`
 	Name     string `json:"name"`
+	Role     string `json:"role"`
 }
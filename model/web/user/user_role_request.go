@@ -0,0 +1,5 @@
+package user
+
+type UserRoleRequest struct {
+	Role string `validate:"required,oneof=user admin" json:"role"`
+}
@@ -0,0 +1,12 @@
+package user
+
+// UserSearchParams filters the users admins list. Disabled is nil to list
+// enabled and disabled users alike.
+type UserSearchParams struct {
+	Username string
+	Name     string
+	Role     string
+	Disabled *bool
+	Page     int
+	Size     int
+}
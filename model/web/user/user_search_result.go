@@ -0,0 +1,8 @@
+package user
+
+import "github.com/sorfian/go-contact-management-api/model/web"
+
+type UserSearchResult struct {
+	Users  []UserAdminResponse `json:"users"`
+	Paging web.PagingResponse  `json:"paging"`
+}
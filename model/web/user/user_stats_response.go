@@ -0,0 +1,14 @@
+package user
+
+// UserStatsResponse counts users and the data they keep. Deleted users,
+// contacts and addresses are only counted in DeletedUsers.
+type UserStatsResponse struct {
+	TotalUsers         int64 `json:"total_users"`
+	AdminUsers         int64 `json:"admin_users"`
+	DisabledUsers      int64 `json:"disabled_users"`
+	DeletedUsers       int64 `json:"deleted_users"`
+	ActiveSessions     int64 `json:"active_sessions"`
+	NewUsersLast30Days int64 `json:"new_users_last_30_days"`
+	TotalContacts      int64 `json:"total_contacts"`
+	TotalAddresses     int64 `json:"total_addresses"`
+}
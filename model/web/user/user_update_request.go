@@ -1,6 +1,8 @@
 package user
 
+// UserUpdateRequest is the JSON merge patch document for the current user,
+// validated after the patch has been merged into the stored name.
 type UserUpdateRequest struct {
-	Name     string `validate:"max=100" json:"name"`
-	Password string `validate:"max=100" json:"password"`
+	Name     string `validate:"required,min=3,max=100" json:"name,omitempty"`
+	Password string `validate:"omitempty,min=3,max=100" json:"password,omitempty"`
 }
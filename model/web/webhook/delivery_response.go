@@ -0,0 +1,28 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/sorfian/go-contact-management-api/model/web"
+)
+
+type DeliveryResponse struct {
+	ID             int64           `json:"id"`
+	WebhookID      int64           `json:"webhook_id"`
+	EventID        string          `json:"event_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at"`
+	ResponseStatus int             `json:"response_status"`
+	Error          string          `json:"error"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type DeliveryListResult struct {
+	Deliveries []DeliveryResponse `json:"deliveries"`
+	Paging     web.PagingResponse `json:"paging"`
+}
@@ -0,0 +1,13 @@
+package webhook
+
+import "time"
+
+// EventPayload is the body of a webhook delivery. ID identifies the event,
+// receivers use it to drop an event they already handled.
+type EventPayload struct {
+	ID             string      `json:"id"`
+	Event          string      `json:"event"`
+	OrganizationID int64       `json:"organization_id"`
+	OccurredAt     time.Time   `json:"occurred_at"`
+	Data           interface{} `json:"data"`
+}
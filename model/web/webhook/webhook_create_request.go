@@ -0,0 +1,8 @@
+package webhook
+
+type WebhookCreateRequest struct {
+	URL    string   `json:"url" validate:"required,http_url,max=2048"`
+	Secret string   `json:"secret" validate:"required,min=16,max=255"`
+	Events []string `json:"events" validate:"required,min=1,unique,dive,oneof=contact.created contact.updated contact.deleted address.created address.updated address.deleted"`
+	Active *bool    `json:"active,omitempty"`
+}
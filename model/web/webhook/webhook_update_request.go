@@ -0,0 +1,10 @@
+package webhook
+
+// WebhookUpdateRequest is the JSON merge patch document for a webhook. The
+// secret is write only, it is kept unless the patch sets a new one.
+type WebhookUpdateRequest struct {
+	URL    string   `json:"url,omitempty" validate:"required,http_url,max=2048"`
+	Secret string   `json:"secret,omitempty" validate:"required,min=16,max=255"`
+	Events []string `json:"events,omitempty" validate:"required,min=1,unique,dive,oneof=contact.created contact.updated contact.deleted address.created address.updated address.deleted"`
+	Active bool     `json:"active"`
+}
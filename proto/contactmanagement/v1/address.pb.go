@@ -0,0 +1,640 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: contactmanagement/v1/address.proto
+
+package contactmanagementv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Address struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ContactId     int64                  `protobuf:"varint,2,opt,name=contact_id,json=contactId,proto3" json:"contact_id,omitempty"`
+	Street        string                 `protobuf:"bytes,3,opt,name=street,proto3" json:"street,omitempty"`
+	City          string                 `protobuf:"bytes,4,opt,name=city,proto3" json:"city,omitempty"`
+	Province      string                 `protobuf:"bytes,5,opt,name=province,proto3" json:"province,omitempty"`
+	Country       string                 `protobuf:"bytes,6,opt,name=country,proto3" json:"country,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,7,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Version       int64                  `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_contactmanagement_v1_address_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_contactmanagement_v1_address_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_contactmanagement_v1_address_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Address) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Address) GetContactId() int64 {
+	if x != nil {
+		return x.ContactId
+	}
+	return 0
+}
+
+func (x *Address) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetProvince() string {
+	if x != nil {
+		return x.Province
+	}
+	return ""
+}
+
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Address) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *Address) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type CreateAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContactId     int64                  `protobuf:"varint,1,opt,name=contact_id,json=contactId,proto3" json:"contact_id,omitempty"`
+	Street        string                 `protobuf:"bytes,2,opt,name=street,proto3" json:"street,omitempty"`
+	City          string                 `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
+	Province      string                 `protobuf:"bytes,4,opt,name=province,proto3" json:"province,omitempty"`
+	Country       string                 `protobuf:"bytes,5,opt,name=country,proto3" json:"country,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,6,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAddressRequest) Reset() {
+	*x = CreateAddressRequest{}
+	mi := &file_contactmanagement_v1_address_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAddressRequest) ProtoMessage() {}
+
+func (x *CreateAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contactmanagement_v1_address_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAddressRequest.ProtoReflect.Descriptor instead.
+func (*CreateAddressRequest) Descriptor() ([]byte, []int) {
+	return file_contactmanagement_v1_address_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateAddressRequest) GetContactId() int64 {
+	if x != nil {
+		return x.ContactId
+	}
+	return 0
+}
+
+func (x *CreateAddressRequest) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *CreateAddressRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *CreateAddressRequest) GetProvince() string {
+	if x != nil {
+		return x.Province
+	}
+	return ""
+}
+
+func (x *CreateAddressRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *CreateAddressRequest) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+type GetAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContactId     int64                  `protobuf:"varint,1,opt,name=contact_id,json=contactId,proto3" json:"contact_id,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAddressRequest) Reset() {
+	*x = GetAddressRequest{}
+	mi := &file_contactmanagement_v1_address_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAddressRequest) ProtoMessage() {}
+
+func (x *GetAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contactmanagement_v1_address_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAddressRequest.ProtoReflect.Descriptor instead.
+func (*GetAddressRequest) Descriptor() ([]byte, []int) {
+	return file_contactmanagement_v1_address_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetAddressRequest) GetContactId() int64 {
+	if x != nil {
+		return x.ContactId
+	}
+	return 0
+}
+
+func (x *GetAddressRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type UpdateAddressRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ContactId  int64                  `protobuf:"varint,1,opt,name=contact_id,json=contactId,proto3" json:"contact_id,omitempty"`
+	Id         int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Street     *string                `protobuf:"bytes,3,opt,name=street,proto3,oneof" json:"street,omitempty"`
+	City       *string                `protobuf:"bytes,4,opt,name=city,proto3,oneof" json:"city,omitempty"`
+	Province   *string                `protobuf:"bytes,5,opt,name=province,proto3,oneof" json:"province,omitempty"`
+	Country    *string                `protobuf:"bytes,6,opt,name=country,proto3,oneof" json:"country,omitempty"`
+	PostalCode *string                `protobuf:"bytes,7,opt,name=postal_code,json=postalCode,proto3,oneof" json:"postal_code,omitempty"`
+	// Only changes the address while it has this version, any version when 0
+	ExpectedVersion int64 `protobuf:"varint,8,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateAddressRequest) Reset() {
+	*x = UpdateAddressRequest{}
+	mi := &file_contactmanagement_v1_address_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAddressRequest) ProtoMessage() {}
+
+func (x *UpdateAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contactmanagement_v1_address_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAddressRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAddressRequest) Descriptor() ([]byte, []int) {
+	return file_contactmanagement_v1_address_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateAddressRequest) GetContactId() int64 {
+	if x != nil {
+		return x.ContactId
+	}
+	return 0
+}
+
+func (x *UpdateAddressRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateAddressRequest) GetStreet() string {
+	if x != nil && x.Street != nil {
+		return *x.Street
+	}
+	return ""
+}
+
+func (x *UpdateAddressRequest) GetCity() string {
+	if x != nil && x.City != nil {
+		return *x.City
+	}
+	return ""
+}
+
+func (x *UpdateAddressRequest) GetProvince() string {
+	if x != nil && x.Province != nil {
+		return *x.Province
+	}
+	return ""
+}
+
+func (x *UpdateAddressRequest) GetCountry() string {
+	if x != nil && x.Country != nil {
+		return *x.Country
+	}
+	return ""
+}
+
+func (x *UpdateAddressRequest) GetPostalCode() string {
+	if x != nil && x.PostalCode != nil {
+		return *x.PostalCode
+	}
+	return ""
+}
+
+func (x *UpdateAddressRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteAddressRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ContactId int64                  `protobuf:"varint,1,opt,name=contact_id,json=contactId,proto3" json:"contact_id,omitempty"`
+	Id        int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Only deletes the address while it has this version, any version when 0
+	ExpectedVersion int64 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteAddressRequest) Reset() {
+	*x = DeleteAddressRequest{}
+	mi := &file_contactmanagement_v1_address_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAddressRequest) ProtoMessage() {}
+
+func (x *DeleteAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contactmanagement_v1_address_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAddressRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAddressRequest) Descriptor() ([]byte, []int) {
+	return file_contactmanagement_v1_address_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteAddressRequest) GetContactId() int64 {
+	if x != nil {
+		return x.ContactId
+	}
+	return 0
+}
+
+func (x *DeleteAddressRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteAddressRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type ListAddressesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContactId     int64                  `protobuf:"varint,1,opt,name=contact_id,json=contactId,proto3" json:"contact_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAddressesRequest) Reset() {
+	*x = ListAddressesRequest{}
+	mi := &file_contactmanagement_v1_address_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAddressesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAddressesRequest) ProtoMessage() {}
+
+func (x *ListAddressesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contactmanagement_v1_address_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAddressesRequest.ProtoReflect.Descriptor instead.
+func (*ListAddressesRequest) Descriptor() ([]byte, []int) {
+	return file_contactmanagement_v1_address_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListAddressesRequest) GetContactId() int64 {
+	if x != nil {
+		return x.ContactId
+	}
+	return 0
+}
+
+type ListAddressesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Addresses     []*Address             `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAddressesResponse) Reset() {
+	*x = ListAddressesResponse{}
+	mi := &file_contactmanagement_v1_address_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAddressesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAddressesResponse) ProtoMessage() {}
+
+func (x *ListAddressesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_contactmanagement_v1_address_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAddressesResponse.ProtoReflect.Descriptor instead.
+func (*ListAddressesResponse) Descriptor() ([]byte, []int) {
+	return file_contactmanagement_v1_address_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListAddressesResponse) GetAddresses() []*Address {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+var File_contactmanagement_v1_address_proto protoreflect.FileDescriptor
+
+const file_contactmanagement_v1_address_proto_rawDesc = "" +
+	"\n" +
+	"\"contactmanagement/v1/address.proto\x12\x14contactmanagement.v1\x1a\x1bgoogle/protobuf/empty.proto\"\xd5\x01\n" +
+	"\aAddress\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"contact_id\x18\x02 \x01(\x03R\tcontactId\x12\x16\n" +
+	"\x06street\x18\x03 \x01(\tR\x06street\x12\x12\n" +
+	"\x04city\x18\x04 \x01(\tR\x04city\x12\x1a\n" +
+	"\bprovince\x18\x05 \x01(\tR\bprovince\x12\x18\n" +
+	"\acountry\x18\x06 \x01(\tR\acountry\x12\x1f\n" +
+	"\vpostal_code\x18\a \x01(\tR\n" +
+	"postalCode\x12\x18\n" +
+	"\aversion\x18\b \x01(\x03R\aversion\"\xb8\x01\n" +
+	"\x14CreateAddressRequest\x12\x1d\n" +
+	"\n" +
+	"contact_id\x18\x01 \x01(\x03R\tcontactId\x12\x16\n" +
+	"\x06street\x18\x02 \x01(\tR\x06street\x12\x12\n" +
+	"\x04city\x18\x03 \x01(\tR\x04city\x12\x1a\n" +
+	"\bprovince\x18\x04 \x01(\tR\bprovince\x12\x18\n" +
+	"\acountry\x18\x05 \x01(\tR\acountry\x12\x1f\n" +
+	"\vpostal_code\x18\x06 \x01(\tR\n" +
+	"postalCode\"B\n" +
+	"\x11GetAddressRequest\x12\x1d\n" +
+	"\n" +
+	"contact_id\x18\x01 \x01(\x03R\tcontactId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\"\xc9\x02\n" +
+	"\x14UpdateAddressRequest\x12\x1d\n" +
+	"\n" +
+	"contact_id\x18\x01 \x01(\x03R\tcontactId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x1b\n" +
+	"\x06street\x18\x03 \x01(\tH\x00R\x06street\x88\x01\x01\x12\x17\n" +
+	"\x04city\x18\x04 \x01(\tH\x01R\x04city\x88\x01\x01\x12\x1f\n" +
+	"\bprovince\x18\x05 \x01(\tH\x02R\bprovince\x88\x01\x01\x12\x1d\n" +
+	"\acountry\x18\x06 \x01(\tH\x03R\acountry\x88\x01\x01\x12$\n" +
+	"\vpostal_code\x18\a \x01(\tH\x04R\n" +
+	"postalCode\x88\x01\x01\x12)\n" +
+	"\x10expected_version\x18\b \x01(\x03R\x0fexpectedVersionB\t\n" +
+	"\a_streetB\a\n" +
+	"\x05_cityB\v\n" +
+	"\t_provinceB\n" +
+	"\n" +
+	"\b_countryB\x0e\n" +
+	"\f_postal_code\"p\n" +
+	"\x14DeleteAddressRequest\x12\x1d\n" +
+	"\n" +
+	"contact_id\x18\x01 \x01(\x03R\tcontactId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\x03R\x0fexpectedVersion\"5\n" +
+	"\x14ListAddressesRequest\x12\x1d\n" +
+	"\n" +
+	"contact_id\x18\x01 \x01(\x03R\tcontactId\"T\n" +
+	"\x15ListAddressesResponse\x12;\n" +
+	"\taddresses\x18\x01 \x03(\v2\x1d.contactmanagement.v1.AddressR\taddresses2\xdd\x03\n" +
+	"\x0eAddressService\x12Z\n" +
+	"\rCreateAddress\x12*.contactmanagement.v1.CreateAddressRequest\x1a\x1d.contactmanagement.v1.Address\x12T\n" +
+	"\n" +
+	"GetAddress\x12'.contactmanagement.v1.GetAddressRequest\x1a\x1d.contactmanagement.v1.Address\x12Z\n" +
+	"\rUpdateAddress\x12*.contactmanagement.v1.UpdateAddressRequest\x1a\x1d.contactmanagement.v1.Address\x12S\n" +
+	"\rDeleteAddress\x12*.contactmanagement.v1.DeleteAddressRequest\x1a\x16.google.protobuf.Empty\x12h\n" +
+	"\rListAddresses\x12*.contactmanagement.v1.ListAddressesRequest\x1a+.contactmanagement.v1.ListAddressesResponseB]Z[github.com/sorfian/go-contact-management-api/proto/contactmanagement/v1;contactmanagementv1b\x06proto3"
+
+var (
+	file_contactmanagement_v1_address_proto_rawDescOnce sync.Once
+	file_contactmanagement_v1_address_proto_rawDescData []byte
+)
+
+func file_contactmanagement_v1_address_proto_rawDescGZIP() []byte {
+	file_contactmanagement_v1_address_proto_rawDescOnce.Do(func() {
+		file_contactmanagement_v1_address_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_contactmanagement_v1_address_proto_rawDesc), len(file_contactmanagement_v1_address_proto_rawDesc)))
+	})
+	return file_contactmanagement_v1_address_proto_rawDescData
+}
+
+var file_contactmanagement_v1_address_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_contactmanagement_v1_address_proto_goTypes = []any{
+	(*Address)(nil),               // 0: contactmanagement.v1.Address
+	(*CreateAddressRequest)(nil),  // 1: contactmanagement.v1.CreateAddressRequest
+	(*GetAddressRequest)(nil),     // 2: contactmanagement.v1.GetAddressRequest
+	(*UpdateAddressRequest)(nil),  // 3: contactmanagement.v1.UpdateAddressRequest
+	(*DeleteAddressRequest)(nil),  // 4: contactmanagement.v1.DeleteAddressRequest
+	(*ListAddressesRequest)(nil),  // 5: contactmanagement.v1.ListAddressesRequest
+	(*ListAddressesResponse)(nil), // 6: contactmanagement.v1.ListAddressesResponse
+	(*emptypb.Empty)(nil),         // 7: google.protobuf.Empty
+}
+var file_contactmanagement_v1_address_proto_depIdxs = []int32{
+	0, // 0: contactmanagement.v1.ListAddressesResponse.addresses:type_name -> contactmanagement.v1.Address
+	1, // 1: contactmanagement.v1.AddressService.CreateAddress:input_type -> contactmanagement.v1.CreateAddressRequest
+	2, // 2: contactmanagement.v1.AddressService.GetAddress:input_type -> contactmanagement.v1.GetAddressRequest
+	3, // 3: contactmanagement.v1.AddressService.UpdateAddress:input_type -> contactmanagement.v1.UpdateAddressRequest
+	4, // 4: contactmanagement.v1.AddressService.DeleteAddress:input_type -> contactmanagement.v1.DeleteAddressRequest
+	5, // 5: contactmanagement.v1.AddressService.ListAddresses:input_type -> contactmanagement.v1.ListAddressesRequest
+	0, // 6: contactmanagement.v1.AddressService.CreateAddress:output_type -> contactmanagement.v1.Address
+	0, // 7: contactmanagement.v1.AddressService.GetAddress:output_type -> contactmanagement.v1.Address
+	0, // 8: contactmanagement.v1.AddressService.UpdateAddress:output_type -> contactmanagement.v1.Address
+	7, // 9: contactmanagement.v1.AddressService.DeleteAddress:output_type -> google.protobuf.Empty
+	6, // 10: contactmanagement.v1.AddressService.ListAddresses:output_type -> contactmanagement.v1.ListAddressesResponse
+	6, // [6:11] is the sub-list for method output_type
+	1, // [1:6] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_contactmanagement_v1_address_proto_init() }
+func file_contactmanagement_v1_address_proto_init() {
+	if File_contactmanagement_v1_address_proto != nil {
+		return
+	}
+	file_contactmanagement_v1_address_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_contactmanagement_v1_address_proto_rawDesc), len(file_contactmanagement_v1_address_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_contactmanagement_v1_address_proto_goTypes,
+		DependencyIndexes: file_contactmanagement_v1_address_proto_depIdxs,
+		MessageInfos:      file_contactmanagement_v1_address_proto_msgTypes,
+	}.Build()
+	File_contactmanagement_v1_address_proto = out.File
+	file_contactmanagement_v1_address_proto_goTypes = nil
+	file_contactmanagement_v1_address_proto_depIdxs = nil
+}
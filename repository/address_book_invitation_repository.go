@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type AddressBookInvitationRepository interface {
+	Create(ctx *fiber.Ctx, tx *gorm.DB, invitation domain.AddressBookInvitation) domain.AddressBookInvitation
+	FindById(ctx *fiber.Ctx, tx *gorm.DB, id int64) (*domain.AddressBookInvitation, error)
+	FindPending(ctx *fiber.Ctx, tx *gorm.DB, addressBookID int64, inviteeID int) (*domain.AddressBookInvitation, error)
+	FindAllPendingByAddressBook(ctx *fiber.Ctx, tx *gorm.DB, addressBookID int64) []domain.AddressBookInvitation
+	FindAllPendingByInvitee(ctx *fiber.Ctx, tx *gorm.DB, inviteeID int) []domain.AddressBookInvitation
+	Update(ctx *fiber.Ctx, tx *gorm.DB, invitation *domain.AddressBookInvitation) domain.AddressBookInvitation
+}
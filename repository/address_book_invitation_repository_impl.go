@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type AddressBookInvitationRepositoryImpl struct {
+}
+
+func NewAddressBookInvitationRepository() AddressBookInvitationRepository {
+	return &AddressBookInvitationRepositoryImpl{}
+}
+
+// withParties loads what an invitation response shows besides the invitation
+func withParties(tx *gorm.DB) *gorm.DB {
+	return tx.Preload("AddressBook").Preload("Inviter").Preload("Invitee")
+}
+
+func (repository *AddressBookInvitationRepositoryImpl) Create(ctx *fiber.Ctx, tx *gorm.DB, invitation domain.AddressBookInvitation) domain.AddressBookInvitation {
+	invitation.OrganizationID = CurrentOrganization(ctx).ID
+	err := tx.WithContext(ctx.UserContext()).Omit("AddressBook", "Inviter", "Invitee").Create(&invitation).Error
+	helper.PanicIfError(err)
+	return invitation
+}
+
+func (repository *AddressBookInvitationRepositoryImpl) FindById(ctx *fiber.Ctx, tx *gorm.DB, id int64) (*domain.AddressBookInvitation, error) {
+	invitation := domain.AddressBookInvitation{}
+	err := withParties(tenantDB(ctx, tx)).Where("id = ?", id).First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (repository *AddressBookInvitationRepositoryImpl) FindPending(ctx *fiber.Ctx, tx *gorm.DB, addressBookID int64, inviteeID int) (*domain.AddressBookInvitation, error) {
+	invitation := domain.AddressBookInvitation{}
+	err := tenantDB(ctx, tx).
+		Where("address_book_id = ? AND invitee_id = ? AND status = ?", addressBookID, inviteeID, domain.InvitationPending).
+		First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (repository *AddressBookInvitationRepositoryImpl) FindAllPendingByAddressBook(ctx *fiber.Ctx, tx *gorm.DB, addressBookID int64) []domain.AddressBookInvitation {
+	var invitations []domain.AddressBookInvitation
+	err := withParties(tenantDB(ctx, tx)).
+		Where("address_book_id = ? AND status = ?", addressBookID, domain.InvitationPending).
+		Order("id").
+		Find(&invitations).Error
+	helper.PanicIfError(err)
+	return invitations
+}
+
+func (repository *AddressBookInvitationRepositoryImpl) FindAllPendingByInvitee(ctx *fiber.Ctx, tx *gorm.DB, inviteeID int) []domain.AddressBookInvitation {
+	var invitations []domain.AddressBookInvitation
+	err := withParties(tenantDB(ctx, tx)).
+		Where("invitee_id = ? AND status = ?", inviteeID, domain.InvitationPending).
+		Order("id").
+		Find(&invitations).Error
+	helper.PanicIfError(err)
+	return invitations
+}
+
+func (repository *AddressBookInvitationRepositoryImpl) Update(ctx *fiber.Ctx, tx *gorm.DB, invitation *domain.AddressBookInvitation) domain.AddressBookInvitation {
+	err := tenantDB(ctx, tx).Model(invitation).Update("status", invitation.Status).Error
+	helper.PanicIfError(err)
+	return *invitation
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type AddressBookMemberRepository interface {
+	Create(ctx *fiber.Ctx, tx *gorm.DB, member domain.AddressBookMember) domain.AddressBookMember
+	Find(ctx *fiber.Ctx, tx *gorm.DB, addressBookID int64, userID int) (*domain.AddressBookMember, error)
+	FindAllByAddressBook(ctx *fiber.Ctx, tx *gorm.DB, addressBookID int64) []domain.AddressBookMember
+	FindAllByUser(ctx *fiber.Ctx, tx *gorm.DB, userID int) []domain.AddressBookMember
+	Update(ctx *fiber.Ctx, tx *gorm.DB, member *domain.AddressBookMember) domain.AddressBookMember
+	Delete(ctx *fiber.Ctx, tx *gorm.DB, member *domain.AddressBookMember) error
+	DeleteAllByUser(ctx *fiber.Ctx, tx *gorm.DB, userID int) error
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type AddressBookMemberRepositoryImpl struct {
+}
+
+func NewAddressBookMemberRepository() AddressBookMemberRepository {
+	return &AddressBookMemberRepositoryImpl{}
+}
+
+func (repository *AddressBookMemberRepositoryImpl) Create(ctx *fiber.Ctx, tx *gorm.DB, member domain.AddressBookMember) domain.AddressBookMember {
+	member.OrganizationID = CurrentOrganization(ctx).ID
+	err := tx.WithContext(ctx.UserContext()).Omit("AddressBook", "User").Create(&member).Error
+	helper.PanicIfError(err)
+	return member
+}
+
+func (repository *AddressBookMemberRepositoryImpl) Find(ctx *fiber.Ctx, tx *gorm.DB, addressBookID int64, userID int) (*domain.AddressBookMember, error) {
+	member := domain.AddressBookMember{}
+	err := tenantDB(ctx, tx).
+		Preload("AddressBook").
+		Preload("User").
+		Where("address_book_id = ? AND user_id = ?", addressBookID, userID).
+		First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (repository *AddressBookMemberRepositoryImpl) FindAllByAddressBook(ctx *fiber.Ctx, tx *gorm.DB, addressBookID int64) []domain.AddressBookMember {
+	var members []domain.AddressBookMember
+	err := tenantDB(ctx, tx).
+		Preload("User").
+		Where("address_book_id = ?", addressBookID).
+		Order("id").
+		Find(&members).Error
+	helper.PanicIfError(err)
+	return members
+}
+
+// FindAllByUser returns the memberships of the user with their address books
+func (repository *AddressBookMemberRepositoryImpl) FindAllByUser(ctx *fiber.Ctx, tx *gorm.DB, userID int) []domain.AddressBookMember {
+	var members []domain.AddressBookMember
+	err := tenantDB(ctx, tx).
+		Preload("AddressBook").
+		Where("user_id = ?", userID).
+		Order("address_book_id").
+		Find(&members).Error
+	helper.PanicIfError(err)
+	return members
+}
+
+func (repository *AddressBookMemberRepositoryImpl) Update(ctx *fiber.Ctx, tx *gorm.DB, member *domain.AddressBookMember) domain.AddressBookMember {
+	err := tenantDB(ctx, tx).Model(member).Update("permission", member.Permission).Error
+	helper.PanicIfError(err)
+	return *member
+}
+
+func (repository *AddressBookMemberRepositoryImpl) Delete(ctx *fiber.Ctx, tx *gorm.DB, member *domain.AddressBookMember) error {
+	return tenantDB(ctx, tx).Delete(member).Error
+}
+
+// DeleteAllByUser ends every address book membership the user has in the
+// organization
+func (repository *AddressBookMemberRepositoryImpl) DeleteAllByUser(ctx *fiber.Ctx, tx *gorm.DB, userID int) error {
+	return tenantDB(ctx, tx).Where("user_id = ?", userID).Delete(&domain.AddressBookMember{}).Error
+}
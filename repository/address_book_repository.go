@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type AddressBookRepository interface {
+	Create(ctx *fiber.Ctx, tx *gorm.DB, addressBook domain.AddressBook) domain.AddressBook
+	FindById(ctx *fiber.Ctx, tx *gorm.DB, id int64) (*domain.AddressBook, error)
+	FindFirstOwned(ctx *fiber.Ctx, tx *gorm.DB, ownerID int) (*domain.AddressBook, error)
+	FindAllOwned(ctx *fiber.Ctx, tx *gorm.DB, ownerID int) []domain.AddressBook
+	Update(ctx *fiber.Ctx, tx *gorm.DB, addressBook *domain.AddressBook) domain.AddressBook
+	Delete(ctx *fiber.Ctx, tx *gorm.DB, addressBook *domain.AddressBook) error
+}
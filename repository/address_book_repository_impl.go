@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type AddressBookRepositoryImpl struct {
+}
+
+func NewAddressBookRepository() AddressBookRepository {
+	return &AddressBookRepositoryImpl{}
+}
+
+func (repository *AddressBookRepositoryImpl) Create(ctx *fiber.Ctx, tx *gorm.DB, addressBook domain.AddressBook) domain.AddressBook {
+	addressBook.OrganizationID = CurrentOrganization(ctx).ID
+	err := tx.WithContext(ctx.UserContext()).Create(&addressBook).Error
+	helper.PanicIfError(err)
+	return addressBook
+}
+
+func (repository *AddressBookRepositoryImpl) FindById(ctx *fiber.Ctx, tx *gorm.DB, id int64) (*domain.AddressBook, error) {
+	addressBook := domain.AddressBook{}
+	err := tenantDB(ctx, tx).Where("id = ?", id).First(&addressBook).Error
+	if err != nil {
+		return nil, err
+	}
+	return &addressBook, nil
+}
+
+// FindFirstOwned returns the oldest address book of the owner, where contacts
+// created without an address book go
+func (repository *AddressBookRepositoryImpl) FindFirstOwned(ctx *fiber.Ctx, tx *gorm.DB, ownerID int) (*domain.AddressBook, error) {
+	addressBook := domain.AddressBook{}
+	err := tenantDB(ctx, tx).Where("owner_id = ?", ownerID).Order("id").First(&addressBook).Error
+	if err != nil {
+		return nil, err
+	}
+	return &addressBook, nil
+}
+
+// FindAllOwned returns the address books the owner has in the organization
+func (repository *AddressBookRepositoryImpl) FindAllOwned(ctx *fiber.Ctx, tx *gorm.DB, ownerID int) []domain.AddressBook {
+	var addressBooks []domain.AddressBook
+	err := tenantDB(ctx, tx).Where("owner_id = ?", ownerID).Order("id").Find(&addressBooks).Error
+	helper.PanicIfError(err)
+	return addressBooks
+}
+
+func (repository *AddressBookRepositoryImpl) Update(ctx *fiber.Ctx, tx *gorm.DB, addressBook *domain.AddressBook) domain.AddressBook {
+	err := tenantDB(ctx, tx).Model(addressBook).Update("name", addressBook.Name).Error
+	helper.PanicIfError(err)
+	return *addressBook
+}
+
+// Delete removes the address book with its members, invitations and contacts
+func (repository *AddressBookRepositoryImpl) Delete(ctx *fiber.Ctx, tx *gorm.DB, addressBook *domain.AddressBook) error {
+	return tenantDB(ctx, tx).Delete(addressBook).Error
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/sorfian/go-contact-management-api/model/domain"
 	"gorm.io/gorm"
@@ -8,8 +10,11 @@ import (
 
 type AddressRepository interface {
 	Create(ctx *fiber.Ctx, tx *gorm.DB, address domain.Address) domain.Address
-	FindById(ctx *fiber.Ctx, tx *gorm.DB, id int64, contactID int64) (*domain.Address, error)
-	FindAll(ctx *fiber.Ctx, tx *gorm.DB, contactID int64) []domain.Address
-	Update(ctx *fiber.Ctx, tx *gorm.DB, address *domain.Address) domain.Address
+	FindById(ctx *fiber.Ctx, tx *gorm.DB, id int64, contactID int64, fields []string) (*domain.Address, error)
+	FindAll(ctx *fiber.Ctx, tx *gorm.DB, contactID int64, fields []string) []domain.Address
+	Update(ctx *fiber.Ctx, tx *gorm.DB, address *domain.Address) (domain.Address, error)
 	Delete(ctx *fiber.Ctx, tx *gorm.DB, address *domain.Address) error
+	DeleteAllByContactId(ctx *fiber.Ctx, tx *gorm.DB, contactID int64, batchID string) error
+	FindAllByContacts(ctx *fiber.Ctx, tx *gorm.DB, contactIDs []int64) []domain.Address
+	FindChangedSince(ctx *fiber.Ctx, tx *gorm.DB, addressBookIDs []int64, since time.Time) []domain.Address
 }
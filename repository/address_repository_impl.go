@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/sorfian/go-contact-management-api/helper"
 	"github.com/sorfian/go-contact-management-api/model/domain"
@@ -15,34 +17,109 @@ func NewAddressRepository() AddressRepository {
 }
 
 func (repository *AddressRepositoryImpl) Create(ctx *fiber.Ctx, tx *gorm.DB, address domain.Address) domain.Address {
-	err := tx.WithContext(ctx.UserContext()).Create(&address).Error
+	address.OrganizationID = CurrentOrganization(ctx).ID
+	omitted := emptyColumns(map[string]string{"street": address.Street, "province": address.Province, "postal_code": address.PostalCode})
+	err := tx.WithContext(ctx.UserContext()).Omit(omitted...).Create(&address).Error
 	helper.PanicIfError(err)
 	return address
 }
 
-func (repository *AddressRepositoryImpl) FindById(ctx *fiber.Ctx, tx *gorm.DB, id int64, contactID int64) (*domain.Address, error) {
+// FindById returns the address of the contact with the columns of the fields,
+// all of them without fields. Its version is always read for the entity tag.
+func (repository *AddressRepositoryImpl) FindById(ctx *fiber.Ctx, tx *gorm.DB, id int64, contactID int64, fields []string) (*domain.Address, error) {
 	address := domain.Address{}
-	err := tx.WithContext(ctx.UserContext()).Where("id = ? AND contact_id = ?", id, contactID).First(&address).Error
+	err := selectColumns(tenantDB(ctx, tx), fields, "version").Where("id = ? AND contact_id = ?", id, contactID).First(&address).Error
 	if err != nil {
 		return nil, err
 	}
 	return &address, nil
 }
 
-func (repository *AddressRepositoryImpl) FindAll(ctx *fiber.Ctx, tx *gorm.DB, contactID int64) []domain.Address {
+// FindAll returns the addresses of the contact with the columns of the
+// fields, all of them without fields
+func (repository *AddressRepositoryImpl) FindAll(ctx *fiber.Ctx, tx *gorm.DB, contactID int64, fields []string) []domain.Address {
 	var addresses []domain.Address
-	err := tx.WithContext(ctx.UserContext()).Where("contact_id = ?", contactID).Find(&addresses).Error
+	err := selectColumns(tenantDB(ctx, tx), fields).Where("contact_id = ?", contactID).Find(&addresses).Error
 	helper.PanicIfError(err)
 	return addresses
 }
 
-func (repository *AddressRepositoryImpl) Update(ctx *fiber.Ctx, tx *gorm.DB, address *domain.Address) domain.Address {
-	err := tx.WithContext(ctx.UserContext()).Save(address).Error
-	helper.PanicIfError(err)
-	return *address
+func (repository *AddressRepositoryImpl) Update(ctx *fiber.Ctx, tx *gorm.DB, address *domain.Address) (domain.Address, error) {
+	// Only update the row when it still has the version that was read
+	result := tenantDB(ctx, tx).Model(address).Where("version = ?", address.Version).Updates(map[string]interface{}{
+		"street":      nullIfEmpty(address.Street),
+		"city":        address.City,
+		"province":    nullIfEmpty(address.Province),
+		"country":     address.Country,
+		"postal_code": nullIfEmpty(address.PostalCode),
+		"version":     gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return *address, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return *address, ErrVersionConflict
+	}
+
+	address.Version++
+	return *address, nil
 }
 
 func (repository *AddressRepositoryImpl) Delete(ctx *fiber.Ctx, tx *gorm.DB, address *domain.Address) error {
-	err := tx.WithContext(ctx.UserContext()).Delete(address).Error
+	// Soft delete and stamp the deletion batch in a single statement
+	result := tenantDB(ctx, tx).Model(address).Where("version = ?", address.Version).Updates(map[string]interface{}{
+		"deletion_batch_id": address.DeletionBatchID,
+		"deleted_at":        time.Now(),
+		"version":           gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+func (repository *AddressRepositoryImpl) DeleteAllByContactId(ctx *fiber.Ctx, tx *gorm.DB, contactID int64, batchID string) error {
+	// Already deleted addresses keep their original batch
+	err := tenantDB(ctx, tx).Model(&domain.Address{}).Where("contact_id = ?", contactID).Updates(map[string]interface{}{
+		"deletion_batch_id": batchID,
+		"deleted_at":        time.Now(),
+		"version":           gorm.Expr("version + 1"),
+	}).Error
 	return err
 }
+
+// FindAllByContacts returns the addresses of the contacts
+func (repository *AddressRepositoryImpl) FindAllByContacts(ctx *fiber.Ctx, tx *gorm.DB, contactIDs []int64) []domain.Address {
+	addresses := []domain.Address{}
+	if len(contactIDs) == 0 {
+		return addresses
+	}
+
+	err := tenantDB(ctx, tx).Where("contact_id IN ?", contactIDs).Order("id").Find(&addresses).Error
+	helper.PanicIfError(err)
+	return addresses
+}
+
+// FindChangedSince returns the addresses of contacts in the address books, or
+// moved out of them, created, updated or deleted since the given time with
+// their contacts, deleted ones included
+func (repository *AddressRepositoryImpl) FindChangedSince(ctx *fiber.Ctx, tx *gorm.DB, addressBookIDs []int64, since time.Time) []domain.Address {
+	addresses := []domain.Address{}
+	if len(addressBookIDs) == 0 {
+		return addresses
+	}
+
+	contacts := tenantDB(ctx, tx).Unscoped().Model(&domain.Contact{}).Select("id").
+		Where("address_book_id IN ? OR id IN (?)", addressBookIDs, movedOutOf(ctx, tx, addressBookIDs, since))
+	err := tenantDB(ctx, tx).Unscoped().
+		Preload("Contact", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("contact_id IN (?)", contacts).
+		Where("updated_at >= ?", since).
+		Order("id").
+		Find(&addresses).Error
+	helper.PanicIfError(err)
+	return addresses
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type AdminActionRepository interface {
+	Create(ctx *fiber.Ctx, tx *gorm.DB, adminAction domain.AdminAction) domain.AdminAction
+	FindAll(ctx *fiber.Ctx, tx *gorm.DB, offset int, size int) ([]domain.AdminAction, int)
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type AdminActionRepositoryImpl struct {
+}
+
+func NewAdminActionRepository() AdminActionRepository {
+	return &AdminActionRepositoryImpl{}
+}
+
+func (repository *AdminActionRepositoryImpl) Create(ctx *fiber.Ctx, tx *gorm.DB, adminAction domain.AdminAction) domain.AdminAction {
+	err := tx.WithContext(ctx.UserContext()).Create(&adminAction).Error
+	helper.PanicIfError(err)
+	return adminAction
+}
+
+// FindAll returns a page of actions, newest first, and the number of actions
+func (repository *AdminActionRepositoryImpl) FindAll(ctx *fiber.Ctx, tx *gorm.DB, offset int, size int) ([]domain.AdminAction, int) {
+	var adminActions []domain.AdminAction
+	var totalItem int64
+
+	query := tx.WithContext(ctx.UserContext()).Model(&domain.AdminAction{})
+	query.Count(&totalItem)
+
+	err := query.Order("id DESC").Offset(offset).Limit(size).Find(&adminActions).Error
+	helper.PanicIfError(err)
+	return adminActions, int(totalItem)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/audit"
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository only ever adds logs, there is no way to change or remove one
+type AuditLogRepository interface {
+	Create(ctx *fiber.Ctx, tx *gorm.DB, auditLog domain.AuditLog) domain.AuditLog
+	FindAllByEntity(ctx *fiber.Ctx, tx *gorm.DB, entityType string, entityID int64, offset int, size int) ([]domain.AuditLog, int)
+	Search(ctx *fiber.Ctx, tx *gorm.DB, params audit.AuditLogSearchParams, offset int) ([]domain.AuditLog, int)
+}
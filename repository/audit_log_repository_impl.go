@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/audit"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepositoryImpl struct {
+}
+
+func NewAuditLogRepository() AuditLogRepository {
+	return &AuditLogRepositoryImpl{}
+}
+
+// Create stamps the log with the current organization, if the request runs in one
+func (repository *AuditLogRepositoryImpl) Create(ctx *fiber.Ctx, tx *gorm.DB, auditLog domain.AuditLog) domain.AuditLog {
+	if organization, ok := ctx.Locals("organization").(*domain.Organization); ok {
+		auditLog.OrganizationID = &organization.ID
+	}
+
+	err := tx.WithContext(ctx.UserContext()).Create(&auditLog).Error
+	helper.PanicIfError(err)
+	return auditLog
+}
+
+// FindAllByEntity returns a page of the logs of one entity in the current
+// organization, newest first, and the number of those logs
+func (repository *AuditLogRepositoryImpl) FindAllByEntity(ctx *fiber.Ctx, tx *gorm.DB, entityType string, entityID int64, offset int, size int) ([]domain.AuditLog, int) {
+	var auditLogs []domain.AuditLog
+	var totalItem int64
+
+	query := tenantDB(ctx, tx).Model(&domain.AuditLog{}).
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID)
+	query.Count(&totalItem)
+
+	err := query.Order("id DESC").Offset(offset).Limit(size).Find(&auditLogs).Error
+	helper.PanicIfError(err)
+	return auditLogs, int(totalItem)
+}
+
+// Search returns a page of the logs of every organization matching params,
+// newest first, and the number of matching logs
+func (repository *AuditLogRepositoryImpl) Search(ctx *fiber.Ctx, tx *gorm.DB, params audit.AuditLogSearchParams, offset int) ([]domain.AuditLog, int) {
+	var auditLogs []domain.AuditLog
+	var totalItem int64
+
+	query := tx.WithContext(ctx.UserContext()).Model(&domain.AuditLog{})
+
+	if params.OrganizationID != 0 {
+		query = query.Where("organization_id = ?", params.OrganizationID)
+	}
+
+	if params.ActorID != 0 {
+		query = query.Where("actor_id = ?", params.ActorID)
+	}
+
+	if params.Action != "" {
+		query = query.Where("action = ?", params.Action)
+	}
+
+	if params.EntityType != "" {
+		query = query.Where("entity_type = ?", params.EntityType)
+	}
+
+	if params.EntityID != 0 {
+		query = query.Where("entity_id = ?", params.EntityID)
+	}
+
+	if params.RequestID != "" {
+		query = query.Where("request_id = ?", params.RequestID)
+	}
+
+	if params.From != nil {
+		query = query.Where("created_at >= ?", *params.From)
+	}
+
+	if params.To != nil {
+		query = query.Where("created_at < ?", *params.To)
+	}
+
+	query.Count(&totalItem)
+
+	err := query.Order("id DESC").Offset(offset).Limit(params.Size).Find(&auditLogs).Error
+	helper.PanicIfError(err)
+	return auditLogs, int(totalItem)
+}
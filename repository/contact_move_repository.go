@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type ContactMoveRepository interface {
+	Create(ctx *fiber.Ctx, tx *gorm.DB, contactMove domain.ContactMove) domain.ContactMove
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type ContactMoveRepositoryImpl struct {
+}
+
+func NewContactMoveRepository() ContactMoveRepository {
+	return &ContactMoveRepositoryImpl{}
+}
+
+func (repository *ContactMoveRepositoryImpl) Create(ctx *fiber.Ctx, tx *gorm.DB, contactMove domain.ContactMove) domain.ContactMove {
+	contactMove.OrganizationID = CurrentOrganization(ctx).ID
+
+	err := tenantDB(ctx, tx).Create(&contactMove).Error
+	helper.PanicIfError(err)
+	return contactMove
+}
+
+// movedOutOf selects the contacts of the current organization moved out of
+// the address books since the given time
+func movedOutOf(ctx *fiber.Ctx, tx *gorm.DB, addressBookIDs []int64, since time.Time) *gorm.DB {
+	return tx.Session(&gorm.Session{NewDB: true}).Model(&domain.ContactMove{}).Scopes(TenantScope(ctx)).
+		Select("contact_id").
+		Where("from_address_book_id IN ? AND moved_at >= ?", addressBookIDs, since)
+}
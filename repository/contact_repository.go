@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/sorfian/go-contact-management-api/model/domain"
 	"github.com/sorfian/go-contact-management-api/model/web/contact"
@@ -9,8 +11,15 @@ import (
 
 type ContactRepository interface {
 	Create(ctx *fiber.Ctx, tx *gorm.DB, contact domain.Contact) domain.Contact
-	FindById(ctx *fiber.Ctx, tx *gorm.DB, id int64, userID int) (*domain.Contact, error)
+	FindById(ctx *fiber.Ctx, tx *gorm.DB, id int64, userID int, fields []string) (*domain.Contact, error)
 	FindAll(ctx *fiber.Ctx, tx *gorm.DB, userID int, params contact.SearchParams, offset int) ([]domain.Contact, int)
-	Update(ctx *fiber.Ctx, tx *gorm.DB, contact *domain.Contact) domain.Contact
+	Update(ctx *fiber.Ctx, tx *gorm.DB, contact *domain.Contact) (domain.Contact, error)
+	UpdatePhoto(ctx *fiber.Ctx, tx *gorm.DB, contact *domain.Contact) (domain.Contact, error)
 	Delete(ctx *fiber.Ctx, tx *gorm.DB, contact *domain.Contact) error
+	CountByAddressBook(ctx *fiber.Ctx, tx *gorm.DB, addressBookID int64) int64
+	Count(ctx *fiber.Ctx, tx *gorm.DB) int64
+	FindAllByAddressBooks(ctx *fiber.Ctx, tx *gorm.DB, addressBookIDs []int64) []domain.Contact
+	FindAllByIds(ctx *fiber.Ctx, tx *gorm.DB, ids []int64) []domain.Contact
+	FindChangedSince(ctx *fiber.Ctx, tx *gorm.DB, addressBookIDs []int64, since time.Time) []domain.Contact
+	FindByVCardName(ctx *fiber.Ctx, tx *gorm.DB, addressBookID int64, name string, id int64) (*domain.Contact, error)
 }
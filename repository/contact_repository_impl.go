@@ -2,6 +2,7 @@ package repository
 
 import (
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sorfian/go-contact-management-api/helper"
@@ -18,14 +19,26 @@ func NewContactRepository() ContactRepository {
 }
 
 func (repository *ContactRepositoryImpl) Create(ctx *fiber.Ctx, tx *gorm.DB, contact domain.Contact) domain.Contact {
-	err := tx.WithContext(ctx.UserContext()).Create(&contact).Error
+	contact.OrganizationID = CurrentOrganization(ctx).ID
+	omitted := emptyColumns(map[string]string{"email": contact.Email, "phone": contact.Phone})
+	err := tx.WithContext(ctx.UserContext()).Omit(omitted...).Create(&contact).Error
 	helper.PanicIfError(err)
 	return contact
 }
 
-func (repository *ContactRepositoryImpl) FindById(ctx *fiber.Ctx, tx *gorm.DB, id int64, userID int) (*domain.Contact, error) {
+// memberAddressBooks selects the address books of the current organization the
+// user is a member of
+func memberAddressBooks(ctx *fiber.Ctx, tx *gorm.DB, userID int) *gorm.DB {
+	return tx.Session(&gorm.Session{NewDB: true}).Model(&domain.AddressBookMember{}).Scopes(TenantScope(ctx)).Select("address_book_id").Where("user_id = ?", userID)
+}
+
+// FindById returns the contact when it is in an address book the user is a
+// member of, with the columns of the fields and all of them without fields.
+// Its address book and version are always read, for the permission check and
+// the entity tag.
+func (repository *ContactRepositoryImpl) FindById(ctx *fiber.Ctx, tx *gorm.DB, id int64, userID int, fields []string) (*domain.Contact, error) {
 	contactEntity := domain.Contact{}
-	err := tx.WithContext(ctx.UserContext()).Where("id = ? AND user_id = ?", id, userID).First(&contactEntity).Error
+	err := selectColumns(tenantDB(ctx, tx), fields, "address_book_id", "version").Where("id = ? AND address_book_id IN (?)", id, memberAddressBooks(ctx, tx, userID)).First(&contactEntity).Error
 	if err != nil {
 		return nil, err
 	}
@@ -36,8 +49,12 @@ func (repository *ContactRepositoryImpl) FindAll(ctx *fiber.Ctx, tx *gorm.DB, us
 	var contacts []domain.Contact
 	var totalItem int64
 
-	// Base query dengan user filter
-	query := tx.WithContext(ctx.UserContext()).Where("user_id = ?", userID)
+	// Base query dengan filter address book yang bisa diakses user
+	query := tenantDB(ctx, tx).Where("address_book_id IN (?)", memberAddressBooks(ctx, tx, userID))
+
+	if params.AddressBookID != 0 {
+		query = query.Where("address_book_id = ?", params.AddressBookID)
+	}
 
 	// Tambahkan filter search jika ada
 	if params.Name != "" {
@@ -59,18 +76,133 @@ func (repository *ContactRepositoryImpl) FindAll(ctx *fiber.Ctx, tx *gorm.DB, us
 	query.Model(&domain.Contact{}).Count(&totalItem)
 
 	// Apply pagination dan get data
-	err := query.Offset(offset).Limit(params.Size).Find(&contacts).Error
+	err := selectColumns(query, params.Fields).Offset(offset).Limit(params.Size).Find(&contacts).Error
 	helper.PanicIfError(err)
 	return contacts, int(totalItem)
 }
 
-func (repository *ContactRepositoryImpl) Update(ctx *fiber.Ctx, tx *gorm.DB, contact *domain.Contact) domain.Contact {
-	err := tx.WithContext(ctx.UserContext()).Save(contact).Error
-	helper.PanicIfError(err)
-	return *contact
+func (repository *ContactRepositoryImpl) Update(ctx *fiber.Ctx, tx *gorm.DB, contact *domain.Contact) (domain.Contact, error) {
+	// Only update the row when it still has the version that was read
+	result := tenantDB(ctx, tx).Model(contact).Where("version = ?", contact.Version).Updates(map[string]interface{}{
+		"user_id":         contact.UserID,
+		"address_book_id": contact.AddressBookID,
+		"first_name":      contact.FirstName,
+		"last_name":       contact.LastName,
+		"email":           nullIfEmpty(contact.Email),
+		"phone":           nullIfEmpty(contact.Phone),
+		"version":         gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return *contact, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return *contact, ErrVersionConflict
+	}
+
+	contact.Version++
+	return *contact, nil
+}
+
+func (repository *ContactRepositoryImpl) UpdatePhoto(ctx *fiber.Ctx, tx *gorm.DB, contact *domain.Contact) (domain.Contact, error) {
+	result := tenantDB(ctx, tx).Model(contact).Where("version = ?", contact.Version).Updates(map[string]interface{}{
+		"photo_key": contact.PhotoKey,
+		"version":   gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return *contact, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return *contact, ErrVersionConflict
+	}
+
+	contact.Version++
+	return *contact, nil
 }
 
 func (repository *ContactRepositoryImpl) Delete(ctx *fiber.Ctx, tx *gorm.DB, contact *domain.Contact) error {
-	err := tx.WithContext(ctx.UserContext()).Delete(contact).Error
-	return err
+	// Soft delete and stamp the deletion batch in a single statement
+	result := tenantDB(ctx, tx).Model(contact).Where("version = ?", contact.Version).Updates(map[string]interface{}{
+		"deletion_batch_id": contact.DeletionBatchID,
+		"deleted_at":        time.Now(),
+		"version":           gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+func (repository *ContactRepositoryImpl) CountByAddressBook(ctx *fiber.Ctx, tx *gorm.DB, addressBookID int64) int64 {
+	var count int64
+	err := tenantDB(ctx, tx).Model(&domain.Contact{}).Where("address_book_id = ?", addressBookID).Count(&count).Error
+	helper.PanicIfError(err)
+	return count
+}
+
+// Count counts the contacts of the organization
+func (repository *ContactRepositoryImpl) Count(ctx *fiber.Ctx, tx *gorm.DB) int64 {
+	var count int64
+	err := tenantDB(ctx, tx).Model(&domain.Contact{}).Count(&count).Error
+	helper.PanicIfError(err)
+	return count
+}
+
+// FindAllByAddressBooks returns the contacts of the address books
+func (repository *ContactRepositoryImpl) FindAllByAddressBooks(ctx *fiber.Ctx, tx *gorm.DB, addressBookIDs []int64) []domain.Contact {
+	contacts := []domain.Contact{}
+	if len(addressBookIDs) == 0 {
+		return contacts
+	}
+
+	err := tenantDB(ctx, tx).Where("address_book_id IN ?", addressBookIDs).Order("id").Find(&contacts).Error
+	helper.PanicIfError(err)
+	return contacts
+}
+
+// FindAllByIds returns the contacts with the IDs
+func (repository *ContactRepositoryImpl) FindAllByIds(ctx *fiber.Ctx, tx *gorm.DB, ids []int64) []domain.Contact {
+	contacts := []domain.Contact{}
+	if len(ids) == 0 {
+		return contacts
+	}
+
+	err := tenantDB(ctx, tx).Where("id IN ?", ids).Order("id").Find(&contacts).Error
+	helper.PanicIfError(err)
+	return contacts
+}
+
+// FindChangedSince returns the contacts of the address books created, updated
+// or deleted since the given time, deleted ones and ones moved out of them
+// included
+func (repository *ContactRepositoryImpl) FindChangedSince(ctx *fiber.Ctx, tx *gorm.DB, addressBookIDs []int64, since time.Time) []domain.Contact {
+	contacts := []domain.Contact{}
+	if len(addressBookIDs) == 0 {
+		return contacts
+	}
+
+	err := tenantDB(ctx, tx).Unscoped().
+		Where("address_book_id IN ? OR id IN (?)", addressBookIDs, movedOutOf(ctx, tx, addressBookIDs, since)).
+		Where("updated_at >= ?", since).
+		Order("id").
+		Find(&contacts).Error
+	helper.PanicIfError(err)
+	return contacts
+}
+
+// FindByVCardName returns the contact of the address book whose card has the
+// name. Contacts no CardDAV client named go by the name given for their ID,
+// which is 0 when the name is not one of those.
+func (repository *ContactRepositoryImpl) FindByVCardName(ctx *fiber.Ctx, tx *gorm.DB, addressBookID int64, name string, id int64) (*domain.Contact, error) {
+	contactEntity := domain.Contact{}
+	err := tenantDB(ctx, tx).
+		Where("address_book_id = ?", addressBookID).
+		Where("vcard_name = ? OR (vcard_name IS NULL AND id = ?)", name, id).
+		First(&contactEntity).Error
+	if err != nil {
+		return nil, err
+	}
+	return &contactEntity, nil
 }
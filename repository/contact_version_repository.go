@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type ContactVersionRepository interface {
+	Create(ctx *fiber.Ctx, tx *gorm.DB, contactVersion domain.ContactVersion) domain.ContactVersion
+	FindByNumber(ctx *fiber.Ctx, tx *gorm.DB, contactID int64, number int) (*domain.ContactVersion, error)
+	FindAll(ctx *fiber.Ctx, tx *gorm.DB, contactID int64, offset int, size int) ([]domain.ContactVersion, int)
+	LastNumber(ctx *fiber.Ctx, tx *gorm.DB, contactID int64) int
+}
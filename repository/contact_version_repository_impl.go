@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type ContactVersionRepositoryImpl struct {
+}
+
+func NewContactVersionRepository() ContactVersionRepository {
+	return &ContactVersionRepositoryImpl{}
+}
+
+func (repository *ContactVersionRepositoryImpl) Create(ctx *fiber.Ctx, tx *gorm.DB, contactVersion domain.ContactVersion) domain.ContactVersion {
+	contactVersion.OrganizationID = CurrentOrganization(ctx).ID
+
+	err := tenantDB(ctx, tx).Create(&contactVersion).Error
+	helper.PanicIfError(err)
+	return contactVersion
+}
+
+func (repository *ContactVersionRepositoryImpl) FindByNumber(ctx *fiber.Ctx, tx *gorm.DB, contactID int64, number int) (*domain.ContactVersion, error) {
+	contactVersion := domain.ContactVersion{}
+	err := tenantDB(ctx, tx).
+		Where("contact_id = ? AND number = ?", contactID, number).
+		First(&contactVersion).Error
+	if err != nil {
+		return nil, err
+	}
+	return &contactVersion, nil
+}
+
+// FindAll returns a page of the versions of the contact, newest first, and the
+// number of versions
+func (repository *ContactVersionRepositoryImpl) FindAll(ctx *fiber.Ctx, tx *gorm.DB, contactID int64, offset int, size int) ([]domain.ContactVersion, int) {
+	var contactVersions []domain.ContactVersion
+	var totalItem int64
+
+	query := tenantDB(ctx, tx).Model(&domain.ContactVersion{}).Where("contact_id = ?", contactID)
+	query.Count(&totalItem)
+
+	err := query.Order("number DESC").Offset(offset).Limit(size).Find(&contactVersions).Error
+	helper.PanicIfError(err)
+	return contactVersions, int(totalItem)
+}
+
+// LastNumber returns the number of the newest version of the contact, 0 when
+// it has none
+func (repository *ContactVersionRepositoryImpl) LastNumber(ctx *fiber.Ctx, tx *gorm.DB, contactID int64) int {
+	var number int
+	err := tenantDB(ctx, tx).Model(&domain.ContactVersion{}).
+		Where("contact_id = ?", contactID).
+		Select("COALESCE(MAX(number), 0)").
+		Scan(&number).Error
+	helper.PanicIfError(err)
+	return number
+}
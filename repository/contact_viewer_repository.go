@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type ContactViewerRepository interface {
+	Save(ctx *fiber.Ctx, tx *gorm.DB, viewer domain.ContactViewer) domain.ContactViewer
+	Extend(ctx *fiber.Ctx, tx *gorm.DB, connectionID string, expiresAt time.Time)
+	Delete(ctx *fiber.Ctx, tx *gorm.DB, connectionID string, contactID int64)
+	DeleteAll(ctx *fiber.Ctx, tx *gorm.DB, connectionID string)
+	FindActive(ctx *fiber.Ctx, tx *gorm.DB, contactIDs []int64, now time.Time) []domain.ContactViewer
+}
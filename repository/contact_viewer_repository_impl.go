@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type ContactViewerRepositoryImpl struct {
+}
+
+func NewContactViewerRepository() ContactViewerRepository {
+	return &ContactViewerRepositoryImpl{}
+}
+
+// Save adds the viewer, or moves its expiry when the connection already
+// views the contact
+func (repository *ContactViewerRepositoryImpl) Save(ctx *fiber.Ctx, tx *gorm.DB, viewer domain.ContactViewer) domain.ContactViewer {
+	viewer.OrganizationID = CurrentOrganization(ctx).ID
+	err := tenantDB(ctx, tx).Omit("User").
+		Where("connection_id = ? AND contact_id = ?", viewer.ConnectionID, viewer.ContactID).
+		Assign(domain.ContactViewer{ExpiresAt: viewer.ExpiresAt}).
+		FirstOrCreate(&viewer).Error
+	helper.PanicIfError(err)
+	return viewer
+}
+
+// Extend moves the expiry of every contact the connection views
+func (repository *ContactViewerRepositoryImpl) Extend(ctx *fiber.Ctx, tx *gorm.DB, connectionID string, expiresAt time.Time) {
+	err := tenantDB(ctx, tx).Model(&domain.ContactViewer{}).
+		Where("connection_id = ?", connectionID).
+		Update("expires_at", expiresAt).Error
+	helper.PanicIfError(err)
+}
+
+func (repository *ContactViewerRepositoryImpl) Delete(ctx *fiber.Ctx, tx *gorm.DB, connectionID string, contactID int64) {
+	err := tenantDB(ctx, tx).
+		Where("connection_id = ? AND contact_id = ?", connectionID, contactID).
+		Delete(&domain.ContactViewer{}).Error
+	helper.PanicIfError(err)
+}
+
+func (repository *ContactViewerRepositoryImpl) DeleteAll(ctx *fiber.Ctx, tx *gorm.DB, connectionID string) {
+	err := tenantDB(ctx, tx).Where("connection_id = ?", connectionID).Delete(&domain.ContactViewer{}).Error
+	helper.PanicIfError(err)
+}
+
+// FindActive returns the viewers of the contacts that have not expired at now
+func (repository *ContactViewerRepositoryImpl) FindActive(ctx *fiber.Ctx, tx *gorm.DB, contactIDs []int64, now time.Time) []domain.ContactViewer {
+	var viewers []domain.ContactViewer
+	err := tenantDB(ctx, tx).Preload("User").
+		Where("contact_id IN ? AND expires_at > ?", contactIDs, now).
+		Order("user_id").
+		Find(&viewers).Error
+	helper.PanicIfError(err)
+	return viewers
+}
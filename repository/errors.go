@@ -0,0 +1,10 @@
+package repository
+
+import "errors"
+
+// ErrVersionConflict is returned when a row changed since it was read
+var ErrVersionConflict = errors.New("version conflict")
+
+// ErrNoOrganization is raised when a tenant scoped query runs outside of an
+// organization, instead of running unscoped
+var ErrNoOrganization = errors.New("no organization in request context")
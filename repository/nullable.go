@@ -0,0 +1,21 @@
+package repository
+
+// nullIfEmpty stores an empty optional value as NULL
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// emptyColumns returns the columns whose optional values are empty, which are
+// left out when a row is created so they are NULL
+func emptyColumns(values map[string]string) []string {
+	var columns []string
+	for column, value := range values {
+		if value == "" {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type OrganizationMemberRepository interface {
+	Create(ctx *fiber.Ctx, tx *gorm.DB, member domain.OrganizationMember) domain.OrganizationMember
+	Find(ctx *fiber.Ctx, tx *gorm.DB, organizationID int64, userID int) (*domain.OrganizationMember, error)
+	FindFirstByUser(ctx *fiber.Ctx, tx *gorm.DB, userID int) (*domain.OrganizationMember, error)
+	FindAllByOrganization(ctx *fiber.Ctx, tx *gorm.DB, organizationID int64) []domain.OrganizationMember
+	FindAllByUser(ctx *fiber.Ctx, tx *gorm.DB, userID int) []domain.OrganizationMember
+	Update(ctx *fiber.Ctx, tx *gorm.DB, member *domain.OrganizationMember) domain.OrganizationMember
+	Delete(ctx *fiber.Ctx, tx *gorm.DB, member *domain.OrganizationMember) error
+}
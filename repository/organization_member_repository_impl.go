@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type OrganizationMemberRepositoryImpl struct {
+}
+
+func NewOrganizationMemberRepository() OrganizationMemberRepository {
+	return &OrganizationMemberRepositoryImpl{}
+}
+
+func (repository *OrganizationMemberRepositoryImpl) Create(ctx *fiber.Ctx, tx *gorm.DB, member domain.OrganizationMember) domain.OrganizationMember {
+	err := tx.WithContext(ctx.UserContext()).Omit("Organization", "User").Create(&member).Error
+	helper.PanicIfError(err)
+	return member
+}
+
+func (repository *OrganizationMemberRepositoryImpl) Find(ctx *fiber.Ctx, tx *gorm.DB, organizationID int64, userID int) (*domain.OrganizationMember, error) {
+	member := domain.OrganizationMember{}
+	err := tx.WithContext(ctx.UserContext()).
+		Preload("Organization").
+		Preload("User").
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// FindFirstByUser returns the oldest membership of the user, whose
+// organization requests without an organization run in
+func (repository *OrganizationMemberRepositoryImpl) FindFirstByUser(ctx *fiber.Ctx, tx *gorm.DB, userID int) (*domain.OrganizationMember, error) {
+	member := domain.OrganizationMember{}
+	err := tx.WithContext(ctx.UserContext()).
+		Preload("Organization").
+		Where("user_id = ?", userID).
+		Order("organization_id").
+		First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (repository *OrganizationMemberRepositoryImpl) FindAllByOrganization(ctx *fiber.Ctx, tx *gorm.DB, organizationID int64) []domain.OrganizationMember {
+	var members []domain.OrganizationMember
+	err := tx.WithContext(ctx.UserContext()).
+		Preload("User").
+		Where("organization_id = ?", organizationID).
+		Order("id").
+		Find(&members).Error
+	helper.PanicIfError(err)
+	return members
+}
+
+// FindAllByUser returns the memberships of the user with their organizations
+func (repository *OrganizationMemberRepositoryImpl) FindAllByUser(ctx *fiber.Ctx, tx *gorm.DB, userID int) []domain.OrganizationMember {
+	var members []domain.OrganizationMember
+	err := tx.WithContext(ctx.UserContext()).
+		Preload("Organization").
+		Where("user_id = ?", userID).
+		Order("organization_id").
+		Find(&members).Error
+	helper.PanicIfError(err)
+	return members
+}
+
+func (repository *OrganizationMemberRepositoryImpl) Update(ctx *fiber.Ctx, tx *gorm.DB, member *domain.OrganizationMember) domain.OrganizationMember {
+	err := tx.WithContext(ctx.UserContext()).Model(member).Update("role", member.Role).Error
+	helper.PanicIfError(err)
+	return *member
+}
+
+func (repository *OrganizationMemberRepositoryImpl) Delete(ctx *fiber.Ctx, tx *gorm.DB, member *domain.OrganizationMember) error {
+	return tx.WithContext(ctx.UserContext()).Delete(member).Error
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type OrganizationRepository interface {
+	Create(ctx *fiber.Ctx, tx *gorm.DB, organization domain.Organization) domain.Organization
+	Update(ctx *fiber.Ctx, tx *gorm.DB, organization *domain.Organization) domain.Organization
+}
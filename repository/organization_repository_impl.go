@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type OrganizationRepositoryImpl struct {
+}
+
+func NewOrganizationRepository() OrganizationRepository {
+	return &OrganizationRepositoryImpl{}
+}
+
+func (repository *OrganizationRepositoryImpl) Create(ctx *fiber.Ctx, tx *gorm.DB, organization domain.Organization) domain.Organization {
+	err := tx.WithContext(ctx.UserContext()).Create(&organization).Error
+	helper.PanicIfError(err)
+	return organization
+}
+
+func (repository *OrganizationRepositoryImpl) Update(ctx *fiber.Ctx, tx *gorm.DB, organization *domain.Organization) domain.Organization {
+	err := tx.WithContext(ctx.UserContext()).Model(organization).Select("name", "settings").Updates(organization).Error
+	helper.PanicIfError(err)
+	return *organization
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type OutboxRepository interface {
+	Create(ctx *fiber.Ctx, tx *gorm.DB, outboxEvent domain.OutboxEvent) domain.OutboxEvent
+	FindDue(ctx *fiber.Ctx, tx *gorm.DB, now time.Time, limit int) []domain.OutboxEvent
+	Claim(ctx *fiber.Ctx, tx *gorm.DB, outboxEvent *domain.OutboxEvent, now time.Time, until time.Time) bool
+	Update(ctx *fiber.Ctx, tx *gorm.DB, outboxEvent *domain.OutboxEvent) domain.OutboxEvent
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+// OutboxRepositoryImpl reads the outbox of every organization, the dispatcher
+// runs outside of a request
+type OutboxRepositoryImpl struct {
+}
+
+func NewOutboxRepository() OutboxRepository {
+	return &OutboxRepositoryImpl{}
+}
+
+// Create stamps the event with the current organization, if the request runs in one
+func (repository *OutboxRepositoryImpl) Create(ctx *fiber.Ctx, tx *gorm.DB, outboxEvent domain.OutboxEvent) domain.OutboxEvent {
+	if organization, ok := ctx.Locals("organization").(*domain.Organization); ok {
+		outboxEvent.OrganizationID = &organization.ID
+	}
+
+	err := tx.WithContext(ctx.UserContext()).Create(&outboxEvent).Error
+	helper.PanicIfError(err)
+	return outboxEvent
+}
+
+// FindDue returns the events that are not dispatched yet and due, in the order
+// they were recorded
+func (repository *OutboxRepositoryImpl) FindDue(ctx *fiber.Ctx, tx *gorm.DB, now time.Time, limit int) []domain.OutboxEvent {
+	var outboxEvents []domain.OutboxEvent
+	err := tx.WithContext(ctx.UserContext()).
+		Where("dispatched_at IS NULL AND next_attempt_at <= ?", now).
+		Order("id").
+		Limit(limit).
+		Find(&outboxEvents).Error
+	helper.PanicIfError(err)
+	return outboxEvents
+}
+
+// Claim moves the next attempt of an event that is still due at now to until,
+// so that no other dispatcher handles it in the meantime. It reports false
+// when another dispatcher claimed the event first.
+func (repository *OutboxRepositoryImpl) Claim(ctx *fiber.Ctx, tx *gorm.DB, outboxEvent *domain.OutboxEvent, now time.Time, until time.Time) bool {
+	result := tx.WithContext(ctx.UserContext()).Model(&domain.OutboxEvent{}).
+		Where("id = ? AND dispatched_at IS NULL AND next_attempt_at <= ?", outboxEvent.ID, now).
+		Update("next_attempt_at", until)
+	helper.PanicIfError(result.Error)
+
+	if result.RowsAffected == 0 {
+		return false
+	}
+	outboxEvent.NextAttemptAt = &until
+	return true
+}
+
+// Update saves the outcome of a dispatch
+func (repository *OutboxRepositoryImpl) Update(ctx *fiber.Ctx, tx *gorm.DB, outboxEvent *domain.OutboxEvent) domain.OutboxEvent {
+	err := tx.WithContext(ctx.UserContext()).Model(outboxEvent).
+		Select("attempts", "next_attempt_at", "error", "dispatched_at").
+		Updates(outboxEvent).Error
+	helper.PanicIfError(err)
+	return *outboxEvent
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+)
+
+// selectColumns limits a query to the columns of the fields, which are named
+// like them, the primary key and the required columns. Without fields every
+// column is read.
+func selectColumns(query *gorm.DB, fields []string, required ...string) *gorm.DB {
+	if len(fields) == 0 {
+		return query
+	}
+
+	columns := []string{"id"}
+	selected := map[string]bool{"id": true}
+	for _, column := range append(required, fields...) {
+		if !selected[column] {
+			selected[column] = true
+			columns = append(columns, column)
+		}
+	}
+	return query.Select(columns)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type StreamEventRepository interface {
+	Create(ctx *fiber.Ctx, tx *gorm.DB, streamEvent domain.StreamEvent) domain.StreamEvent
+	ExistsForEvent(ctx *fiber.Ctx, tx *gorm.DB, eventID string) bool
+	FindAfter(ctx *fiber.Ctx, tx *gorm.DB, userID int, after int64, limit int) []domain.StreamEvent
+	LastID(ctx *fiber.Ctx, tx *gorm.DB) int64
+	DeleteBefore(ctx *fiber.Ctx, tx *gorm.DB, before time.Time) int64
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type StreamEventRepositoryImpl struct {
+}
+
+func NewStreamEventRepository() StreamEventRepository {
+	return &StreamEventRepositoryImpl{}
+}
+
+func (repository *StreamEventRepositoryImpl) Create(ctx *fiber.Ctx, tx *gorm.DB, streamEvent domain.StreamEvent) domain.StreamEvent {
+	streamEvent.OrganizationID = CurrentOrganization(ctx).ID
+	err := tx.WithContext(ctx.UserContext()).Create(&streamEvent).Error
+	helper.PanicIfError(err)
+	return streamEvent
+}
+
+// ExistsForEvent reports whether the domain event was already recorded
+func (repository *StreamEventRepositoryImpl) ExistsForEvent(ctx *fiber.Ctx, tx *gorm.DB, eventID string) bool {
+	var count int64
+	err := tenantDB(ctx, tx).Model(&domain.StreamEvent{}).Where("event_id = ?", eventID).Count(&count).Error
+	helper.PanicIfError(err)
+	return count > 0
+}
+
+// FindAfter returns the events after the given ID of the address books the
+// user is a member of, oldest first
+func (repository *StreamEventRepositoryImpl) FindAfter(ctx *fiber.Ctx, tx *gorm.DB, userID int, after int64, limit int) []domain.StreamEvent {
+	var streamEvents []domain.StreamEvent
+	err := tenantDB(ctx, tx).
+		Where("id > ? AND address_book_id IN (?)", after, memberAddressBooks(ctx, tx, userID)).
+		Order("id").
+		Limit(limit).
+		Find(&streamEvents).Error
+	helper.PanicIfError(err)
+	return streamEvents
+}
+
+// LastID returns the ID of the latest event of the organization, 0 when there
+// is none
+func (repository *StreamEventRepositoryImpl) LastID(ctx *fiber.Ctx, tx *gorm.DB) int64 {
+	var lastID int64
+	err := tenantDB(ctx, tx).Model(&domain.StreamEvent{}).Select("COALESCE(MAX(id), 0)").Scan(&lastID).Error
+	helper.PanicIfError(err)
+	return lastID
+}
+
+// DeleteBefore deletes the events of every organization that occurred before
+// the given time and returns how many were deleted
+func (repository *StreamEventRepositoryImpl) DeleteBefore(ctx *fiber.Ctx, tx *gorm.DB, before time.Time) int64 {
+	result := tx.WithContext(ctx.UserContext()).Where("occurred_at < ?", before).Delete(&domain.StreamEvent{})
+	helper.PanicIfError(result.Error)
+	return result.RowsAffected
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CurrentOrganization returns the organization the request runs in, as set by
+// middleware.OrganizationMiddleware. It panics with ErrNoOrganization when
+// there is none.
+func CurrentOrganization(ctx *fiber.Ctx) *domain.Organization {
+	organization, ok := ctx.Locals("organization").(*domain.Organization)
+	if !ok || organization == nil {
+		panic(ErrNoOrganization)
+	}
+	return organization
+}
+
+// TenantScope limits a query to the rows of the current organization. Every
+// method of a repository over tenant data applies it, so that no query can
+// read or change the rows of another organization.
+func TenantScope(ctx *fiber.Ctx) func(db *gorm.DB) *gorm.DB {
+	organizationID := CurrentOrganization(ctx).ID
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(clause.Eq{
+			Column: clause.Column{Table: clause.CurrentTable, Name: "organization_id"},
+			Value:  organizationID,
+		})
+	}
+}
+
+// tenantDB starts a tenant scoped query in the request context
+func tenantDB(ctx *fiber.Ctx, tx *gorm.DB) *gorm.DB {
+	return tx.WithContext(ctx.UserContext()).Scopes(TenantScope(ctx))
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/user"
 	"gorm.io/gorm"
 )
 
@@ -12,4 +13,8 @@ type UserRepository interface {
 	FindByToken(ctx *fiber.Ctx, tx *gorm.DB, token string) (*domain.User, error)
 	Update(ctx *fiber.Ctx, tx *gorm.DB, user *domain.User) domain.User
 	FindById(ctx *fiber.Ctx, tx *gorm.DB, id int) (*domain.User, error)
+	FindAll(ctx *fiber.Ctx, tx *gorm.DB) []domain.User
+	Delete(ctx *fiber.Ctx, tx *gorm.DB, user *domain.User) error
+	Search(ctx *fiber.Ctx, tx *gorm.DB, params user.UserSearchParams, offset int) ([]domain.User, int)
+	CountStats(ctx *fiber.Ctx, tx *gorm.DB) user.UserStatsResponse
 }
@@ -1,9 +1,13 @@
 package repository
 
 import (
+	"strings"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/sorfian/go-contact-management-api/helper"
 	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/user"
 	"gorm.io/gorm"
 )
 
@@ -53,3 +57,74 @@ func (repository *UserRepositoryImpl) FindById(ctx *fiber.Ctx, tx *gorm.DB, id i
 	}
 	return &user, nil
 }
+
+func (repository *UserRepositoryImpl) FindAll(ctx *fiber.Ctx, tx *gorm.DB) []domain.User {
+	var users []domain.User
+	err := tx.WithContext(ctx.UserContext()).Order("id").Find(&users).Error
+	helper.PanicIfError(err)
+	return users
+}
+
+// Delete soft deletes the user, or removes it with its contacts and addresses
+// when tx is Unscoped
+func (repository *UserRepositoryImpl) Delete(ctx *fiber.Ctx, tx *gorm.DB, user *domain.User) error {
+	return tx.WithContext(ctx.UserContext()).Delete(user).Error
+}
+
+func (repository *UserRepositoryImpl) Search(ctx *fiber.Ctx, tx *gorm.DB, params user.UserSearchParams, offset int) ([]domain.User, int) {
+	var users []domain.User
+	var totalItem int64
+
+	query := tx.WithContext(ctx.UserContext()).Model(&domain.User{})
+
+	if params.Username != "" {
+		query = query.Where("LOWER(username) LIKE ?", "%"+strings.ToLower(params.Username)+"%")
+	}
+
+	if params.Name != "" {
+		query = query.Where("LOWER(name) LIKE ?", "%"+strings.ToLower(params.Name)+"%")
+	}
+
+	if params.Role != "" {
+		query = query.Where("role = ?", params.Role)
+	}
+
+	if params.Disabled != nil {
+		if *params.Disabled {
+			query = query.Where("disabled_at IS NOT NULL")
+		} else {
+			query = query.Where("disabled_at IS NULL")
+		}
+	}
+
+	query.Count(&totalItem)
+
+	err := query.Order("id").Offset(offset).Limit(params.Size).Find(&users).Error
+	helper.PanicIfError(err)
+	return users, int(totalItem)
+}
+
+func (repository *UserRepositoryImpl) CountStats(ctx *fiber.Ctx, tx *gorm.DB) user.UserStatsResponse {
+	db := tx.WithContext(ctx.UserContext())
+	stats := user.UserStatsResponse{}
+
+	counts := []struct {
+		count *int64
+		query *gorm.DB
+	}{
+		{&stats.TotalUsers, db.Model(&domain.User{})},
+		{&stats.AdminUsers, db.Model(&domain.User{}).Where("role = ?", domain.RoleAdmin)},
+		{&stats.DisabledUsers, db.Model(&domain.User{}).Where("disabled_at IS NOT NULL")},
+		{&stats.DeletedUsers, db.Unscoped().Model(&domain.User{}).Where("deleted_at IS NOT NULL")},
+		{&stats.ActiveSessions, db.Model(&domain.User{}).Where("token_exp > ?", time.Now().UnixMilli())},
+		{&stats.NewUsersLast30Days, db.Model(&domain.User{}).Where("created_at >= ?", time.Now().AddDate(0, 0, -30))},
+		{&stats.TotalContacts, db.Model(&domain.Contact{})},
+		{&stats.TotalAddresses, db.Model(&domain.Address{})},
+	}
+	for _, count := range counts {
+		err := count.query.Count(count.count).Error
+		helper.PanicIfError(err)
+	}
+
+	return stats
+}
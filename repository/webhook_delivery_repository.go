@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type WebhookDeliveryRepository interface {
+	Create(ctx *fiber.Ctx, tx *gorm.DB, delivery domain.WebhookDelivery) domain.WebhookDelivery
+	FindById(ctx *fiber.Ctx, tx *gorm.DB, id int64, webhookID int64) (*domain.WebhookDelivery, error)
+	ExistsForEvent(ctx *fiber.Ctx, tx *gorm.DB, webhookID int64, eventID string) bool
+	FindAll(ctx *fiber.Ctx, tx *gorm.DB, webhookID int64, status string, offset int, size int) ([]domain.WebhookDelivery, int)
+	FindDue(ctx *fiber.Ctx, tx *gorm.DB, now time.Time, limit int) []domain.WebhookDelivery
+	Claim(ctx *fiber.Ctx, tx *gorm.DB, delivery *domain.WebhookDelivery, now time.Time, until time.Time) bool
+	Update(ctx *fiber.Ctx, tx *gorm.DB, delivery *domain.WebhookDelivery) domain.WebhookDelivery
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type WebhookDeliveryRepositoryImpl struct {
+}
+
+func NewWebhookDeliveryRepository() WebhookDeliveryRepository {
+	return &WebhookDeliveryRepositoryImpl{}
+}
+
+func (repository *WebhookDeliveryRepositoryImpl) Create(ctx *fiber.Ctx, tx *gorm.DB, delivery domain.WebhookDelivery) domain.WebhookDelivery {
+	delivery.OrganizationID = CurrentOrganization(ctx).ID
+	err := tenantDB(ctx, tx).Omit("Webhook").Create(&delivery).Error
+	helper.PanicIfError(err)
+	return delivery
+}
+
+func (repository *WebhookDeliveryRepositoryImpl) FindById(ctx *fiber.Ctx, tx *gorm.DB, id int64, webhookID int64) (*domain.WebhookDelivery, error) {
+	delivery := domain.WebhookDelivery{}
+	err := tenantDB(ctx, tx).Preload("Webhook").
+		Where("id = ? AND webhook_id = ?", id, webhookID).
+		First(&delivery).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ExistsForEvent reports whether the event was already queued for the webhook
+func (repository *WebhookDeliveryRepositoryImpl) ExistsForEvent(ctx *fiber.Ctx, tx *gorm.DB, webhookID int64, eventID string) bool {
+	var count int64
+	err := tenantDB(ctx, tx).Model(&domain.WebhookDelivery{}).
+		Where("webhook_id = ? AND event_id = ?", webhookID, eventID).
+		Count(&count).Error
+	helper.PanicIfError(err)
+	return count > 0
+}
+
+// FindAll returns a page of the deliveries of the webhook, newest first, and
+// the number of those deliveries. An empty status returns every status.
+func (repository *WebhookDeliveryRepositoryImpl) FindAll(ctx *fiber.Ctx, tx *gorm.DB, webhookID int64, status string, offset int, size int) ([]domain.WebhookDelivery, int) {
+	var deliveries []domain.WebhookDelivery
+	var totalItem int64
+
+	query := tenantDB(ctx, tx).Model(&domain.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	query.Count(&totalItem)
+
+	err := query.Order("id DESC").Offset(offset).Limit(size).Find(&deliveries).Error
+	helper.PanicIfError(err)
+	return deliveries, int(totalItem)
+}
+
+// FindDue returns the pending deliveries of active webhooks whose next attempt
+// is due, oldest first. It reads every organization, the delivery worker runs
+// outside of a request.
+func (repository *WebhookDeliveryRepositoryImpl) FindDue(ctx *fiber.Ctx, tx *gorm.DB, now time.Time, limit int) []domain.WebhookDelivery {
+	var deliveries []domain.WebhookDelivery
+	err := tx.WithContext(ctx.UserContext()).
+		Joins("Webhook").
+		Where("webhook_deliveries.status = ? AND webhook_deliveries.next_attempt_at <= ?", domain.WebhookDeliveryPending, now).
+		Where("Webhook.active = ?", true).
+		Order("webhook_deliveries.next_attempt_at, webhook_deliveries.id").
+		Limit(limit).
+		Find(&deliveries).Error
+	helper.PanicIfError(err)
+	return deliveries
+}
+
+// Claim moves the next attempt of a delivery that is still due at now to until,
+// so that no other worker sends it in the meantime. It reports false when
+// another worker claimed the delivery first.
+func (repository *WebhookDeliveryRepositoryImpl) Claim(ctx *fiber.Ctx, tx *gorm.DB, delivery *domain.WebhookDelivery, now time.Time, until time.Time) bool {
+	result := tx.WithContext(ctx.UserContext()).Model(&domain.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", delivery.ID, domain.WebhookDeliveryPending, now).
+		Update("next_attempt_at", until)
+	helper.PanicIfError(result.Error)
+
+	if result.RowsAffected == 0 {
+		return false
+	}
+	delivery.NextAttemptAt = &until
+	return true
+}
+
+// Update saves the outcome of an attempt. Like FindDue it is not tenant
+// scoped, the delivery was read by ID beforehand.
+func (repository *WebhookDeliveryRepositoryImpl) Update(ctx *fiber.Ctx, tx *gorm.DB, delivery *domain.WebhookDelivery) domain.WebhookDelivery {
+	err := tx.WithContext(ctx.UserContext()).Model(delivery).
+		Select("status", "attempts", "next_attempt_at", "response_status", "error", "delivered_at").
+		Updates(delivery).Error
+	helper.PanicIfError(err)
+	return *delivery
+}
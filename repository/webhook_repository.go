@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type WebhookRepository interface {
+	Create(ctx *fiber.Ctx, tx *gorm.DB, webhook domain.Webhook) domain.Webhook
+	FindById(ctx *fiber.Ctx, tx *gorm.DB, id int64) (*domain.Webhook, error)
+	FindAll(ctx *fiber.Ctx, tx *gorm.DB) []domain.Webhook
+	FindAllActive(ctx *fiber.Ctx, tx *gorm.DB) []domain.Webhook
+	Update(ctx *fiber.Ctx, tx *gorm.DB, webhook *domain.Webhook) domain.Webhook
+	Delete(ctx *fiber.Ctx, tx *gorm.DB, webhook *domain.Webhook) error
+}
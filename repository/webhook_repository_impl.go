@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"gorm.io/gorm"
+)
+
+type WebhookRepositoryImpl struct {
+}
+
+func NewWebhookRepository() WebhookRepository {
+	return &WebhookRepositoryImpl{}
+}
+
+func (repository *WebhookRepositoryImpl) Create(ctx *fiber.Ctx, tx *gorm.DB, webhook domain.Webhook) domain.Webhook {
+	webhook.OrganizationID = CurrentOrganization(ctx).ID
+	err := tenantDB(ctx, tx).Create(&webhook).Error
+	helper.PanicIfError(err)
+	return webhook
+}
+
+func (repository *WebhookRepositoryImpl) FindById(ctx *fiber.Ctx, tx *gorm.DB, id int64) (*domain.Webhook, error) {
+	webhook := domain.Webhook{}
+	err := tenantDB(ctx, tx).Where("id = ?", id).First(&webhook).Error
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (repository *WebhookRepositoryImpl) FindAll(ctx *fiber.Ctx, tx *gorm.DB) []domain.Webhook {
+	var webhooks []domain.Webhook
+	err := tenantDB(ctx, tx).Order("id").Find(&webhooks).Error
+	helper.PanicIfError(err)
+	return webhooks
+}
+
+// FindAllActive returns the webhooks of the organization that are sent events
+func (repository *WebhookRepositoryImpl) FindAllActive(ctx *fiber.Ctx, tx *gorm.DB) []domain.Webhook {
+	var webhooks []domain.Webhook
+	err := tenantDB(ctx, tx).Where("active = ?", true).Order("id").Find(&webhooks).Error
+	helper.PanicIfError(err)
+	return webhooks
+}
+
+func (repository *WebhookRepositoryImpl) Update(ctx *fiber.Ctx, tx *gorm.DB, webhook *domain.Webhook) domain.Webhook {
+	err := tenantDB(ctx, tx).Model(webhook).Select("url", "secret", "events", "active").Updates(webhook).Error
+	helper.PanicIfError(err)
+	return *webhook
+}
+
+// Delete removes the webhook with its deliveries
+func (repository *WebhookRepositoryImpl) Delete(ctx *fiber.Ctx, tx *gorm.DB, webhook *domain.Webhook) error {
+	return tenantDB(ctx, tx).Delete(webhook).Error
+}
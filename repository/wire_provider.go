@@ -7,4 +7,18 @@ var Set = wire.NewSet(
 	NewUserRepository,
 	NewContactRepository,
 	NewAddressRepository,
+	NewAdminActionRepository,
+	NewAuditLogRepository,
+	NewContactVersionRepository,
+	NewContactMoveRepository,
+	NewWebhookRepository,
+	NewWebhookDeliveryRepository,
+	NewOutboxRepository,
+	NewStreamEventRepository,
+	NewContactViewerRepository,
+	NewAddressBookRepository,
+	NewAddressBookMemberRepository,
+	NewAddressBookInvitationRepository,
+	NewOrganizationRepository,
+	NewOrganizationMemberRepository,
 )
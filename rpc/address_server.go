@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/web/address"
+	pb "github.com/sorfian/go-contact-management-api/proto/contactmanagement/v1"
+	"github.com/sorfian/go-contact-management-api/service"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// AddressServer serves AddressService with the service the REST controllers call
+type AddressServer struct {
+	pb.UnimplementedAddressServiceServer
+	AddressService service.AddressService
+}
+
+func NewAddressServer(addressService service.AddressService) pb.AddressServiceServer {
+	return &AddressServer{AddressService: addressService}
+}
+
+func (server *AddressServer) CreateAddress(ctx context.Context, req *pb.CreateAddressRequest) (*pb.Address, error) {
+	fiberCtx, user := request(ctx)
+
+	createRequest := address.AddressCreateRequest{
+		Street:     req.GetStreet(),
+		City:       req.GetCity(),
+		Province:   req.GetProvince(),
+		Country:    req.GetCountry(),
+		PostalCode: req.GetPostalCode(),
+	}
+
+	addressResponse := server.AddressService.Create(fiberCtx, user, req.GetContactId(), &createRequest)
+	return toAddress(req.GetContactId(), addressResponse), nil
+}
+
+func (server *AddressServer) GetAddress(ctx context.Context, req *pb.GetAddressRequest) (*pb.Address, error) {
+	fiberCtx, user := request(ctx)
+	addressResponse := server.AddressService.Get(fiberCtx, user, req.GetContactId(), req.GetId(), nil)
+	return toAddress(req.GetContactId(), addressResponse), nil
+}
+
+func (server *AddressServer) UpdateAddress(ctx context.Context, req *pb.UpdateAddressRequest) (*pb.Address, error) {
+	fiberCtx, user := request(ctx)
+
+	patch := map[string]interface{}{}
+	setPatchField(patch, "street", req.Street)
+	setPatchField(patch, "city", req.City)
+	setPatchField(patch, "province", req.Province)
+	setPatchField(patch, "country", req.Country)
+	setPatchField(patch, "postal_code", req.PostalCode)
+
+	addressResponse := server.AddressService.Update(fiberCtx, user, req.GetContactId(), req.GetId(), marshalPatch(patch), req.GetExpectedVersion())
+	return toAddress(req.GetContactId(), addressResponse), nil
+}
+
+func (server *AddressServer) DeleteAddress(ctx context.Context, req *pb.DeleteAddressRequest) (*emptypb.Empty, error) {
+	fiberCtx, user := request(ctx)
+	server.AddressService.Delete(fiberCtx, user, req.GetContactId(), req.GetId(), req.GetExpectedVersion())
+	return &emptypb.Empty{}, nil
+}
+
+func (server *AddressServer) ListAddresses(ctx context.Context, req *pb.ListAddressesRequest) (*pb.ListAddressesResponse, error) {
+	fiberCtx, user := request(ctx)
+
+	addressResponses := server.AddressService.GetAll(fiberCtx, user, req.GetContactId(), nil)
+
+	response := &pb.ListAddressesResponse{Addresses: make([]*pb.Address, 0, len(addressResponses))}
+	for _, addressResponse := range addressResponses {
+		response.Addresses = append(response.Addresses, toAddress(req.GetContactId(), addressResponse))
+	}
+
+	return response, nil
+}
+
+func toAddress(contactID int64, addressResponse address.AddressResponse) *pb.Address {
+	return &pb.Address{
+		Id:         addressResponse.ID,
+		ContactId:  contactID,
+		Street:     addressResponse.Street,
+		City:       addressResponse.City,
+		Province:   addressResponse.Province,
+		Country:    addressResponse.Country,
+		PostalCode: addressResponse.PostalCode,
+		Version:    addressResponse.Version,
+	}
+}
+
+func setPatchField(patch map[string]interface{}, name string, value *string) {
+	if value != nil {
+		patch[name] = *value
+	}
+}
+
+func marshalPatch(patch map[string]interface{}) []byte {
+	data, err := json.Marshal(patch)
+	helper.PanicIfError(err)
+	return data
+}
@@ -0,0 +1,132 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/repository"
+	"github.com/sorfian/go-contact-management-api/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"gorm.io/gorm"
+)
+
+// OrganizationMetadata selects the organization a call runs in, like the
+// X-Organization-ID header of the REST API
+const OrganizationMetadata = "x-organization-id"
+
+// Authenticator authenticates calls by the "authorization: Bearer <token>"
+// metadata and resolves their organization, as the auth and organization
+// middlewares do for REST requests
+type Authenticator struct {
+	UserRepository      repository.UserRepository
+	OrganizationService service.OrganizationService
+	DB                  *gorm.DB
+}
+
+func NewAuthenticator(userRepository repository.UserRepository, organizationService service.OrganizationService, DB *gorm.DB) *Authenticator {
+	return &Authenticator{
+		UserRepository:      userRepository,
+		OrganizationService: organizationService,
+		DB:                  DB,
+	}
+}
+
+// Unary authenticates unary calls and turns the errors the services panic
+// with into status errors
+func (authenticator *Authenticator) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (response any, err error) {
+		defer recoverStatus(&err)
+
+		return handler(withRequest(ctx, authenticator.authenticate(ctx)), req)
+	}
+}
+
+// Stream authenticates streaming calls and turns the errors the services
+// panic with into status errors
+func (authenticator *Authenticator) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer recoverStatus(&err)
+
+		ctx := withRequest(stream.Context(), authenticator.authenticate(stream.Context()))
+		return handler(srv, &requestStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticate returns the context the services are called with for the call,
+// with the user and the organization set
+func (authenticator *Authenticator) authenticate(ctx context.Context) *fiber.Ctx {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	token := strings.TrimPrefix(firstMetadata(md, "authorization"), "Bearer ")
+	if token == "" {
+		panic(helper.NewUnauthorizedError("Missing authorization metadata"))
+	}
+
+	fiberCtx := helper.NewContext(ctx)
+	user, err := authenticator.UserRepository.FindByToken(fiberCtx, authenticator.DB, token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			panic(helper.NewUnauthorizedError("Invalid token"))
+		}
+		panic(err)
+	}
+
+	if user.TokenExp < time.Now().Unix() {
+		panic(helper.NewUnauthorizedError("Token expired"))
+	}
+
+	if user.DisabledAt != nil {
+		panic(helper.NewUnauthorizedError("Account disabled"))
+	}
+
+	fiberCtx.Locals("user", user)
+
+	var organizationID int64
+	if value := firstMetadata(md, OrganizationMetadata); value != "" {
+		organizationID, err = strconv.ParseInt(value, 10, 64)
+		if err != nil || organizationID < 1 {
+			panic(helper.NewBadRequestError("Invalid organization ID"))
+		}
+	}
+
+	fiberCtx.Locals("organization", authenticator.OrganizationService.Resolve(fiberCtx, *user, organizationID))
+
+	return fiberCtx
+}
+
+func firstMetadata(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+type contextKey struct{}
+
+func withRequest(ctx context.Context, fiberCtx *fiber.Ctx) context.Context {
+	return context.WithValue(ctx, contextKey{}, fiberCtx)
+}
+
+// request returns the context a call runs in and the user making it
+func request(ctx context.Context) (*fiber.Ctx, domain.User) {
+	fiberCtx := ctx.Value(contextKey{}).(*fiber.Ctx)
+	return fiberCtx, *fiberCtx.Locals("user").(*domain.User)
+}
+
+// requestStream is a server stream whose context carries the request
+type requestStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *requestStream) Context() context.Context {
+	return stream.ctx
+}
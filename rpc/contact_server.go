@@ -0,0 +1,151 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+	pb "github.com/sorfian/go-contact-management-api/proto/contactmanagement/v1"
+	"github.com/sorfian/go-contact-management-api/service"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// listPageSize is how many contacts ListContacts reads at a time
+const listPageSize = 100
+
+// ContactServer serves ContactService with the service the REST controllers call
+type ContactServer struct {
+	pb.UnimplementedContactServiceServer
+	ContactService service.ContactService
+}
+
+func NewContactServer(contactService service.ContactService) pb.ContactServiceServer {
+	return &ContactServer{ContactService: contactService}
+}
+
+func (server *ContactServer) CreateContact(ctx context.Context, req *pb.CreateContactRequest) (*pb.Contact, error) {
+	fiberCtx, user := request(ctx)
+
+	createRequest := contact.ContactCreateRequest{
+		AddressBookID: req.GetAddressBookId(),
+		FirstName:     req.GetFirstName(),
+		LastName:      req.GetLastName(),
+		Email:         req.GetEmail(),
+		Phone:         req.GetPhone(),
+	}
+
+	return toContact(server.ContactService.Create(fiberCtx, user, &createRequest)), nil
+}
+
+func (server *ContactServer) GetContact(ctx context.Context, req *pb.GetContactRequest) (*pb.Contact, error) {
+	fiberCtx, user := request(ctx)
+	return toContact(server.ContactService.Get(fiberCtx, user, req.GetId(), nil, nil)), nil
+}
+
+func (server *ContactServer) UpdateContact(ctx context.Context, req *pb.UpdateContactRequest) (*pb.Contact, error) {
+	fiberCtx, user := request(ctx)
+
+	// The fields set are applied like a JSON merge patch
+	patch := map[string]interface{}{}
+	if req.AddressBookId != nil {
+		patch["address_book_id"] = req.GetAddressBookId()
+	}
+	setPatchField(patch, "first_name", req.FirstName)
+	setPatchField(patch, "last_name", req.LastName)
+	setPatchField(patch, "email", req.Email)
+	setPatchField(patch, "phone", req.Phone)
+
+	contactResponse := server.ContactService.Update(fiberCtx, user, req.GetId(), marshalPatch(patch), req.GetExpectedVersion())
+	return toContact(contactResponse), nil
+}
+
+func (server *ContactServer) DeleteContact(ctx context.Context, req *pb.DeleteContactRequest) (*emptypb.Empty, error) {
+	fiberCtx, user := request(ctx)
+	server.ContactService.Delete(fiberCtx, user, req.GetId(), req.GetExpectedVersion())
+	return &emptypb.Empty{}, nil
+}
+
+func (server *ContactServer) SearchContacts(ctx context.Context, req *pb.SearchContactsRequest) (*pb.SearchContactsResponse, error) {
+	fiberCtx, user := request(ctx)
+
+	page := int(req.GetPage())
+	if page == 0 {
+		page = 1
+	}
+	size := int(req.GetSize())
+	if size == 0 {
+		size = 10
+	}
+
+	// The REST API has these checked against its spec
+	if page < 1 {
+		panic(helper.NewBadRequestError("page must be at least 1"))
+	}
+	if size < 1 || size > 100 {
+		panic(helper.NewBadRequestError("size must be between 1 and 100"))
+	}
+
+	searchResult := server.ContactService.GetAll(fiberCtx, user, contact.SearchParams{
+		AddressBookID: req.GetAddressBookId(),
+		Name:          req.GetName(),
+		Phone:         req.GetPhone(),
+		Email:         req.GetEmail(),
+		Page:          page,
+		Size:          size,
+	})
+
+	response := &pb.SearchContactsResponse{
+		Contacts: make([]*pb.Contact, 0, len(searchResult.Contacts)),
+		Paging: &pb.Paging{
+			Page:      int32(searchResult.Paging.Page),
+			Size:      int32(searchResult.Paging.Size),
+			TotalPage: int32(searchResult.Paging.TotalPage),
+			TotalItem: int32(searchResult.Paging.TotalItem),
+		},
+	}
+	for _, contactResponse := range searchResult.Contacts {
+		response.Contacts = append(response.Contacts, toContact(contactResponse))
+	}
+
+	return response, nil
+}
+
+// ListContacts sends the matching contacts a page at a time. Each page is read
+// on its own, so contacts changed while the listing runs can be sent twice or
+// left out.
+func (server *ContactServer) ListContacts(req *pb.ListContactsRequest, stream pb.ContactService_ListContactsServer) error {
+	fiberCtx, user := request(stream.Context())
+
+	searchParams := contact.SearchParams{
+		AddressBookID: req.GetAddressBookId(),
+		Name:          req.GetName(),
+		Phone:         req.GetPhone(),
+		Email:         req.GetEmail(),
+		Size:          listPageSize,
+	}
+
+	for searchParams.Page = 1; ; searchParams.Page++ {
+		searchResult := server.ContactService.GetAll(fiberCtx, user, searchParams)
+		for _, contactResponse := range searchResult.Contacts {
+			if err := stream.Send(toContact(contactResponse)); err != nil {
+				return err
+			}
+		}
+
+		if searchParams.Page >= searchResult.Paging.TotalPage {
+			return nil
+		}
+	}
+}
+
+func toContact(contactResponse contact.ContactResponse) *pb.Contact {
+	return &pb.Contact{
+		Id:            contactResponse.ID,
+		AddressBookId: contactResponse.AddressBookID,
+		FirstName:     contactResponse.FirstName,
+		LastName:      contactResponse.LastName,
+		Email:         contactResponse.Email,
+		Phone:         contactResponse.Phone,
+		Version:       contactResponse.Version,
+	}
+}
@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recoverStatus turns a panic into the status error of the call
+func recoverStatus(err *error) {
+	if recovered := recover(); recovered != nil {
+		*err = toStatus(recovered)
+	}
+}
+
+// toStatus maps an error the services panic with to the status code closest
+// to the HTTP status the REST API responds with
+func toStatus(value any) error {
+	err, ok := value.(error)
+	if !ok {
+		err = fmt.Errorf("%v", value)
+	}
+
+	response := helper.NewErrorResponse(err)
+
+	code := codes.Internal
+	switch response.Code {
+	case fiber.StatusBadRequest:
+		code = codes.InvalidArgument
+	case fiber.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case fiber.StatusForbidden:
+		code = codes.PermissionDenied
+	case fiber.StatusNotFound:
+		code = codes.NotFound
+	case fiber.StatusConflict:
+		code = codes.FailedPrecondition
+	case fiber.StatusPreconditionFailed:
+		// A version mismatch, the client reads again and retries
+		code = codes.Aborted
+	default:
+		log.Printf("grpc: %v", err)
+	}
+
+	return status.Error(code, fmt.Sprint(response.Data))
+}
@@ -0,0 +1,21 @@
+package rpc
+
+import (
+	pb "github.com/sorfian/go-contact-management-api/proto/contactmanagement/v1"
+	"google.golang.org/grpc"
+)
+
+// NewServer creates the gRPC server of the contact and address services. Each
+// call is authenticated by the token in its metadata and runs in an
+// organization, the same as a REST request.
+func NewServer(contactServer pb.ContactServiceServer, addressServer pb.AddressServiceServer, authenticator *Authenticator) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(authenticator.Unary()),
+		grpc.ChainStreamInterceptor(authenticator.Stream()),
+	)
+
+	pb.RegisterContactServiceServer(server, contactServer)
+	pb.RegisterAddressServiceServer(server, addressServer)
+
+	return server
+}
@@ -0,0 +1,11 @@
+package rpc
+
+import "github.com/google/wire"
+
+// Set RPCSet is a Wire provider set for the gRPC server
+var Set = wire.NewSet(
+	NewAuthenticator,
+	NewContactServer,
+	NewAddressServer,
+	NewServer,
+)
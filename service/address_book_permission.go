@@ -0,0 +1,74 @@
+package service
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/repository"
+	"gorm.io/gorm"
+)
+
+// requireAddressBookPermission returns the user's membership of the address
+// book. Users who are not members get not found, so that the address books of
+// others stay hidden, and members without the permission get forbidden.
+func requireAddressBookPermission(ctx *fiber.Ctx, tx *gorm.DB, memberRepository repository.AddressBookMemberRepository, addressBookID int64, userID int, permission string) *domain.AddressBookMember {
+	member, err := memberRepository.Find(ctx, tx, addressBookID, userID)
+	if err != nil {
+		panic(helper.NewNotFoundError("address book not found"))
+	}
+
+	if !member.Allows(permission) {
+		panic(helper.NewForbiddenError("address book permission " + permission + " required"))
+	}
+
+	return member
+}
+
+// defaultAddressBook returns the first address book the user owns in the
+// organization, creating one named by the organization settings for users who
+// have none
+func defaultAddressBook(ctx *fiber.Ctx, tx *gorm.DB, addressBookRepository repository.AddressBookRepository, memberRepository repository.AddressBookMemberRepository, user domain.User) *domain.AddressBook {
+	addressBook, err := addressBookRepository.FindFirstOwned(ctx, tx, user.ID)
+	if err == nil {
+		return addressBook
+	}
+
+	name := repository.CurrentOrganization(ctx).Settings.AddressBookName()
+	createdAddressBook := createAddressBook(ctx, tx, addressBookRepository, memberRepository, user, name)
+	return &createdAddressBook
+}
+
+// createAddressBook creates an address book with its owner as the only member
+func createAddressBook(ctx *fiber.Ctx, tx *gorm.DB, addressBookRepository repository.AddressBookRepository, memberRepository repository.AddressBookMemberRepository, user domain.User, name string) domain.AddressBook {
+	addressBook := addressBookRepository.Create(ctx, tx, domain.AddressBook{
+		OwnerID: user.ID,
+		Name:    name,
+	})
+
+	memberRepository.Create(ctx, tx, domain.AddressBookMember{
+		AddressBookID: addressBook.ID,
+		UserID:        user.ID,
+		Permission:    domain.PermissionOwner,
+	})
+
+	return addressBook
+}
+
+// findAuthorizedContact returns a contact of an address book the user is a
+// member of with the permission. Contacts of other address books are not found.
+func findAuthorizedContact(ctx *fiber.Ctx, tx *gorm.DB, contactRepository repository.ContactRepository, memberRepository repository.AddressBookMemberRepository, user domain.User, contactID int64, permission string) *domain.Contact {
+	return findAuthorizedContactFields(ctx, tx, contactRepository, memberRepository, user, contactID, permission, nil)
+}
+
+// findAuthorizedContactFields is findAuthorizedContact reading the contact with
+// the columns of the fields, all of them without fields
+func findAuthorizedContactFields(ctx *fiber.Ctx, tx *gorm.DB, contactRepository repository.ContactRepository, memberRepository repository.AddressBookMemberRepository, user domain.User, contactID int64, permission string, fields []string) *domain.Contact {
+	contactEntity, err := contactRepository.FindById(ctx, tx, contactID, user.ID, fields)
+	if err != nil {
+		panic(helper.NewNotFoundError("contact not found"))
+	}
+
+	requireAddressBookPermission(ctx, tx, memberRepository, contactEntity.AddressBookID, user.ID, permission)
+
+	return contactEntity
+}
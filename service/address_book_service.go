@@ -0,0 +1,18 @@
+package service
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/addressbook"
+)
+
+type AddressBookService interface {
+	Create(ctx *fiber.Ctx, user domain.User, request *addressbook.AddressBookCreateRequest) addressbook.AddressBookResponse
+	Get(ctx *fiber.Ctx, user domain.User, addressBookID int64) addressbook.AddressBookResponse
+	GetAll(ctx *fiber.Ctx, user domain.User) []addressbook.AddressBookResponse
+	Update(ctx *fiber.Ctx, user domain.User, addressBookID int64, patch []byte) addressbook.AddressBookResponse
+	Delete(ctx *fiber.Ctx, user domain.User, addressBookID int64)
+	GetMembers(ctx *fiber.Ctx, user domain.User, addressBookID int64) []addressbook.MemberResponse
+	UpdateMember(ctx *fiber.Ctx, user domain.User, addressBookID int64, memberUserID int, request *addressbook.MemberUpdateRequest) addressbook.MemberResponse
+	RemoveMember(ctx *fiber.Ctx, user domain.User, addressBookID int64, memberUserID int)
+}
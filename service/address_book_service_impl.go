@@ -0,0 +1,186 @@
+package service
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/addressbook"
+	"github.com/sorfian/go-contact-management-api/repository"
+	"gorm.io/gorm"
+)
+
+type AddressBookServiceImpl struct {
+	AddressBookRepository       repository.AddressBookRepository
+	AddressBookMemberRepository repository.AddressBookMemberRepository
+	ContactRepository           repository.ContactRepository
+	DB                          *gorm.DB
+	Validate                    *validator.Validate
+}
+
+func NewAddressBookService(addressBookRepository repository.AddressBookRepository, addressBookMemberRepository repository.AddressBookMemberRepository, contactRepository repository.ContactRepository, DB *gorm.DB, validate *validator.Validate) AddressBookService {
+	return &AddressBookServiceImpl{
+		AddressBookRepository:       addressBookRepository,
+		AddressBookMemberRepository: addressBookMemberRepository,
+		ContactRepository:           contactRepository,
+		DB:                          DB,
+		Validate:                    validate,
+	}
+}
+
+func (service *AddressBookServiceImpl) Create(ctx *fiber.Ctx, user domain.User, request *addressbook.AddressBookCreateRequest) addressbook.AddressBookResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	addressBook := createAddressBook(ctx, tx, service.AddressBookRepository, service.AddressBookMemberRepository, user, request.Name)
+
+	return toAddressBookResponse(&addressBook, domain.PermissionOwner)
+}
+
+func (service *AddressBookServiceImpl) Get(ctx *fiber.Ctx, user domain.User, addressBookID int64) addressbook.AddressBookResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	member := requireAddressBookPermission(ctx, tx, service.AddressBookMemberRepository, addressBookID, user.ID, domain.PermissionViewer)
+
+	return toAddressBookResponse(&member.AddressBook, member.Permission)
+}
+
+func (service *AddressBookServiceImpl) GetAll(ctx *fiber.Ctx, user domain.User) []addressbook.AddressBookResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	// Every user has an address book of their own to put contacts in
+	defaultAddressBook(ctx, tx, service.AddressBookRepository, service.AddressBookMemberRepository, user)
+
+	members := service.AddressBookMemberRepository.FindAllByUser(ctx, tx, user.ID)
+
+	addressBookResponses := []addressbook.AddressBookResponse{}
+	for _, member := range members {
+		addressBookResponses = append(addressBookResponses, toAddressBookResponse(&member.AddressBook, member.Permission))
+	}
+
+	return addressBookResponses
+}
+
+func (service *AddressBookServiceImpl) Update(ctx *fiber.Ctx, user domain.User, addressBookID int64, patch []byte) addressbook.AddressBookResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	member := requireAddressBookPermission(ctx, tx, service.AddressBookMemberRepository, addressBookID, user.ID, domain.PermissionOwner)
+
+	merged := addressbook.AddressBookCreateRequest{Name: member.AddressBook.Name}
+	err := helper.ApplyMergePatch(&merged, patch)
+	helper.PanicIfError(err)
+
+	err = service.Validate.Struct(merged)
+	helper.PanicIfError(err)
+
+	member.AddressBook.Name = merged.Name
+	updatedAddressBook := service.AddressBookRepository.Update(ctx, tx, &member.AddressBook)
+
+	return toAddressBookResponse(&updatedAddressBook, member.Permission)
+}
+
+// Delete removes an empty address book, contacts have to be moved or deleted
+// first
+func (service *AddressBookServiceImpl) Delete(ctx *fiber.Ctx, user domain.User, addressBookID int64) {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	member := requireAddressBookPermission(ctx, tx, service.AddressBookMemberRepository, addressBookID, user.ID, domain.PermissionOwner)
+
+	if service.ContactRepository.CountByAddressBook(ctx, tx, addressBookID) > 0 {
+		panic(helper.NewResourceConflictError("address book still has contacts"))
+	}
+
+	err := service.AddressBookRepository.Delete(ctx, tx, &member.AddressBook)
+	helper.PanicIfError(err)
+}
+
+func (service *AddressBookServiceImpl) GetMembers(ctx *fiber.Ctx, user domain.User, addressBookID int64) []addressbook.MemberResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	requireAddressBookPermission(ctx, tx, service.AddressBookMemberRepository, addressBookID, user.ID, domain.PermissionViewer)
+
+	members := service.AddressBookMemberRepository.FindAllByAddressBook(ctx, tx, addressBookID)
+
+	memberResponses := []addressbook.MemberResponse{}
+	for _, member := range members {
+		memberResponses = append(memberResponses, toMemberResponse(&member))
+	}
+
+	return memberResponses
+}
+
+func (service *AddressBookServiceImpl) UpdateMember(ctx *fiber.Ctx, user domain.User, addressBookID int64, memberUserID int, request *addressbook.MemberUpdateRequest) addressbook.MemberResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	requireAddressBookPermission(ctx, tx, service.AddressBookMemberRepository, addressBookID, user.ID, domain.PermissionOwner)
+
+	member := service.findMember(ctx, tx, addressBookID, memberUserID)
+	if member.Permission == domain.PermissionOwner {
+		panic(helper.NewBadRequestError("the owner permission cannot be changed"))
+	}
+
+	member.Permission = request.Permission
+	updatedMember := service.AddressBookMemberRepository.Update(ctx, tx, member)
+
+	return toMemberResponse(&updatedMember)
+}
+
+// RemoveMember lets the owner remove a member and any member leave the
+// address book
+func (service *AddressBookServiceImpl) RemoveMember(ctx *fiber.Ctx, user domain.User, addressBookID int64, memberUserID int) {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	permission := domain.PermissionOwner
+	if memberUserID == user.ID {
+		permission = domain.PermissionViewer
+	}
+	requireAddressBookPermission(ctx, tx, service.AddressBookMemberRepository, addressBookID, user.ID, permission)
+
+	member := service.findMember(ctx, tx, addressBookID, memberUserID)
+	if member.Permission == domain.PermissionOwner {
+		panic(helper.NewBadRequestError("the owner cannot leave the address book"))
+	}
+
+	err := service.AddressBookMemberRepository.Delete(ctx, tx, member)
+	helper.PanicIfError(err)
+}
+
+func (service *AddressBookServiceImpl) findMember(ctx *fiber.Ctx, tx *gorm.DB, addressBookID int64, userID int) *domain.AddressBookMember {
+	member, err := service.AddressBookMemberRepository.Find(ctx, tx, addressBookID, userID)
+	if err != nil {
+		panic(helper.NewNotFoundError("member not found"))
+	}
+	return member
+}
+
+func toAddressBookResponse(addressBook *domain.AddressBook, permission string) addressbook.AddressBookResponse {
+	return addressbook.AddressBookResponse{
+		ID:         addressBook.ID,
+		Name:       addressBook.Name,
+		OwnerID:    addressBook.OwnerID,
+		Permission: permission,
+		CreatedAt:  addressBook.CreatedAt,
+	}
+}
+
+func toMemberResponse(member *domain.AddressBookMember) addressbook.MemberResponse {
+	return addressbook.MemberResponse{
+		UserID:     member.UserID,
+		Username:   member.User.Username,
+		Name:       member.User.Name,
+		Permission: member.Permission,
+	}
+}
@@ -8,8 +8,10 @@ import (
 
 type AddressService interface {
 	Create(ctx *fiber.Ctx, user domain.User, contactID int64, request *address.AddressCreateRequest) address.AddressResponse
-	Get(ctx *fiber.Ctx, user domain.User, contactID int64, addressID int64) address.AddressResponse
-	GetAll(ctx *fiber.Ctx, user domain.User, contactID int64) []address.AddressResponse
-	Update(ctx *fiber.Ctx, user domain.User, contactID int64, addressID int64, request address.AddressUpdateRequest) address.AddressResponse
-	Delete(ctx *fiber.Ctx, user domain.User, contactID int64, addressID int64)
+	Get(ctx *fiber.Ctx, user domain.User, contactID int64, addressID int64, fields []string) address.AddressResponse
+	GetAll(ctx *fiber.Ctx, user domain.User, contactID int64, fields []string) []address.AddressResponse
+	GetAllByContacts(ctx *fiber.Ctx, user domain.User, contactIDs []int64) map[int64][]address.AddressResponse
+	Update(ctx *fiber.Ctx, user domain.User, contactID int64, addressID int64, patch []byte, expectedVersion int64) address.AddressResponse
+	Replace(ctx *fiber.Ctx, user domain.User, contactID int64, addressID int64, request *address.AddressCreateRequest, expectedVersion int64) address.AddressResponse
+	Delete(ctx *fiber.Ctx, user domain.User, contactID int64, addressID int64, expectedVersion int64)
 }
@@ -1,8 +1,11 @@
 package service
 
 import (
+	"errors"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/event"
 	"github.com/sorfian/go-contact-management-api/helper"
 	"github.com/sorfian/go-contact-management-api/model/domain"
 	"github.com/sorfian/go-contact-management-api/model/web/address"
@@ -11,18 +14,26 @@ import (
 )
 
 type AddressServiceImpl struct {
-	AddressRepository repository.AddressRepository
-	ContactRepository repository.ContactRepository
-	DB                *gorm.DB
-	Validate          *validator.Validate
+	AddressRepository           repository.AddressRepository
+	ContactRepository           repository.ContactRepository
+	AddressBookMemberRepository repository.AddressBookMemberRepository
+	AuditLogRepository          repository.AuditLogRepository
+	ContactVersionRepository    repository.ContactVersionRepository
+	OutboxRepository            repository.OutboxRepository
+	DB                          *gorm.DB
+	Validate                    *validator.Validate
 }
 
-func NewAddressService(addressRepository repository.AddressRepository, contactRepository repository.ContactRepository, DB *gorm.DB, validate *validator.Validate) AddressService {
+func NewAddressService(addressRepository repository.AddressRepository, contactRepository repository.ContactRepository, addressBookMemberRepository repository.AddressBookMemberRepository, auditLogRepository repository.AuditLogRepository, contactVersionRepository repository.ContactVersionRepository, outboxRepository repository.OutboxRepository, DB *gorm.DB, validate *validator.Validate) AddressService {
 	return &AddressServiceImpl{
-		AddressRepository: addressRepository,
-		ContactRepository: contactRepository,
-		DB:                DB,
-		Validate:          validate,
+		AddressRepository:           addressRepository,
+		ContactRepository:           contactRepository,
+		AddressBookMemberRepository: addressBookMemberRepository,
+		AuditLogRepository:          auditLogRepository,
+		ContactVersionRepository:    contactVersionRepository,
+		OutboxRepository:            outboxRepository,
+		DB:                          DB,
+		Validate:                    validate,
 	}
 }
 
@@ -33,11 +44,8 @@ func (service *AddressServiceImpl) Create(ctx *fiber.Ctx, user domain.User, cont
 	tx := service.DB.Begin()
 	defer helper.CommitOrRollback(tx)
 
-	// Verify contact belongs to user
-	_, err = service.ContactRepository.FindById(ctx, tx, contactID, user.ID)
-	if err != nil {
-		panic(helper.NewNotFoundError("contact not found"))
-	}
+	// Verify the user may edit the contact
+	contactEntity := findAuthorizedContact(ctx, tx, service.ContactRepository, service.AddressBookMemberRepository, user, contactID, domain.PermissionEditor)
 
 	newAddress := domain.Address{
 		ContactID:  contactID,
@@ -46,137 +54,204 @@ func (service *AddressServiceImpl) Create(ctx *fiber.Ctx, user domain.User, cont
 		Province:   request.Province,
 		Country:    request.Country,
 		PostalCode: request.PostalCode,
+		Version:    1,
 	}
 
 	createdAddress := service.AddressRepository.Create(ctx, tx, newAddress)
+	addressResponse := toAddressResponse(&createdAddress)
 
-	return address.AddressResponse{
-		ID:         createdAddress.ID,
-		Street:     createdAddress.Street,
-		City:       createdAddress.City,
-		Province:   createdAddress.Province,
-		Country:    createdAddress.Country,
-		PostalCode: createdAddress.PostalCode,
-	}
+	recordAudit(ctx, tx, service.AuditLogRepository, user, domain.AuditActionCreate, domain.AuditEntityAddress, createdAddress.ID, auditChanges(nil, addressResponse))
+	recordContactVersion(ctx, tx, service.ContactVersionRepository, service.AddressRepository, user, contactEntity)
+	recordEvent(ctx, tx, service.OutboxRepository, user, event.AddressCreated{ContactID: contactEntity.ID, AddressBookID: contactEntity.AddressBookID, Address: addressResponse})
+
+	return addressResponse
 }
 
-func (service *AddressServiceImpl) Get(ctx *fiber.Ctx, user domain.User, contactID int64, addressID int64) address.AddressResponse {
+// Get returns the address read with the columns of the fields, all of them
+// without fields
+func (service *AddressServiceImpl) Get(ctx *fiber.Ctx, user domain.User, contactID int64, addressID int64, fields []string) address.AddressResponse {
 	tx := service.DB.Begin()
 	defer helper.CommitOrRollback(tx)
 
-	// Verify contact belongs to user
-	_, err := service.ContactRepository.FindById(ctx, tx, contactID, user.ID)
-	if err != nil {
-		panic(helper.NewNotFoundError("contact not found"))
-	}
+	// Verify the user may read the contact
+	findAuthorizedContact(ctx, tx, service.ContactRepository, service.AddressBookMemberRepository, user, contactID, domain.PermissionViewer)
 
-	addressEntity, err := service.AddressRepository.FindById(ctx, tx, addressID, contactID)
+	addressEntity, err := service.AddressRepository.FindById(ctx, tx, addressID, contactID, fields)
 	if err != nil {
 		panic(helper.NewNotFoundError("address not found"))
 	}
 
-	return address.AddressResponse{
-		ID:         addressEntity.ID,
-		Street:     addressEntity.Street,
-		City:       addressEntity.City,
-		Province:   addressEntity.Province,
-		Country:    addressEntity.Country,
-		PostalCode: addressEntity.PostalCode,
-	}
+	return toAddressResponse(addressEntity)
 }
 
-func (service *AddressServiceImpl) GetAll(ctx *fiber.Ctx, user domain.User, contactID int64) []address.AddressResponse {
+func (service *AddressServiceImpl) GetAll(ctx *fiber.Ctx, user domain.User, contactID int64, fields []string) []address.AddressResponse {
 	tx := service.DB.Begin()
 	defer helper.CommitOrRollback(tx)
 
-	// Verify contact belongs to a user
-	_, err := service.ContactRepository.FindById(ctx, tx, contactID, user.ID)
-	if err != nil {
-		panic(helper.NewNotFoundError("contact not found"))
-	}
+	// Verify the user may read the contact
+	findAuthorizedContact(ctx, tx, service.ContactRepository, service.AddressBookMemberRepository, user, contactID, domain.PermissionViewer)
 
-	addresses := service.AddressRepository.FindAll(ctx, tx, contactID)
+	addresses := service.AddressRepository.FindAll(ctx, tx, contactID, fields)
 
-	var addressResponses []address.AddressResponse
+	addressResponses := []address.AddressResponse{}
 	for _, newAddress := range addresses {
-		addressResponses = append(addressResponses, address.AddressResponse{
-			ID:         newAddress.ID,
-			Street:     newAddress.Street,
-			City:       newAddress.City,
-			Province:   newAddress.Province,
-			Country:    newAddress.Country,
-			PostalCode: newAddress.PostalCode,
-		})
+		addressResponses = append(addressResponses, toAddressResponse(&newAddress))
 	}
 
 	return addressResponses
 }
 
-func (service *AddressServiceImpl) Update(ctx *fiber.Ctx, user domain.User, contactID int64, addressID int64, request address.AddressUpdateRequest) address.AddressResponse {
-	err := service.Validate.Struct(request)
-	helper.PanicIfError(err)
-
+// GetAllByContacts returns the addresses of many contacts at once, keyed by
+// contact. Contacts the user may not read are left out.
+func (service *AddressServiceImpl) GetAllByContacts(ctx *fiber.Ctx, user domain.User, contactIDs []int64) map[int64][]address.AddressResponse {
 	tx := service.DB.Begin()
 	defer helper.CommitOrRollback(tx)
 
-	// Verify contact belongs to a user
-	_, err = service.ContactRepository.FindById(ctx, tx, contactID, user.ID)
-	if err != nil {
-		panic(helper.NewNotFoundError("contact not found"))
+	visible := map[int64]bool{}
+	for _, member := range service.AddressBookMemberRepository.FindAllByUser(ctx, tx, user.ID) {
+		visible[member.AddressBookID] = true
 	}
 
-	addressEntity, err := service.AddressRepository.FindById(ctx, tx, addressID, contactID)
-	if err != nil {
-		panic(helper.NewNotFoundError("address not found"))
+	addressResponses := map[int64][]address.AddressResponse{}
+	authorizedIDs := []int64{}
+	for _, contactEntity := range service.ContactRepository.FindAllByIds(ctx, tx, contactIDs) {
+		if visible[contactEntity.AddressBookID] {
+			authorizedIDs = append(authorizedIDs, contactEntity.ID)
+			addressResponses[contactEntity.ID] = []address.AddressResponse{}
+		}
 	}
 
-	if request.Street != "" {
-		addressEntity.Street = request.Street
+	for _, addressEntity := range service.AddressRepository.FindAllByContacts(ctx, tx, authorizedIDs) {
+		addressResponses[addressEntity.ContactID] = append(addressResponses[addressEntity.ContactID], toAddressResponse(&addressEntity))
 	}
 
-	if request.City != "" {
-		addressEntity.City = request.City
-	}
+	return addressResponses
+}
 
-	if request.Province != "" {
-		addressEntity.Province = request.Province
-	}
+func (service *AddressServiceImpl) Update(ctx *fiber.Ctx, user domain.User, contactID int64, addressID int64, patch []byte, expectedVersion int64) address.AddressResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
 
-	if request.Country != "" {
-		addressEntity.Country = request.Country
-	}
+	// Verify the user may edit the contact
+	contactEntity := findAuthorizedContact(ctx, tx, service.ContactRepository, service.AddressBookMemberRepository, user, contactID, domain.PermissionEditor)
 
-	if request.PostalCode != "" {
-		addressEntity.PostalCode = request.PostalCode
+	addressEntity, err := service.AddressRepository.FindById(ctx, tx, addressID, contactID, nil)
+	if err != nil {
+		panic(helper.NewNotFoundError("address not found"))
 	}
 
-	updatedAddress := service.AddressRepository.Update(ctx, tx, addressEntity)
+	checkAddressVersion(addressEntity, expectedVersion)
 
-	return address.AddressResponse{
-		ID:         updatedAddress.ID,
-		Street:     updatedAddress.Street,
-		City:       updatedAddress.City,
-		Province:   updatedAddress.Province,
-		Country:    updatedAddress.Country,
-		PostalCode: updatedAddress.PostalCode,
+	// The merged address is validated with the same rules as a new one,
+	// except that the optional members may have been cleared
+	merged := address.AddressPatchResult{
+		Street:     addressEntity.Street,
+		City:       addressEntity.City,
+		Province:   addressEntity.Province,
+		Country:    addressEntity.Country,
+		PostalCode: addressEntity.PostalCode,
 	}
+	err = helper.ApplyMergePatch(&merged, patch)
+	helper.PanicIfError(err)
+
+	err = service.Validate.Struct(merged)
+	helper.PanicIfError(err)
+
+	request := address.AddressCreateRequest(merged)
+	return service.replace(ctx, tx, user, contactEntity, addressEntity, &request)
 }
 
-func (service *AddressServiceImpl) Delete(ctx *fiber.Ctx, user domain.User, contactID int64, addressID int64) {
+func (service *AddressServiceImpl) Replace(ctx *fiber.Ctx, user domain.User, contactID int64, addressID int64, request *address.AddressCreateRequest, expectedVersion int64) address.AddressResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
 	tx := service.DB.Begin()
 	defer helper.CommitOrRollback(tx)
 
-	// Verify contact belongs to a user
-	_, err := service.ContactRepository.FindById(ctx, tx, contactID, user.ID)
+	// Verify the user may edit the contact
+	contactEntity := findAuthorizedContact(ctx, tx, service.ContactRepository, service.AddressBookMemberRepository, user, contactID, domain.PermissionEditor)
+
+	addressEntity, err := service.AddressRepository.FindById(ctx, tx, addressID, contactID, nil)
 	if err != nil {
-		panic(helper.NewNotFoundError("contact not found"))
+		panic(helper.NewNotFoundError("address not found"))
 	}
 
-	addressEntity, err := service.AddressRepository.FindById(ctx, tx, addressID, contactID)
+	checkAddressVersion(addressEntity, expectedVersion)
+
+	return service.replace(ctx, tx, user, contactEntity, addressEntity, request)
+}
+
+// replace overwrites every editable field of the address with request, which
+// the caller validated
+func (service *AddressServiceImpl) replace(ctx *fiber.Ctx, tx *gorm.DB, user domain.User, contactEntity *domain.Contact, addressEntity *domain.Address, request *address.AddressCreateRequest) address.AddressResponse {
+	before := toAddressResponse(addressEntity)
+
+	addressEntity.Street = request.Street
+	addressEntity.City = request.City
+	addressEntity.Province = request.Province
+	addressEntity.Country = request.Country
+	addressEntity.PostalCode = request.PostalCode
+
+	updatedAddress, err := service.AddressRepository.Update(ctx, tx, addressEntity)
+	panicIfAddressConflict(err)
+	addressResponse := toAddressResponse(&updatedAddress)
+
+	recordAudit(ctx, tx, service.AuditLogRepository, user, domain.AuditActionUpdate, domain.AuditEntityAddress, updatedAddress.ID, auditChanges(before, addressResponse))
+	recordContactVersion(ctx, tx, service.ContactVersionRepository, service.AddressRepository, user, contactEntity)
+	recordEvent(ctx, tx, service.OutboxRepository, user, event.AddressUpdated{ContactID: contactEntity.ID, AddressBookID: contactEntity.AddressBookID, Address: addressResponse})
+
+	return addressResponse
+}
+
+func (service *AddressServiceImpl) Delete(ctx *fiber.Ctx, user domain.User, contactID int64, addressID int64, expectedVersion int64) {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	// Verify the user may edit the contact
+	contactEntity := findAuthorizedContact(ctx, tx, service.ContactRepository, service.AddressBookMemberRepository, user, contactID, domain.PermissionEditor)
+
+	addressEntity, err := service.AddressRepository.FindById(ctx, tx, addressID, contactID, nil)
 	if err != nil {
 		panic(helper.NewNotFoundError("address not found"))
 	}
 
+	checkAddressVersion(addressEntity, expectedVersion)
+
+	batchID := helper.GenerateBatchID()
+	addressEntity.DeletionBatchID = &batchID
+
 	err = service.AddressRepository.Delete(ctx, tx, addressEntity)
+	panicIfAddressConflict(err)
+
+	addressResponse := toAddressResponse(addressEntity)
+
+	recordAudit(ctx, tx, service.AuditLogRepository, user, domain.AuditActionDelete, domain.AuditEntityAddress, addressEntity.ID, auditChanges(addressResponse, nil))
+	recordContactVersion(ctx, tx, service.ContactVersionRepository, service.AddressRepository, user, contactEntity)
+	recordEvent(ctx, tx, service.OutboxRepository, user, event.AddressDeleted{ContactID: contactEntity.ID, AddressBookID: contactEntity.AddressBookID, Address: addressResponse})
+}
+
+// checkAddressVersion rejects the request when the client holds a stale copy
+func checkAddressVersion(addressEntity *domain.Address, expectedVersion int64) {
+	if expectedVersion != 0 && addressEntity.Version != expectedVersion {
+		panic(helper.NewPreconditionFailedError("address version does not match"))
+	}
+}
+
+func panicIfAddressConflict(err error) {
+	if errors.Is(err, repository.ErrVersionConflict) {
+		panic(helper.NewPreconditionFailedError("address was modified by another request"))
+	}
 	helper.PanicIfError(err)
 }
+
+func toAddressResponse(addressEntity *domain.Address) address.AddressResponse {
+	return address.AddressResponse{
+		ID:         addressEntity.ID,
+		Street:     addressEntity.Street,
+		City:       addressEntity.City,
+		Province:   addressEntity.Province,
+		Country:    addressEntity.Country,
+		PostalCode: addressEntity.PostalCode,
+		Version:    addressEntity.Version,
+	}
+}
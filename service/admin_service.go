@@ -0,0 +1,21 @@
+package service
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/audit"
+	"github.com/sorfian/go-contact-management-api/model/web/user"
+)
+
+// AdminService is the user management of the admin API. Every change is
+// recorded as an admin action of the admin making it.
+type AdminService interface {
+	Search(ctx *fiber.Ctx, params user.UserSearchParams) user.UserSearchResult
+	Stats(ctx *fiber.Ctx) user.UserStatsResponse
+	Disable(ctx *fiber.Ctx, admin domain.User, userID int) user.UserAdminResponse
+	Enable(ctx *fiber.Ctx, admin domain.User, userID int) user.UserAdminResponse
+	ForceLogout(ctx *fiber.Ctx, admin domain.User, userID int) user.UserAdminResponse
+	SetRole(ctx *fiber.Ctx, admin domain.User, userID int, request *user.UserRoleRequest) user.UserAdminResponse
+	ListActions(ctx *fiber.Ctx, page int, size int) user.AdminActionListResult
+	SearchAuditLogs(ctx *fiber.Ctx, params audit.AuditLogSearchParams) audit.AuditLogListResult
+}
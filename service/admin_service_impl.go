@@ -0,0 +1,213 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/audit"
+	"github.com/sorfian/go-contact-management-api/model/web/user"
+	"github.com/sorfian/go-contact-management-api/repository"
+	"gorm.io/gorm"
+)
+
+type AdminServiceImpl struct {
+	UserRepository        repository.UserRepository
+	AdminActionRepository repository.AdminActionRepository
+	AuditLogRepository    repository.AuditLogRepository
+	DB                    *gorm.DB
+	Validate              *validator.Validate
+}
+
+func NewAdminService(userRepository repository.UserRepository, adminActionRepository repository.AdminActionRepository, auditLogRepository repository.AuditLogRepository, DB *gorm.DB, validate *validator.Validate) AdminService {
+	return &AdminServiceImpl{
+		UserRepository:        userRepository,
+		AdminActionRepository: adminActionRepository,
+		AuditLogRepository:    auditLogRepository,
+		DB:                    DB,
+		Validate:              validate,
+	}
+}
+
+func (service *AdminServiceImpl) Search(ctx *fiber.Ctx, params user.UserSearchParams) user.UserSearchResult {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	offset := (params.Page - 1) * params.Size
+
+	users, totalItem := service.UserRepository.Search(ctx, tx, params, offset)
+
+	totalPage := (totalItem + params.Size - 1) / params.Size
+
+	userResponses := []user.UserAdminResponse{}
+	for _, userEntity := range users {
+		userResponses = append(userResponses, toUserAdminResponse(&userEntity))
+	}
+
+	return user.UserSearchResult{
+		Users: userResponses,
+		Paging: web.PagingResponse{
+			Page:      params.Page,
+			Size:      params.Size,
+			TotalPage: totalPage,
+			TotalItem: totalItem,
+		},
+	}
+}
+
+func (service *AdminServiceImpl) Stats(ctx *fiber.Ctx) user.UserStatsResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	return service.UserRepository.CountStats(ctx, tx)
+}
+
+// Disable stops the user from logging in and ends their session
+func (service *AdminServiceImpl) Disable(ctx *fiber.Ctx, admin domain.User, userID int) user.UserAdminResponse {
+	if userID == admin.ID {
+		panic(helper.NewBadRequestError("admins cannot disable their own account"))
+	}
+
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	userEntity := service.findUser(ctx, tx, userID)
+	if userEntity.DisabledAt == nil {
+		disabledAt := time.Now()
+		userEntity.DisabledAt = &disabledAt
+	}
+	userEntity.Token = ""
+	userEntity.TokenExp = 0
+
+	service.UserRepository.Update(ctx, tx, userEntity)
+	service.record(ctx, tx, admin, userEntity, domain.AdminActionDisable, "")
+
+	return toUserAdminResponse(userEntity)
+}
+
+func (service *AdminServiceImpl) Enable(ctx *fiber.Ctx, admin domain.User, userID int) user.UserAdminResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	userEntity := service.findUser(ctx, tx, userID)
+	userEntity.DisabledAt = nil
+
+	service.UserRepository.Update(ctx, tx, userEntity)
+	service.record(ctx, tx, admin, userEntity, domain.AdminActionEnable, "")
+
+	return toUserAdminResponse(userEntity)
+}
+
+func (service *AdminServiceImpl) ForceLogout(ctx *fiber.Ctx, admin domain.User, userID int) user.UserAdminResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	userEntity := service.findUser(ctx, tx, userID)
+	userEntity.Token = ""
+	userEntity.TokenExp = 0
+
+	service.UserRepository.Update(ctx, tx, userEntity)
+	service.record(ctx, tx, admin, userEntity, domain.AdminActionForceLogout, "")
+
+	return toUserAdminResponse(userEntity)
+}
+
+func (service *AdminServiceImpl) SetRole(ctx *fiber.Ctx, admin domain.User, userID int, request *user.UserRoleRequest) user.UserAdminResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	// Keeps the last admin from locking everyone out of the admin API
+	if userID == admin.ID {
+		panic(helper.NewBadRequestError("admins cannot change their own role"))
+	}
+
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	userEntity := service.findUser(ctx, tx, userID)
+	details := fmt.Sprintf("%s -> %s", userEntity.Role, request.Role)
+	userEntity.Role = request.Role
+
+	service.UserRepository.Update(ctx, tx, userEntity)
+	service.record(ctx, tx, admin, userEntity, domain.AdminActionSetRole, details)
+
+	return toUserAdminResponse(userEntity)
+}
+
+func (service *AdminServiceImpl) ListActions(ctx *fiber.Ctx, page int, size int) user.AdminActionListResult {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	adminActions, totalItem := service.AdminActionRepository.FindAll(ctx, tx, (page-1)*size, size)
+
+	totalPage := (totalItem + size - 1) / size
+
+	actionResponses := []user.AdminActionResponse{}
+	for _, adminAction := range adminActions {
+		actionResponses = append(actionResponses, user.AdminActionResponse{
+			ID:           adminAction.ID,
+			AdminID:      adminAction.AdminID,
+			TargetUserID: adminAction.TargetUserID,
+			Action:       adminAction.Action,
+			Details:      adminAction.Details,
+			CreatedAt:    adminAction.CreatedAt,
+		})
+	}
+
+	return user.AdminActionListResult{
+		Actions: actionResponses,
+		Paging: web.PagingResponse{
+			Page:      page,
+			Size:      size,
+			TotalPage: totalPage,
+			TotalItem: totalItem,
+		},
+	}
+}
+
+// SearchAuditLogs lists the audit logs of every organization
+func (service *AdminServiceImpl) SearchAuditLogs(ctx *fiber.Ctx, params audit.AuditLogSearchParams) audit.AuditLogListResult {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	auditLogs, totalItem := service.AuditLogRepository.Search(ctx, tx, params, (params.Page-1)*params.Size)
+
+	return toAuditLogListResult(auditLogs, params.Page, params.Size, totalItem)
+}
+
+func (service *AdminServiceImpl) findUser(ctx *fiber.Ctx, tx *gorm.DB, userID int) *domain.User {
+	userEntity, err := service.UserRepository.FindById(ctx, tx, userID)
+	if err != nil {
+		panic(helper.NewNotFoundError("user not found"))
+	}
+	return userEntity
+}
+
+func (service *AdminServiceImpl) record(ctx *fiber.Ctx, tx *gorm.DB, admin domain.User, target *domain.User, action string, details string) {
+	service.AdminActionRepository.Create(ctx, tx, domain.AdminAction{
+		AdminID:      &admin.ID,
+		TargetUserID: target.ID,
+		Action:       action,
+		Details:      details,
+	})
+}
+
+func toUserAdminResponse(userEntity *domain.User) user.UserAdminResponse {
+	userResponse := user.UserAdminResponse{
+		ID:         userEntity.ID,
+		Username:   userEntity.Username,
+		Name:       userEntity.Name,
+		Role:       userEntity.Role,
+		TokenExp:   userEntity.TokenExp,
+		DisabledAt: userEntity.DisabledAt,
+		CreatedAt:  userEntity.CreatedAt,
+	}
+	if userEntity.DeletedAt.Valid {
+		userResponse.DeletedAt = &userEntity.DeletedAt.Time
+	}
+	return userResponse
+}
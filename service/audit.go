@@ -0,0 +1,119 @@
+package service
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/audit"
+	"github.com/sorfian/go-contact-management-api/repository"
+	"gorm.io/gorm"
+)
+
+// maxAuditRequestIDLength is the size of audit_logs.request_id, clients may
+// send their own X-Request-ID
+const maxAuditRequestIDLength = 100
+
+// redactedAuditValue stands in for secrets, the log only shows they changed
+const redactedAuditValue = "[redacted]"
+
+// recordAudit writes the audit log of a change inside tx, so the log commits
+// or rolls back together with the change
+func recordAudit(ctx *fiber.Ctx, tx *gorm.DB, auditLogRepository repository.AuditLogRepository, actor domain.User, action string, entityType string, entityID int64, changes map[string]domain.AuditChange) {
+	requestID, _ := ctx.Locals("requestid").(string)
+	if len(requestID) > maxAuditRequestIDLength {
+		requestID = requestID[:maxAuditRequestIDLength]
+	}
+
+	auditLogRepository.Create(ctx, tx, domain.AuditLog{
+		ActorID:    &actor.ID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    changes,
+		RequestID:  requestID,
+		IP:         ctx.IP(),
+	})
+}
+
+// auditChanges compares the JSON fields of two responses of an entity and
+// returns the fields that differ. before is nil for a create and after is nil
+// for a delete. The ID and version are left out, they are not edited.
+func auditChanges(before interface{}, after interface{}) map[string]domain.AuditChange {
+	beforeFields := auditFields(before)
+	afterFields := auditFields(after)
+
+	changes := map[string]domain.AuditChange{}
+	for name, value := range beforeFields {
+		if !reflect.DeepEqual(value, afterFields[name]) {
+			changes[name] = domain.AuditChange{Before: value, After: afterFields[name]}
+		}
+	}
+	for name, value := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			changes[name] = domain.AuditChange{After: value}
+		}
+	}
+
+	return changes
+}
+
+// auditFields returns the JSON fields of value. Members of nested objects
+// are named by their path, like photo.small, so every change is a plain value.
+func auditFields(value interface{}) map[string]interface{} {
+	fields := map[string]interface{}{}
+	if value == nil {
+		return fields
+	}
+
+	payload, err := json.Marshal(value)
+	helper.PanicIfError(err)
+	object := map[string]interface{}{}
+	helper.PanicIfError(json.Unmarshal(payload, &object))
+
+	delete(object, "id")
+	delete(object, "version")
+	addAuditFields(fields, "", object)
+	return fields
+}
+
+func addAuditFields(fields map[string]interface{}, prefix string, object map[string]interface{}) {
+	for name, value := range object {
+		if nested, ok := value.(map[string]interface{}); ok {
+			addAuditFields(fields, prefix+name+".", nested)
+			continue
+		}
+		fields[prefix+name] = value
+	}
+}
+
+func toAuditLogListResult(auditLogs []domain.AuditLog, page int, size int, totalItem int) audit.AuditLogListResult {
+	logResponses := []audit.AuditLogResponse{}
+	for _, auditLog := range auditLogs {
+		logResponses = append(logResponses, audit.AuditLogResponse{
+			ID:             auditLog.ID,
+			OrganizationID: auditLog.OrganizationID,
+			ActorID:        auditLog.ActorID,
+			Action:         auditLog.Action,
+			EntityType:     auditLog.EntityType,
+			EntityID:       auditLog.EntityID,
+			Changes:        auditLog.Changes,
+			RequestID:      auditLog.RequestID,
+			IP:             auditLog.IP,
+			CreatedAt:      auditLog.CreatedAt,
+		})
+	}
+
+	return audit.AuditLogListResult{
+		Logs: logResponses,
+		Paging: web.PagingResponse{
+			Page:      page,
+			Size:      size,
+			TotalPage: (totalItem + size - 1) / size,
+			TotalItem: totalItem,
+		},
+	}
+}
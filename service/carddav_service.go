@@ -0,0 +1,18 @@
+package service
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/carddav"
+)
+
+type CardDAVService interface {
+	AddressBooks(ctx *fiber.Ctx, user domain.User) []carddav.AddressBook
+	AddressBook(ctx *fiber.Ctx, user domain.User, addressBookID int64) carddav.AddressBook
+	Cards(ctx *fiber.Ctx, user domain.User, addressBookID int64, filter *carddav.Filter) []carddav.Card
+	FindCards(ctx *fiber.Ctx, user domain.User, addressBookID int64, names []string) []carddav.Card
+	Card(ctx *fiber.Ctx, user domain.User, addressBookID int64, name string) carddav.Card
+	PutCard(ctx *fiber.Ctx, user domain.User, addressBookID int64, name string, data []byte, ifMatch string, ifNoneMatch string) bool
+	DeleteCard(ctx *fiber.Ctx, user domain.User, addressBookID int64, name string, ifMatch string)
+	Changes(ctx *fiber.Ctx, user domain.User, addressBookID int64, token string) carddav.Changes
+}
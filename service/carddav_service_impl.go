@@ -0,0 +1,498 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/blob"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/address"
+	"github.com/sorfian/go-contact-management-api/model/web/carddav"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+	"github.com/sorfian/go-contact-management-api/repository"
+	"gorm.io/gorm"
+)
+
+type CardDAVServiceImpl struct {
+	ContactService               ContactService
+	AddressService               AddressService
+	ContactRepository            repository.ContactRepository
+	AddressRepository            repository.AddressRepository
+	AddressBookRepository        repository.AddressBookRepository
+	AddressBookMemberRepository  repository.AddressBookMemberRepository
+	OrganizationMemberRepository repository.OrganizationMemberRepository
+	BlobStore                    blob.BlobStore
+	DB                           *gorm.DB
+	Validate                     *validator.Validate
+}
+
+func NewCardDAVService(contactService ContactService, addressService AddressService, contactRepository repository.ContactRepository, addressRepository repository.AddressRepository, addressBookRepository repository.AddressBookRepository, addressBookMemberRepository repository.AddressBookMemberRepository, organizationMemberRepository repository.OrganizationMemberRepository, blobStore blob.BlobStore, DB *gorm.DB, validate *validator.Validate) CardDAVService {
+	return &CardDAVServiceImpl{
+		ContactService:               contactService,
+		AddressService:               addressService,
+		ContactRepository:            contactRepository,
+		AddressRepository:            addressRepository,
+		AddressBookRepository:        addressBookRepository,
+		AddressBookMemberRepository:  addressBookMemberRepository,
+		OrganizationMemberRepository: organizationMemberRepository,
+		BlobStore:                    blobStore,
+		DB:                           DB,
+		Validate:                     validate,
+	}
+}
+
+// AddressBooks returns the address books of the user in every organization
+// they belong to. Users without an address book in the organization of the
+// request get one, for the cards they create to go in.
+func (service *CardDAVServiceImpl) AddressBooks(ctx *fiber.Ctx, user domain.User) []carddav.AddressBook {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	defaultAddressBook(ctx, tx, service.AddressBookRepository, service.AddressBookMemberRepository, user)
+
+	// Each organization is read as if the request ran in it
+	defer ctx.Locals("organization", ctx.Locals("organization"))
+
+	token := encodeSyncToken(time.Now().Add(-SyncGracePeriod))
+	organizationMembers := service.OrganizationMemberRepository.FindAllByUser(ctx, tx, user.ID)
+
+	addressBooks := []carddav.AddressBook{}
+	for i := range organizationMembers {
+		organization := &organizationMembers[i].Organization
+		ctx.Locals("organization", organization)
+
+		for _, member := range service.AddressBookMemberRepository.FindAllByUser(ctx, tx, user.ID) {
+			addressBook := toCardDAVAddressBook(&member, token)
+			// Address books of different organizations may share a name
+			if len(organizationMembers) > 1 {
+				addressBook.Name += " (" + organization.Name + ")"
+			}
+			addressBooks = append(addressBooks, addressBook)
+		}
+	}
+
+	return addressBooks
+}
+
+func (service *CardDAVServiceImpl) AddressBook(ctx *fiber.Ctx, user domain.User, addressBookID int64) carddav.AddressBook {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	member := requireAddressBookPermission(ctx, tx, service.AddressBookMemberRepository, addressBookID, user.ID, domain.PermissionViewer)
+
+	return toCardDAVAddressBook(member, encodeSyncToken(time.Now().Add(-SyncGracePeriod)))
+}
+
+// Cards returns the cards of the address book that pass the filter of an
+// addressbook-query, all of them without one
+func (service *CardDAVServiceImpl) Cards(ctx *fiber.Ctx, user domain.User, addressBookID int64, filter *carddav.Filter) []carddav.Card {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	requireAddressBookPermission(ctx, tx, service.AddressBookMemberRepository, addressBookID, user.ID, domain.PermissionViewer)
+
+	contacts := service.ContactRepository.FindAllByAddressBooks(ctx, tx, []int64{addressBookID})
+	vCards := service.vCards(ctx, tx, contacts)
+
+	cards := []carddav.Card{}
+	for i := range contacts {
+		if matchesCardFilter(vCards[i], filter) {
+			cards = append(cards, toCard(&contacts[i], vCards[i]))
+		}
+	}
+	return cards
+}
+
+// FindCards returns the cards of the address book with the names, leaving
+// out the names it has no card of
+func (service *CardDAVServiceImpl) FindCards(ctx *fiber.Ctx, user domain.User, addressBookID int64, names []string) []carddav.Card {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	requireAddressBookPermission(ctx, tx, service.AddressBookMemberRepository, addressBookID, user.ID, domain.PermissionViewer)
+
+	contacts := []domain.Contact{}
+	for _, name := range names {
+		contactEntity, err := service.ContactRepository.FindByVCardName(ctx, tx, addressBookID, name, cardContactID(name))
+		if err == nil {
+			contacts = append(contacts, *contactEntity)
+		}
+	}
+
+	return toCards(contacts, service.vCards(ctx, tx, contacts))
+}
+
+func (service *CardDAVServiceImpl) Card(ctx *fiber.Ctx, user domain.User, addressBookID int64, name string) carddav.Card {
+	contactEntity, addresses := service.findCard(ctx, user, addressBookID, name, domain.PermissionViewer)
+	if contactEntity == nil {
+		panic(helper.NewNotFoundError("card not found"))
+	}
+
+	return toCard(contactEntity, toVCard(contactEntity, addresses, service.photo(ctx, contactEntity)))
+}
+
+// PutCard creates or replaces the card of the name, and reports whether it
+// created it. The contact and its addresses are changed through the contact
+// and address services, one change at a time, so they are audited and
+// versioned like any other. Properties of the vCard a contact does not keep
+// are dropped.
+func (service *CardDAVServiceImpl) PutCard(ctx *fiber.Ctx, user domain.User, addressBookID int64, name string, data []byte, ifMatch string, ifNoneMatch string) bool {
+	vCard, err := helper.DecodeVCard(data)
+	if err != nil {
+		panic(helper.NewBadRequestError(err.Error()))
+	}
+
+	request := contact.ContactCreateRequest{
+		AddressBookID: addressBookID,
+		FirstName:     vCard.FirstName,
+		LastName:      vCard.LastName,
+		Email:         vCard.Email,
+		Phone:         vCard.Phone,
+		VCardName:     name,
+		VCardUID:      vCard.UID,
+	}
+	err = service.Validate.Struct(&request)
+	helper.PanicIfError(err)
+
+	addressRequests := make([]address.AddressCreateRequest, 0, len(vCard.Addresses))
+	for _, vCardAddress := range vCard.Addresses {
+		addressRequest := address.AddressCreateRequest{
+			Street:     vCardAddress.Street,
+			City:       vCardAddress.City,
+			Province:   vCardAddress.Province,
+			Country:    vCardAddress.Country,
+			PostalCode: vCardAddress.PostalCode,
+		}
+		err = service.Validate.Struct(&addressRequest)
+		helper.PanicIfError(err)
+		addressRequests = append(addressRequests, addressRequest)
+	}
+
+	contactEntity, addresses := service.findCard(ctx, user, addressBookID, name, domain.PermissionEditor)
+	if contactEntity == nil {
+		if ifMatch != "" {
+			panic(helper.NewPreconditionFailedError("If-Match does not match the card"))
+		}
+
+		createdContact := service.ContactService.Create(ctx, user, &request)
+		for i := range addressRequests {
+			service.AddressService.Create(ctx, user, createdContact.ID, &addressRequests[i])
+		}
+		return true
+	}
+
+	etag := toCard(contactEntity, toVCard(contactEntity, addresses, service.photo(ctx, contactEntity))).ETag
+	if helper.MatchesETag(ifNoneMatch, etag) || (ifMatch != "" && !helper.MatchesETag(ifMatch, etag)) {
+		panic(helper.NewPreconditionFailedError("If-Match does not match the card"))
+	}
+
+	if contactEntity.FirstName != request.FirstName || contactEntity.LastName != request.LastName || contactEntity.Email != request.Email || contactEntity.Phone != request.Phone {
+		service.ContactService.Replace(ctx, user, contactEntity.ID, &request, contactEntity.Version)
+	}
+
+	// Addresses are matched up in order, the card has no IDs for them
+	for i, addressEntity := range addresses {
+		switch {
+		case i >= len(addressRequests):
+			service.AddressService.Delete(ctx, user, contactEntity.ID, addressEntity.ID, addressEntity.Version)
+		case addressRequests[i] != toAddressCreateRequest(toAddressSnapshot(&addressEntity)):
+			service.AddressService.Replace(ctx, user, contactEntity.ID, addressEntity.ID, &addressRequests[i], addressEntity.Version)
+		}
+	}
+	for i := len(addresses); i < len(addressRequests); i++ {
+		service.AddressService.Create(ctx, user, contactEntity.ID, &addressRequests[i])
+	}
+
+	return false
+}
+
+func (service *CardDAVServiceImpl) DeleteCard(ctx *fiber.Ctx, user domain.User, addressBookID int64, name string, ifMatch string) {
+	contactEntity, addresses := service.findCard(ctx, user, addressBookID, name, domain.PermissionEditor)
+	if contactEntity == nil {
+		panic(helper.NewNotFoundError("card not found"))
+	}
+
+	if ifMatch != "" && !helper.MatchesETag(ifMatch, toCard(contactEntity, toVCard(contactEntity, addresses, service.photo(ctx, contactEntity))).ETag) {
+		panic(helper.NewPreconditionFailedError("If-Match does not match the card"))
+	}
+
+	service.ContactService.Delete(ctx, user, contactEntity.ID, contactEntity.Version)
+}
+
+// Changes returns the cards of the address book changed since the token of
+// a sync-collection, or all of them without one or when the user joined the
+// address book since. Like the delta sync, a contact moved to another
+// address book is removed the same as a deleted one.
+func (service *CardDAVServiceImpl) Changes(ctx *fiber.Ctx, user domain.User, addressBookID int64, token string) carddav.Changes {
+	var since time.Time
+	if token != "" {
+		var ok bool
+		since, ok = parseSyncToken(token)
+		if !ok {
+			panic(helper.NewForbiddenError("sync token is not valid"))
+		}
+	}
+	now := time.Now()
+
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	member := requireAddressBookPermission(ctx, tx, service.AddressBookMemberRepository, addressBookID, user.ID, domain.PermissionViewer)
+
+	changes := carddav.Changes{
+		Token:   encodeSyncToken(now.Add(-SyncGracePeriod)),
+		Removed: []string{},
+	}
+
+	var contacts []domain.Contact
+	if since.IsZero() || !member.CreatedAt.Before(since) {
+		contacts = service.ContactRepository.FindAllByAddressBooks(ctx, tx, []int64{addressBookID})
+	} else {
+		changed := map[int64]bool{}
+		for _, contactEntity := range service.ContactRepository.FindChangedSince(ctx, tx, []int64{addressBookID}, since) {
+			if contactEntity.DeletedAt.Valid || contactEntity.AddressBookID != addressBookID {
+				changes.Removed = append(changes.Removed, cardName(&contactEntity))
+				continue
+			}
+			changed[contactEntity.ID] = true
+			contacts = append(contacts, contactEntity)
+		}
+
+		// A changed address changes the card of its contact
+		for _, addressEntity := range service.AddressRepository.FindChangedSince(ctx, tx, []int64{addressBookID}, since) {
+			contactEntity := addressEntity.Contact
+			if changed[contactEntity.ID] || contactEntity.DeletedAt.Valid || contactEntity.AddressBookID != addressBookID {
+				continue
+			}
+			changed[contactEntity.ID] = true
+			contacts = append(contacts, contactEntity)
+		}
+	}
+
+	changes.Cards = toCards(contacts, service.vCards(ctx, tx, contacts))
+	return changes
+}
+
+// findCard returns the contact of the card of the name with its addresses,
+// nil when the address book has no such card
+func (service *CardDAVServiceImpl) findCard(ctx *fiber.Ctx, user domain.User, addressBookID int64, name string, permission string) (*domain.Contact, []domain.Address) {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	requireAddressBookPermission(ctx, tx, service.AddressBookMemberRepository, addressBookID, user.ID, permission)
+
+	contactEntity, err := service.ContactRepository.FindByVCardName(ctx, tx, addressBookID, name, cardContactID(name))
+	if err != nil {
+		return nil, nil
+	}
+
+	return contactEntity, service.AddressRepository.FindAllByContacts(ctx, tx, []int64{contactEntity.ID})
+}
+
+// vCards returns the vCards of the contacts in the same order
+func (service *CardDAVServiceImpl) vCards(ctx *fiber.Ctx, tx *gorm.DB, contacts []domain.Contact) []helper.VCard {
+	contactIDs := make([]int64, 0, len(contacts))
+	for _, contactEntity := range contacts {
+		contactIDs = append(contactIDs, contactEntity.ID)
+	}
+
+	addresses := map[int64][]domain.Address{}
+	for _, addressEntity := range service.AddressRepository.FindAllByContacts(ctx, tx, contactIDs) {
+		addresses[addressEntity.ContactID] = append(addresses[addressEntity.ContactID], addressEntity)
+	}
+
+	vCards := make([]helper.VCard, 0, len(contacts))
+	for i := range contacts {
+		vCards = append(vCards, toVCard(&contacts[i], addresses[contacts[i].ID], service.photo(ctx, &contacts[i])))
+	}
+	return vCards
+}
+
+// photo returns the medium thumbnail of the photo of the contact, nil when it
+// has none
+func (service *CardDAVServiceImpl) photo(ctx *fiber.Ctx, contactEntity *domain.Contact) []byte {
+	if contactEntity.PhotoKey == nil {
+		return nil
+	}
+
+	data, err := service.BlobStore.Get(ctx.UserContext(), photoBlobKey(*contactEntity.PhotoKey, "medium"))
+	if errors.Is(err, blob.ErrNotFound) {
+		return nil
+	}
+	helper.PanicIfError(err)
+	return data
+}
+
+// cardName returns the name of the card of a contact. Contacts a CardDAV
+// client did not create are named by their ID.
+func cardName(contactEntity *domain.Contact) string {
+	if contactEntity.VCardName != nil {
+		return *contactEntity.VCardName
+	}
+	return strconv.FormatInt(contactEntity.ID, 10) + ".vcf"
+}
+
+// cardContactID returns the ID of the contact a card name names when it is
+// the name of a contact no CardDAV client created, and 0 otherwise
+func cardContactID(name string) int64 {
+	id, err := strconv.ParseInt(strings.TrimSuffix(name, ".vcf"), 10, 64)
+	if err != nil || strconv.FormatInt(id, 10)+".vcf" != name {
+		return 0
+	}
+	return id
+}
+
+func toVCard(contactEntity *domain.Contact, addresses []domain.Address, photo []byte) helper.VCard {
+	vCard := helper.VCard{
+		UID:       "contact-" + strconv.FormatInt(contactEntity.ID, 10),
+		FirstName: contactEntity.FirstName,
+		LastName:  contactEntity.LastName,
+		Email:     contactEntity.Email,
+		Phone:     contactEntity.Phone,
+		Photo:     photo,
+	}
+	if contactEntity.VCardUID != nil && *contactEntity.VCardUID != "" {
+		vCard.UID = *contactEntity.VCardUID
+	}
+
+	for _, addressEntity := range addresses {
+		vCard.Addresses = append(vCard.Addresses, helper.VCardAddress{
+			Street:     addressEntity.Street,
+			City:       addressEntity.City,
+			Province:   addressEntity.Province,
+			PostalCode: addressEntity.PostalCode,
+			Country:    addressEntity.Country,
+		})
+	}
+	return vCard
+}
+
+// toCard renders the vCard of a contact. Its entity tag is a hash of the
+// data, so it changes with anything the card shows.
+func toCard(contactEntity *domain.Contact, vCard helper.VCard) carddav.Card {
+	data := helper.EncodeVCard(vCard)
+	sum := sha256.Sum256(data)
+	return carddav.Card{
+		Name: cardName(contactEntity),
+		ETag: `"` + hex.EncodeToString(sum[:16]) + `"`,
+		Data: data,
+	}
+}
+
+func toCards(contacts []domain.Contact, vCards []helper.VCard) []carddav.Card {
+	cards := make([]carddav.Card, 0, len(contacts))
+	for i := range contacts {
+		cards = append(cards, toCard(&contacts[i], vCards[i]))
+	}
+	return cards
+}
+
+func toCardDAVAddressBook(member *domain.AddressBookMember, token string) carddav.AddressBook {
+	return carddav.AddressBook{
+		OrganizationID: member.AddressBook.OrganizationID,
+		ID:             member.AddressBookID,
+		Name:           member.AddressBook.Name,
+		Writable:       member.Allows(domain.PermissionEditor),
+		SyncToken:      token,
+	}
+}
+
+// matchesCardFilter reports whether a vCard passes the filter of an
+// addressbook-query. Only the properties a contact keeps are defined.
+func matchesCardFilter(vCard helper.VCard, filter *carddav.Filter) bool {
+	if filter == nil || len(filter.PropFilters) == 0 {
+		return true
+	}
+
+	allOf := filter.Test == "allof"
+	for _, propFilter := range filter.PropFilters {
+		if matchesPropFilter(vCard, propFilter) != allOf {
+			return !allOf
+		}
+	}
+	return allOf
+}
+
+func matchesPropFilter(vCard helper.VCard, propFilter carddav.PropFilter) bool {
+	values := vCardValues(vCard, propFilter.Name)
+	if propFilter.IsNotDefined != nil {
+		return len(values) == 0
+	}
+	if len(propFilter.TextMatches) == 0 {
+		return len(values) > 0
+	}
+
+	allOf := propFilter.Test == "allof"
+	for _, textMatch := range propFilter.TextMatches {
+		matched := false
+		for _, value := range values {
+			if matchesText(value, textMatch) {
+				matched = true
+				break
+			}
+		}
+		if matched != allOf {
+			return !allOf
+		}
+	}
+	return allOf
+}
+
+// matchesText matches a value the way a text-match asks, case insensitive
+// unless the collation is i;octet
+func matchesText(value string, textMatch carddav.TextMatch) bool {
+	text := textMatch.Text
+	if textMatch.Collation != "i;octet" {
+		value = strings.ToLower(value)
+		text = strings.ToLower(text)
+	}
+
+	var matched bool
+	switch textMatch.MatchType {
+	case "equals":
+		matched = value == text
+	case "starts-with":
+		matched = strings.HasPrefix(value, text)
+	case "ends-with":
+		matched = strings.HasSuffix(value, text)
+	default:
+		matched = strings.Contains(value, text)
+	}
+	return matched != (textMatch.NegateCondition == "yes")
+}
+
+// vCardValues returns the values of a property of the vCard
+func vCardValues(vCard helper.VCard, name string) []string {
+	var values []string
+	switch strings.ToUpper(name) {
+	case "UID":
+		values = []string{vCard.UID}
+	case "FN":
+		values = []string{strings.TrimSpace(vCard.FirstName + " " + vCard.LastName)}
+	case "N":
+		values = []string{vCard.LastName + ";" + vCard.FirstName}
+	case "EMAIL":
+		values = []string{vCard.Email}
+	case "TEL":
+		values = []string{vCard.Phone}
+	case "ADR":
+		for _, vCardAddress := range vCard.Addresses {
+			values = append(values, strings.Join([]string{"", "", vCardAddress.Street, vCardAddress.City, vCardAddress.Province, vCardAddress.PostalCode, vCardAddress.Country}, ";"))
+		}
+	}
+
+	defined := values[:0]
+	for _, value := range values {
+		if value != "" && value != ";" {
+			defined = append(defined, value)
+		}
+	}
+	return defined
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/collaboration"
+)
+
+// CollaborationService keeps track of who views which contact over the
+// collaboration connections of every API process
+type CollaborationService interface {
+	Join(ctx *fiber.Ctx, user domain.User, connectionID string, contactID int64)
+	Leave(ctx *fiber.Ctx, connectionID string, contactID int64)
+	KeepAlive(ctx *fiber.Ctx, connectionID string)
+	Disconnect(ctx *fiber.Ctx, connectionID string)
+	Viewers(ctx *fiber.Ctx, user domain.User, contactIDs []int64) map[int64][]collaboration.ViewerResponse
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/collaboration"
+	"github.com/sorfian/go-contact-management-api/repository"
+	"gorm.io/gorm"
+)
+
+// ContactViewerTTL is how long a viewer counts without its connection keeping
+// it alive, connections do so well within it
+const ContactViewerTTL = 30 * time.Second
+
+type CollaborationServiceImpl struct {
+	ContactRepository           repository.ContactRepository
+	AddressBookMemberRepository repository.AddressBookMemberRepository
+	ContactViewerRepository     repository.ContactViewerRepository
+	DB                          *gorm.DB
+}
+
+func NewCollaborationService(contactRepository repository.ContactRepository, addressBookMemberRepository repository.AddressBookMemberRepository, contactViewerRepository repository.ContactViewerRepository, DB *gorm.DB) CollaborationService {
+	return &CollaborationServiceImpl{
+		ContactRepository:           contactRepository,
+		AddressBookMemberRepository: addressBookMemberRepository,
+		ContactViewerRepository:     contactViewerRepository,
+		DB:                          DB,
+	}
+}
+
+// Join makes the user a viewer of the contact over the connection
+func (service *CollaborationServiceImpl) Join(ctx *fiber.Ctx, user domain.User, connectionID string, contactID int64) {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	findAuthorizedContact(ctx, tx, service.ContactRepository, service.AddressBookMemberRepository, user, contactID, domain.PermissionViewer)
+
+	service.ContactViewerRepository.Save(ctx, tx, domain.ContactViewer{
+		ContactID:    contactID,
+		UserID:       user.ID,
+		ConnectionID: connectionID,
+		ExpiresAt:    time.Now().Add(ContactViewerTTL),
+	})
+}
+
+// Leave removes the user from the viewers of the contact on the connection
+func (service *CollaborationServiceImpl) Leave(ctx *fiber.Ctx, connectionID string, contactID int64) {
+	service.ContactViewerRepository.Delete(ctx, service.DB, connectionID, contactID)
+}
+
+// KeepAlive keeps the user a viewer of the contacts the connection views
+func (service *CollaborationServiceImpl) KeepAlive(ctx *fiber.Ctx, connectionID string) {
+	service.ContactViewerRepository.Extend(ctx, service.DB, connectionID, time.Now().Add(ContactViewerTTL))
+}
+
+// Disconnect removes the user from the viewers of every contact the
+// connection viewed
+func (service *CollaborationServiceImpl) Disconnect(ctx *fiber.Ctx, connectionID string) {
+	service.ContactViewerRepository.DeleteAll(ctx, service.DB, connectionID)
+}
+
+// Viewers returns for each contact the other users viewing it, once each
+// however many connections they have open
+func (service *CollaborationServiceImpl) Viewers(ctx *fiber.Ctx, user domain.User, contactIDs []int64) map[int64][]collaboration.ViewerResponse {
+	viewers := map[int64][]collaboration.ViewerResponse{}
+	if len(contactIDs) == 0 {
+		return viewers
+	}
+
+	seen := map[int64]map[int]bool{}
+	for _, contactID := range contactIDs {
+		viewers[contactID] = []collaboration.ViewerResponse{}
+		seen[contactID] = map[int]bool{user.ID: true}
+	}
+
+	for _, viewer := range service.ContactViewerRepository.FindActive(ctx, service.DB, contactIDs, time.Now()) {
+		if seen[viewer.ContactID][viewer.UserID] || viewer.User.ID == 0 {
+			continue
+		}
+		seen[viewer.ContactID][viewer.UserID] = true
+
+		viewers[viewer.ContactID] = append(viewers[viewer.ContactID], collaboration.ViewerResponse{
+			UserID:   viewer.User.ID,
+			Username: viewer.User.Username,
+			Name:     viewer.User.Name,
+		})
+	}
+
+	return viewers
+}
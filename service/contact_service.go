@@ -3,13 +3,20 @@ package service
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/audit"
 	"github.com/sorfian/go-contact-management-api/model/web/contact"
 )
 
 type ContactService interface {
 	Create(ctx *fiber.Ctx, user domain.User, request *contact.ContactCreateRequest) contact.ContactResponse
-	Get(ctx *fiber.Ctx, user domain.User, contactID int64) contact.ContactResponse
+	Get(ctx *fiber.Ctx, user domain.User, contactID int64, include []string, fields []string) contact.ContactResponse
 	GetAll(ctx *fiber.Ctx, user domain.User, param contact.SearchParams) contact.SearchResult
-	Update(ctx *fiber.Ctx, user domain.User, contactID int64, request contact.ContactUpdateRequest) contact.ContactResponse
-	Delete(ctx *fiber.Ctx, user domain.User, contactID int64)
+	Update(ctx *fiber.Ctx, user domain.User, contactID int64, patch []byte, expectedVersion int64) contact.ContactResponse
+	Replace(ctx *fiber.Ctx, user domain.User, contactID int64, request *contact.ContactCreateRequest, expectedVersion int64) contact.ContactResponse
+	Delete(ctx *fiber.Ctx, user domain.User, contactID int64, expectedVersion int64)
+	Bulk(ctx *fiber.Ctx, user domain.User, request *contact.BulkRequest) contact.BulkResult
+	GetHistory(ctx *fiber.Ctx, user domain.User, contactID int64, page int, size int) audit.AuditLogListResult
+	GetVersions(ctx *fiber.Ctx, user domain.User, contactID int64, page int, size int) contact.ContactVersionListResult
+	GetVersion(ctx *fiber.Ctx, user domain.User, contactID int64, number int) contact.ContactVersionResponse
+	Revert(ctx *fiber.Ctx, user domain.User, contactID int64, number int, expectedVersion int64) contact.ContactResponse
 }
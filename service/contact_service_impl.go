@@ -1,24 +1,51 @@
 package service
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/event"
 	"github.com/sorfian/go-contact-management-api/helper"
 	"github.com/sorfian/go-contact-management-api/model/domain"
 	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/address"
+	"github.com/sorfian/go-contact-management-api/model/web/audit"
 	"github.com/sorfian/go-contact-management-api/model/web/contact"
 	"github.com/sorfian/go-contact-management-api/repository"
 	"gorm.io/gorm"
 )
 
 type ContactServiceImpl struct {
-	ContactRepository repository.ContactRepository
-	DB                *gorm.DB
-	Validate          *validator.Validate
+	ContactRepository           repository.ContactRepository
+	AddressRepository           repository.AddressRepository
+	AddressBookRepository       repository.AddressBookRepository
+	AddressBookMemberRepository repository.AddressBookMemberRepository
+	AuditLogRepository          repository.AuditLogRepository
+	ContactVersionRepository    repository.ContactVersionRepository
+	ContactMoveRepository       repository.ContactMoveRepository
+	OutboxRepository            repository.OutboxRepository
+	DB                          *gorm.DB
+	Validate                    *validator.Validate
+	PhotoOptions                PhotoOptions
 }
 
-func NewContactService(contactRepository repository.ContactRepository, DB *gorm.DB, validate *validator.Validate) ContactService {
-	return &ContactServiceImpl{ContactRepository: contactRepository, DB: DB, Validate: validate}
+func NewContactService(contactRepository repository.ContactRepository, addressRepository repository.AddressRepository, addressBookRepository repository.AddressBookRepository, addressBookMemberRepository repository.AddressBookMemberRepository, auditLogRepository repository.AuditLogRepository, contactVersionRepository repository.ContactVersionRepository, contactMoveRepository repository.ContactMoveRepository, outboxRepository repository.OutboxRepository, DB *gorm.DB, validate *validator.Validate, photoOptions PhotoOptions) ContactService {
+	return &ContactServiceImpl{
+		ContactRepository:           contactRepository,
+		AddressRepository:           addressRepository,
+		AddressBookRepository:       addressBookRepository,
+		AddressBookMemberRepository: addressBookMemberRepository,
+		AuditLogRepository:          auditLogRepository,
+		ContactVersionRepository:    contactVersionRepository,
+		ContactMoveRepository:       contactMoveRepository,
+		OutboxRepository:            outboxRepository,
+		DB:                          DB,
+		Validate:                    validate,
+		PhotoOptions:                photoOptions,
+	}
 }
 
 func (service *ContactServiceImpl) Create(ctx *fiber.Ctx, user domain.User, request *contact.ContactCreateRequest) contact.ContactResponse {
@@ -28,64 +55,42 @@ func (service *ContactServiceImpl) Create(ctx *fiber.Ctx, user domain.User, requ
 	tx := service.DB.Begin()
 	defer helper.CommitOrRollback(tx)
 
-	newContact := domain.Contact{
-		UserID:    user.ID,
-		FirstName: request.FirstName,
-		LastName:  request.LastName,
-		Email:     request.Email,
-		Phone:     request.Phone,
-	}
-
-	createdContact := service.ContactRepository.Create(ctx, tx, newContact)
-
-	return contact.ContactResponse{
-		ID:        createdContact.ID,
-		FirstName: createdContact.FirstName,
-		LastName:  createdContact.LastName,
-		Email:     createdContact.Email,
-		Phone:     createdContact.Phone,
-	}
+	return service.create(ctx, tx, user, request)
 }
 
-func (service *ContactServiceImpl) Get(ctx *fiber.Ctx, user domain.User, contactID int64) contact.ContactResponse {
+// Get returns the contact read with the columns of the fields, all of them
+// without fields
+func (service *ContactServiceImpl) Get(ctx *fiber.Ctx, user domain.User, contactID int64, include []string, fields []string) contact.ContactResponse {
 	tx := service.DB.Begin()
 	defer helper.CommitOrRollback(tx)
 
-	newContact, err := service.ContactRepository.FindById(ctx, tx, contactID, user.ID)
-	if err != nil {
-		panic(helper.NewNotFoundError("contact not found"))
-	}
+	newContact := findAuthorizedContactFields(ctx, tx, service.ContactRepository, service.AddressBookMemberRepository, user, contactID, domain.PermissionViewer, fields)
 
-	return contact.ContactResponse{
-		ID:        newContact.ID,
-		FirstName: newContact.FirstName,
-		LastName:  newContact.LastName,
-		Email:     newContact.Email,
-		Phone:     newContact.Phone,
-	}
+	contacts := []domain.Contact{*newContact}
+	service.preload(ctx, tx, contacts, include)
+	return toContactResponse(&contacts[0], service.PhotoOptions.BaseURL)
 }
 
 func (service *ContactServiceImpl) GetAll(ctx *fiber.Ctx, user domain.User, params contact.SearchParams) contact.SearchResult {
 	tx := service.DB.Begin()
 	defer helper.CommitOrRollback(tx)
 
+	if params.AddressBookID != 0 {
+		requireAddressBookPermission(ctx, tx, service.AddressBookMemberRepository, params.AddressBookID, user.ID, domain.PermissionViewer)
+	}
+
 	offset := (params.Page - 1) * params.Size
 
 	// Panggil repository untuk get data dengan filter
 	contacts, totalItem := service.ContactRepository.FindAll(ctx, tx, user.ID, params, offset)
+	service.preload(ctx, tx, contacts, params.Include)
 
 	// Hitung total page
 	totalPage := (totalItem + params.Size - 1) / params.Size
 
-	var contactResponses []contact.ContactResponse
+	contactResponses := []contact.ContactResponse{}
 	for _, newContact := range contacts {
-		contactResponses = append(contactResponses, contact.ContactResponse{
-			ID:        newContact.ID,
-			FirstName: newContact.FirstName,
-			LastName:  newContact.LastName,
-			Email:     newContact.Email,
-			Phone:     newContact.Phone,
-		})
+		contactResponses = append(contactResponses, toContactResponse(&newContact, service.PhotoOptions.BaseURL))
 	}
 
 	return contact.SearchResult{
@@ -99,54 +104,485 @@ func (service *ContactServiceImpl) GetAll(ctx *fiber.Ctx, user domain.User, para
 	}
 }
 
-func (service *ContactServiceImpl) Update(ctx *fiber.Ctx, user domain.User, contactID int64, request contact.ContactUpdateRequest) contact.ContactResponse {
+func (service *ContactServiceImpl) Update(ctx *fiber.Ctx, user domain.User, contactID int64, patch []byte, expectedVersion int64) contact.ContactResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	return service.update(ctx, tx, user, contactID, patch, expectedVersion)
+}
+
+func (service *ContactServiceImpl) Replace(ctx *fiber.Ctx, user domain.User, contactID int64, request *contact.ContactCreateRequest, expectedVersion int64) contact.ContactResponse {
 	err := service.Validate.Struct(request)
 	helper.PanicIfError(err)
 
 	tx := service.DB.Begin()
 	defer helper.CommitOrRollback(tx)
 
-	newContact, err := service.ContactRepository.FindById(ctx, tx, contactID, user.ID)
-	if err != nil {
-		panic(helper.NewNotFoundError("contact not found"))
+	newContact := findAuthorizedContact(ctx, tx, service.ContactRepository, service.AddressBookMemberRepository, user, contactID, domain.PermissionEditor)
+
+	checkContactVersion(newContact, expectedVersion)
+
+	return service.replace(ctx, tx, user, newContact, request)
+}
+
+func (service *ContactServiceImpl) Delete(ctx *fiber.Ctx, user domain.User, contactID int64, expectedVersion int64) {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	service.delete(ctx, tx, user, contactID, expectedVersion)
+}
+
+// GetHistory returns a page of the audit logs of the contact, newest first
+func (service *ContactServiceImpl) GetHistory(ctx *fiber.Ctx, user domain.User, contactID int64, page int, size int) audit.AuditLogListResult {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	findAuthorizedContact(ctx, tx, service.ContactRepository, service.AddressBookMemberRepository, user, contactID, domain.PermissionViewer)
+
+	auditLogs, totalItem := service.AuditLogRepository.FindAllByEntity(ctx, tx, domain.AuditEntityContact, contactID, (page-1)*size, size)
+
+	return toAuditLogListResult(auditLogs, page, size, totalItem)
+}
+
+// GetVersions returns a page of the versions of the contact, newest first
+func (service *ContactServiceImpl) GetVersions(ctx *fiber.Ctx, user domain.User, contactID int64, page int, size int) contact.ContactVersionListResult {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	findAuthorizedContact(ctx, tx, service.ContactRepository, service.AddressBookMemberRepository, user, contactID, domain.PermissionViewer)
+
+	contactVersions, totalItem := service.ContactVersionRepository.FindAll(ctx, tx, contactID, (page-1)*size, size)
+
+	versionResponses := []contact.ContactVersionResponse{}
+	for _, contactVersion := range contactVersions {
+		versionResponses = append(versionResponses, toContactVersionResponse(&contactVersion))
 	}
 
-	if request.FirstName != "" {
-		newContact.FirstName = request.FirstName
+	return contact.ContactVersionListResult{
+		Versions: versionResponses,
+		Paging: web.PagingResponse{
+			Page:      page,
+			Size:      size,
+			TotalPage: (totalItem + size - 1) / size,
+			TotalItem: totalItem,
+		},
 	}
+}
+
+func (service *ContactServiceImpl) GetVersion(ctx *fiber.Ctx, user domain.User, contactID int64, number int) contact.ContactVersionResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	findAuthorizedContact(ctx, tx, service.ContactRepository, service.AddressBookMemberRepository, user, contactID, domain.PermissionViewer)
+
+	return toContactVersionResponse(service.findContactVersion(ctx, tx, contactID, number))
+}
+
+// Revert restores the contact and its addresses to an earlier version. The
+// restored state is validated like a patched contact, since the snapshot may
+// lack the members a patch can clear, and saved as a new version through
+// replace, so the older versions are kept.
+func (service *ContactServiceImpl) Revert(ctx *fiber.Ctx, user domain.User, contactID int64, number int, expectedVersion int64) contact.ContactResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	contactEntity := findAuthorizedContact(ctx, tx, service.ContactRepository, service.AddressBookMemberRepository, user, contactID, domain.PermissionEditor)
+
+	checkContactVersion(contactEntity, expectedVersion)
+
+	snapshot := service.findContactVersion(ctx, tx, contactID, number).Snapshot
+
+	// Addresses first, so the version the update records includes them
+	service.restoreAddresses(ctx, tx, user, contactEntity, snapshot.Addresses)
+
+	err := service.Validate.Struct(contact.ContactPatchResult{
+		AddressBookID: snapshot.AddressBookID,
+		FirstName:     snapshot.FirstName,
+		LastName:      snapshot.LastName,
+		Email:         snapshot.Email,
+		Phone:         snapshot.Phone,
+	})
+	helper.PanicIfError(err)
 
-	if request.LastName != "" {
-		newContact.LastName = request.LastName
+	return service.replace(ctx, tx, user, contactEntity, &contact.ContactCreateRequest{
+		AddressBookID: snapshot.AddressBookID,
+		FirstName:     snapshot.FirstName,
+		LastName:      snapshot.LastName,
+		Email:         snapshot.Email,
+		Phone:         snapshot.Phone,
+	})
+}
+
+func (service *ContactServiceImpl) Bulk(ctx *fiber.Ctx, user domain.User, request *contact.BulkRequest) contact.BulkResult {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	if request.Mode == "" {
+		request.Mode = contact.BulkModeTransaction
 	}
 
-	if request.Email != "" {
-		newContact.Email = request.Email
+	result := contact.BulkResult{
+		Mode:    request.Mode,
+		Results: make([]contact.BulkOperationResult, 0, len(request.Operations)),
 	}
 
-	if request.Phone != "" {
-		newContact.Phone = request.Phone
+	// Best effort: every operation commits or rolls back on its own
+	if request.Mode == contact.BulkModeBestEffort {
+		for index, operation := range request.Operations {
+			tx := service.DB.Begin()
+			operationResult := service.runBulkOperation(ctx, tx, user, index, operation)
+			if operationResult.Code >= fiber.StatusBadRequest {
+				tx.Rollback()
+			} else {
+				helper.PanicIfError(tx.Commit().Error)
+				result.Committed = true
+			}
+			result.Results = append(result.Results, operationResult)
+		}
+		return result
 	}
 
-	updatedContact := service.ContactRepository.Update(ctx, tx, newContact)
+	// Transaction: the first failure rolls back everything and skips the rest
+	tx := service.DB.Begin()
+	failed := false
+	for index, operation := range request.Operations {
+		if failed {
+			result.Results = append(result.Results, contact.BulkOperationResult{
+				Index:     index,
+				Operation: operation.Operation,
+				Code:      fiber.StatusFailedDependency,
+				Status:    helper.GetStatusText(fiber.StatusFailedDependency),
+				Data:      "operation skipped because an earlier operation failed",
+			})
+			continue
+		}
+
+		operationResult := service.runBulkOperation(ctx, tx, user, index, operation)
+		failed = operationResult.Code >= fiber.StatusBadRequest
+		result.Results = append(result.Results, operationResult)
+	}
 
-	return contact.ContactResponse{
-		ID:        updatedContact.ID,
-		FirstName: updatedContact.FirstName,
-		LastName:  updatedContact.LastName,
-		Email:     updatedContact.Email,
-		Phone:     updatedContact.Phone,
+	if failed {
+		tx.Rollback()
+		return result
 	}
+
+	helper.PanicIfError(tx.Commit().Error)
+	result.Committed = true
+	return result
 }
 
-func (service *ContactServiceImpl) Delete(ctx *fiber.Ctx, user domain.User, contactID int64) {
-	tx := service.DB.Begin()
-	defer helper.CommitOrRollback(tx)
+// runBulkOperation executes one bulk operation inside tx, turning a panic into
+// the same error envelope the single-item endpoints respond with.
+func (service *ContactServiceImpl) runBulkOperation(ctx *fiber.Ctx, tx *gorm.DB, user domain.User, index int, operation contact.BulkOperation) (result contact.BulkOperationResult) {
+	result = contact.BulkOperationResult{Index: index, Operation: operation.Operation}
 
-	newContact, err := service.ContactRepository.FindById(ctx, tx, contactID, user.ID)
-	if err != nil {
-		panic(helper.NewNotFoundError("contact not found"))
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(error)
+			if !ok {
+				err = fmt.Errorf("%v", r)
+			}
+			response := helper.NewErrorResponse(err)
+			result.Code = response.Code
+			result.Status = response.Status
+			result.Data = response.Data
+		}
+	}()
+
+	switch operation.Operation {
+	case contact.BulkOperationCreate:
+		request := contact.ContactCreateRequest{}
+		decodeBulkData(operation.Data, &request)
+		helper.PanicIfError(service.Validate.Struct(request))
+
+		result.Code = fiber.StatusCreated
+		result.Data = service.create(ctx, tx, user, &request)
+	case contact.BulkOperationUpdate:
+		if len(operation.Data) == 0 {
+			panic(helper.NewBadRequestError("operation data is required"))
+		}
+
+		result.Code = fiber.StatusOK
+		result.Data = service.update(ctx, tx, user, operation.ID, operation.Data, operation.Version)
+	case contact.BulkOperationDelete:
+		service.delete(ctx, tx, user, operation.ID, operation.Version)
+
+		result.Code = fiber.StatusOK
+		result.Data = "Contact deleted successfully"
+	}
+	result.Status = helper.GetStatusText(result.Code)
+
+	return result
+}
+
+func (service *ContactServiceImpl) create(ctx *fiber.Ctx, tx *gorm.DB, user domain.User, request *contact.ContactCreateRequest) contact.ContactResponse {
+	var addressBook *domain.AddressBook
+	if request.AddressBookID == 0 {
+		addressBook = defaultAddressBook(ctx, tx, service.AddressBookRepository, service.AddressBookMemberRepository, user)
+	} else {
+		addressBook = &requireAddressBookPermission(ctx, tx, service.AddressBookMemberRepository, request.AddressBookID, user.ID, domain.PermissionEditor).AddressBook
 	}
 
+	maxContacts := repository.CurrentOrganization(ctx).Settings.MaxContacts
+	if maxContacts > 0 && service.ContactRepository.Count(ctx, tx) >= int64(maxContacts) {
+		panic(helper.NewResourceConflictError("organization contact limit reached"))
+	}
+
+	// Contacts belong to the owner of their address book, not to the editor
+	// who added them
+	newContact := domain.Contact{
+		UserID:        addressBook.OwnerID,
+		AddressBookID: addressBook.ID,
+		FirstName:     request.FirstName,
+		LastName:      request.LastName,
+		Email:         request.Email,
+		Phone:         request.Phone,
+		Version:       1,
+	}
+	if request.VCardName != "" {
+		newContact.VCardName = &request.VCardName
+		newContact.VCardUID = &request.VCardUID
+	}
+
+	createdContact := service.ContactRepository.Create(ctx, tx, newContact)
+	contactResponse := toContactResponse(&createdContact, service.PhotoOptions.BaseURL)
+
+	recordAudit(ctx, tx, service.AuditLogRepository, user, domain.AuditActionCreate, domain.AuditEntityContact, createdContact.ID, auditChanges(nil, contactResponse))
+	recordContactVersion(ctx, tx, service.ContactVersionRepository, service.AddressRepository, user, &createdContact)
+	recordEvent(ctx, tx, service.OutboxRepository, user, event.ContactCreated{ContactResponse: contactResponse})
+
+	return contactResponse
+}
+
+// update applies a JSON merge patch to the contact and validates the merged
+// result with the same rules as a newly created contact, except that the
+// optional members may have been cleared.
+func (service *ContactServiceImpl) update(ctx *fiber.Ctx, tx *gorm.DB, user domain.User, contactID int64, patch []byte, expectedVersion int64) contact.ContactResponse {
+	newContact := findAuthorizedContact(ctx, tx, service.ContactRepository, service.AddressBookMemberRepository, user, contactID, domain.PermissionEditor)
+
+	checkContactVersion(newContact, expectedVersion)
+
+	merged := contact.ContactPatchResult{
+		AddressBookID: newContact.AddressBookID,
+		FirstName:     newContact.FirstName,
+		LastName:      newContact.LastName,
+		Email:         newContact.Email,
+		Phone:         newContact.Phone,
+	}
+	err := helper.ApplyMergePatch(&merged, patch)
+	helper.PanicIfError(err)
+
+	err = service.Validate.Struct(merged)
+	helper.PanicIfError(err)
+
+	return service.replace(ctx, tx, user, newContact, &contact.ContactCreateRequest{
+		AddressBookID: merged.AddressBookID,
+		FirstName:     merged.FirstName,
+		LastName:      merged.LastName,
+		Email:         merged.Email,
+		Phone:         merged.Phone,
+	})
+}
+
+// replace overwrites every editable field of the contact with request, which
+// the caller validated. A different address book moves the contact, which
+// needs edit permission there too.
+func (service *ContactServiceImpl) replace(ctx *fiber.Ctx, tx *gorm.DB, user domain.User, contactEntity *domain.Contact, request *contact.ContactCreateRequest) contact.ContactResponse {
+	before := toContactResponse(contactEntity, service.PhotoOptions.BaseURL)
+
+	if request.AddressBookID != 0 && request.AddressBookID != contactEntity.AddressBookID {
+		member := requireAddressBookPermission(ctx, tx, service.AddressBookMemberRepository, request.AddressBookID, user.ID, domain.PermissionEditor)
+		service.ContactMoveRepository.Create(ctx, tx, domain.ContactMove{
+			ContactID:         contactEntity.ID,
+			FromAddressBookID: contactEntity.AddressBookID,
+			ToAddressBookID:   member.AddressBookID,
+		})
+		contactEntity.AddressBookID = member.AddressBookID
+		contactEntity.UserID = member.AddressBook.OwnerID
+	}
+
+	contactEntity.FirstName = request.FirstName
+	contactEntity.LastName = request.LastName
+	contactEntity.Email = request.Email
+	contactEntity.Phone = request.Phone
+
+	updatedContact, err := service.ContactRepository.Update(ctx, tx, contactEntity)
+	panicIfContactConflict(err)
+	contactResponse := toContactResponse(&updatedContact, service.PhotoOptions.BaseURL)
+
+	recordAudit(ctx, tx, service.AuditLogRepository, user, domain.AuditActionUpdate, domain.AuditEntityContact, updatedContact.ID, auditChanges(before, contactResponse))
+	recordContactVersion(ctx, tx, service.ContactVersionRepository, service.AddressRepository, user, &updatedContact)
+	recordEvent(ctx, tx, service.OutboxRepository, user, event.ContactUpdated{ContactResponse: contactResponse})
+
+	return contactResponse
+}
+
+func (service *ContactServiceImpl) delete(ctx *fiber.Ctx, tx *gorm.DB, user domain.User, contactID int64, expectedVersion int64) {
+	newContact := findAuthorizedContact(ctx, tx, service.ContactRepository, service.AddressBookMemberRepository, user, contactID, domain.PermissionEditor)
+
+	checkContactVersion(newContact, expectedVersion)
+
+	// Addresses are soft-deleted with the contact under the same batch
+	batchID := helper.GenerateBatchID()
+
+	err := service.AddressRepository.DeleteAllByContactId(ctx, tx, newContact.ID, batchID)
+	helper.PanicIfError(err)
+
+	newContact.DeletionBatchID = &batchID
 	err = service.ContactRepository.Delete(ctx, tx, newContact)
+	panicIfContactConflict(err)
+
+	contactResponse := toContactResponse(newContact, service.PhotoOptions.BaseURL)
+
+	recordAudit(ctx, tx, service.AuditLogRepository, user, domain.AuditActionDelete, domain.AuditEntityContact, newContact.ID, auditChanges(contactResponse, nil))
+	recordEvent(ctx, tx, service.OutboxRepository, user, event.ContactDeleted{ContactResponse: contactResponse})
+}
+
+func (service *ContactServiceImpl) findContactVersion(ctx *fiber.Ctx, tx *gorm.DB, contactID int64, number int) *domain.ContactVersion {
+	contactVersion, err := service.ContactVersionRepository.FindByNumber(ctx, tx, contactID, number)
+	if err != nil {
+		panic(helper.NewNotFoundError("contact version not found"))
+	}
+	return contactVersion
+}
+
+// restoreAddresses makes the addresses of the contact match a snapshot.
+// Addresses deleted since the snapshot come back as new addresses, addresses
+// added since are deleted and the others get their old values back.
+func (service *ContactServiceImpl) restoreAddresses(ctx *fiber.Ctx, tx *gorm.DB, user domain.User, contactEntity *domain.Contact, snapshots []domain.AddressSnapshot) {
+	missing := map[int64]bool{}
+	byID := map[int64]domain.AddressSnapshot{}
+	for _, snapshot := range snapshots {
+		helper.PanicIfError(service.Validate.Struct(address.AddressPatchResult(toAddressCreateRequest(snapshot))))
+		missing[snapshot.ID] = true
+		byID[snapshot.ID] = snapshot
+	}
+
+	for _, addressEntity := range service.AddressRepository.FindAll(ctx, tx, contactEntity.ID, nil) {
+		addressEntity := addressEntity
+		before := toAddressResponse(&addressEntity)
+
+		snapshot, ok := byID[addressEntity.ID]
+		if !ok {
+			batchID := helper.GenerateBatchID()
+			addressEntity.DeletionBatchID = &batchID
+			panicIfAddressConflict(service.AddressRepository.Delete(ctx, tx, &addressEntity))
+
+			recordAudit(ctx, tx, service.AuditLogRepository, user, domain.AuditActionDelete, domain.AuditEntityAddress, addressEntity.ID, auditChanges(before, nil))
+			recordEvent(ctx, tx, service.OutboxRepository, user, event.AddressDeleted{ContactID: contactEntity.ID, AddressBookID: contactEntity.AddressBookID, Address: before})
+			continue
+		}
+		delete(missing, addressEntity.ID)
+
+		if toAddressSnapshot(&addressEntity) == snapshot {
+			continue
+		}
+
+		addressEntity.Street = snapshot.Street
+		addressEntity.City = snapshot.City
+		addressEntity.Province = snapshot.Province
+		addressEntity.Country = snapshot.Country
+		addressEntity.PostalCode = snapshot.PostalCode
+
+		updatedAddress, err := service.AddressRepository.Update(ctx, tx, &addressEntity)
+		panicIfAddressConflict(err)
+
+		addressResponse := toAddressResponse(&updatedAddress)
+
+		recordAudit(ctx, tx, service.AuditLogRepository, user, domain.AuditActionUpdate, domain.AuditEntityAddress, updatedAddress.ID, auditChanges(before, addressResponse))
+		recordEvent(ctx, tx, service.OutboxRepository, user, event.AddressUpdated{ContactID: contactEntity.ID, AddressBookID: contactEntity.AddressBookID, Address: addressResponse})
+	}
+
+	for _, snapshot := range snapshots {
+		if !missing[snapshot.ID] {
+			continue
+		}
+
+		createdAddress := service.AddressRepository.Create(ctx, tx, domain.Address{
+			ContactID:  contactEntity.ID,
+			Street:     snapshot.Street,
+			City:       snapshot.City,
+			Province:   snapshot.Province,
+			Country:    snapshot.Country,
+			PostalCode: snapshot.PostalCode,
+			Version:    1,
+		})
+
+		addressResponse := toAddressResponse(&createdAddress)
+
+		recordAudit(ctx, tx, service.AuditLogRepository, user, domain.AuditActionCreate, domain.AuditEntityAddress, createdAddress.ID, auditChanges(nil, addressResponse))
+		recordEvent(ctx, tx, service.OutboxRepository, user, event.AddressCreated{ContactID: contactEntity.ID, AddressBookID: contactEntity.AddressBookID, Address: addressResponse})
+	}
+}
+
+// checkContactVersion rejects the request when the client holds a stale copy
+func checkContactVersion(contactEntity *domain.Contact, expectedVersion int64) {
+	if expectedVersion != 0 && contactEntity.Version != expectedVersion {
+		panic(helper.NewPreconditionFailedError("contact version does not match"))
+	}
+}
+
+func panicIfContactConflict(err error) {
+	if errors.Is(err, repository.ErrVersionConflict) {
+		panic(helper.NewPreconditionFailedError("contact was modified by another request"))
+	}
 	helper.PanicIfError(err)
 }
+
+func decodeBulkData(data json.RawMessage, request interface{}) {
+	if len(data) == 0 {
+		panic(helper.NewBadRequestError("operation data is required"))
+	}
+
+	if err := json.Unmarshal(data, request); err != nil {
+		panic(helper.NewBadRequestError("operation data is invalid: " + err.Error()))
+	}
+}
+
+// preload loads the sub-resources named by include into the contacts, with
+// one query for all of them per sub-resource
+func (service *ContactServiceImpl) preload(ctx *fiber.Ctx, tx *gorm.DB, contacts []domain.Contact, include []string) {
+	contactIDs := make([]int64, 0, len(contacts))
+	for _, contactEntity := range contacts {
+		contactIDs = append(contactIDs, contactEntity.ID)
+	}
+
+	for _, name := range include {
+		switch name {
+		case contact.IncludeAddresses:
+			addresses := map[int64][]domain.Address{}
+			for _, addressEntity := range service.AddressRepository.FindAllByContacts(ctx, tx, contactIDs) {
+				addresses[addressEntity.ContactID] = append(addresses[addressEntity.ContactID], addressEntity)
+			}
+			for index := range contacts {
+				contacts[index].Addresses = append([]domain.Address{}, addresses[contacts[index].ID]...)
+			}
+		}
+	}
+}
+
+// toContactResponse embeds the associations that were loaded, a nil
+// association was not asked for. Photos are linked under photoBaseURL.
+func toContactResponse(contactEntity *domain.Contact, photoBaseURL string) contact.ContactResponse {
+	contactResponse := contact.ContactResponse{
+		ID:            contactEntity.ID,
+		AddressBookID: contactEntity.AddressBookID,
+		FirstName:     contactEntity.FirstName,
+		LastName:      contactEntity.LastName,
+		Email:         contactEntity.Email,
+		Phone:         contactEntity.Phone,
+		Version:       contactEntity.Version,
+		Photo:         photoURLs(contactEntity.PhotoKey, photoBaseURL),
+	}
+
+	if contactEntity.Addresses != nil {
+		addresses := make([]address.AddressResponse, 0, len(contactEntity.Addresses))
+		for index := range contactEntity.Addresses {
+			addresses = append(addresses, toAddressResponse(&contactEntity.Addresses[index]))
+		}
+		contactResponse.Addresses = &addresses
+	}
+
+	return contactResponse
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/address"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+	"github.com/sorfian/go-contact-management-api/repository"
+	"gorm.io/gorm"
+)
+
+// recordContactVersion snapshots the contact and its addresses as they are in
+// tx as the next version of the contact
+func recordContactVersion(ctx *fiber.Ctx, tx *gorm.DB, contactVersionRepository repository.ContactVersionRepository, addressRepository repository.AddressRepository, actor domain.User, contactEntity *domain.Contact) {
+	snapshot := domain.ContactSnapshot{
+		AddressBookID: contactEntity.AddressBookID,
+		FirstName:     contactEntity.FirstName,
+		LastName:      contactEntity.LastName,
+		Email:         contactEntity.Email,
+		Phone:         contactEntity.Phone,
+		Addresses:     []domain.AddressSnapshot{},
+	}
+	for _, addressEntity := range addressRepository.FindAll(ctx, tx, contactEntity.ID, nil) {
+		snapshot.Addresses = append(snapshot.Addresses, toAddressSnapshot(&addressEntity))
+	}
+
+	contactVersionRepository.Create(ctx, tx, domain.ContactVersion{
+		ContactID:      contactEntity.ID,
+		Number:         contactVersionRepository.LastNumber(ctx, tx, contactEntity.ID) + 1,
+		ContactVersion: contactEntity.Version,
+		ActorID:        &actor.ID,
+		Snapshot:       snapshot,
+	})
+}
+
+func toAddressSnapshot(addressEntity *domain.Address) domain.AddressSnapshot {
+	return domain.AddressSnapshot{
+		ID:         addressEntity.ID,
+		Street:     addressEntity.Street,
+		City:       addressEntity.City,
+		Province:   addressEntity.Province,
+		Country:    addressEntity.Country,
+		PostalCode: addressEntity.PostalCode,
+	}
+}
+
+func toAddressCreateRequest(snapshot domain.AddressSnapshot) address.AddressCreateRequest {
+	return address.AddressCreateRequest{
+		Street:     snapshot.Street,
+		City:       snapshot.City,
+		Province:   snapshot.Province,
+		Country:    snapshot.Country,
+		PostalCode: snapshot.PostalCode,
+	}
+}
+
+func toContactVersionResponse(contactVersion *domain.ContactVersion) contact.ContactVersionResponse {
+	return contact.ContactVersionResponse{
+		Number:         contactVersion.Number,
+		ContactVersion: contactVersion.ContactVersion,
+		ActorID:        contactVersion.ActorID,
+		Snapshot:       contactVersion.Snapshot,
+		CreatedAt:      contactVersion.CreatedAt,
+	}
+}
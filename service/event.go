@@ -0,0 +1,56 @@
+package service
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/event"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/repository"
+	"gorm.io/gorm"
+)
+
+// OutboxOptions configures the dispatcher. An event whose subscribers failed
+// is delivered again after RetryBackoff, doubling with every attempt.
+type OutboxOptions struct {
+	RetryBackoff time.Duration
+	PollInterval time.Duration
+}
+
+// recordEvent writes the event to the outbox inside tx, so subscribers only
+// hear about changes that committed
+func recordEvent(ctx *fiber.Ctx, tx *gorm.DB, outboxRepository repository.OutboxRepository, actor domain.User, e event.Event) {
+	payload, err := json.Marshal(e)
+	helper.PanicIfError(err)
+
+	// Truncated like the column, so the event is due right away
+	now := time.Now().Truncate(time.Second)
+
+	outboxRepository.Create(ctx, tx, domain.OutboxEvent{
+		EventID:       helper.GenerateBatchID(),
+		Name:          e.Name(),
+		ActorID:       &actor.ID,
+		Payload:       payload,
+		NextAttemptAt: &now,
+	})
+}
+
+// NewEventBus returns the bus the dispatcher publishes to, with the
+// subscribers of the application
+func NewEventBus(webhookService WebhookService, eventStreamService EventStreamService) *event.Bus {
+	bus := event.NewBus()
+	for _, name := range []string{
+		event.NameContactCreated,
+		event.NameContactUpdated,
+		event.NameContactDeleted,
+		event.NameAddressCreated,
+		event.NameAddressUpdated,
+		event.NameAddressDeleted,
+	} {
+		bus.Subscribe(name, webhookService.QueueDeliveries)
+		bus.Subscribe(name, eventStreamService.Record)
+	}
+	return bus
+}
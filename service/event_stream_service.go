@@ -0,0 +1,22 @@
+package service
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/event"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/stream"
+)
+
+// EventStreamService feeds the live event stream. Record subscribes to the
+// event bus, Resume, Poll and SignedIn serve the stream of the request
+// organization and Prune drops the events of every organization that are past
+// the retention.
+type EventStreamService interface {
+	Record(ctx *fiber.Ctx, message event.Message)
+	Resume(ctx *fiber.Ctx, user domain.User, lastEventID string) int64
+	Poll(ctx *fiber.Ctx, user domain.User, after int64, limit int) []stream.EventResponse
+	SignedIn(ctx *fiber.Ctx, user domain.User) bool
+	Prune(ctx *fiber.Ctx, now time.Time) int64
+}
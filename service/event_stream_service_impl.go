@@ -0,0 +1,157 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/event"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/stream"
+	"github.com/sorfian/go-contact-management-api/repository"
+	"gorm.io/gorm"
+)
+
+// EventStreamOptions configures the live event stream. A connection looks for
+// new events every PollInterval and sends a comment when it has been silent
+// for HeartbeatInterval, so proxies keep it open. Events are kept for
+// Retention, a client away for longer misses the older ones.
+type EventStreamOptions struct {
+	PollInterval      time.Duration
+	HeartbeatInterval time.Duration
+	Retention         time.Duration
+}
+
+type EventStreamServiceImpl struct {
+	StreamEventRepository repository.StreamEventRepository
+	UserRepository        repository.UserRepository
+	DB                    *gorm.DB
+	Options               EventStreamOptions
+}
+
+func NewEventStreamService(streamEventRepository repository.StreamEventRepository, userRepository repository.UserRepository, DB *gorm.DB, options EventStreamOptions) EventStreamService {
+	return &EventStreamServiceImpl{
+		StreamEventRepository: streamEventRepository,
+		UserRepository:        userRepository,
+		DB:                    DB,
+		Options:               options,
+	}
+}
+
+// Record adds a contact or address event to the stream. The stream is read
+// by every API process, the dispatcher alone writes it, so the IDs follow the
+// order the events were dispatched in.
+func (service *EventStreamServiceImpl) Record(ctx *fiber.Ctx, message event.Message) {
+	addressBookID, ok := streamAddressBook(message.Event)
+	if !ok || message.OrganizationID == nil {
+		return
+	}
+
+	payload, err := json.Marshal(message.Event)
+	helper.PanicIfError(err)
+
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	if service.StreamEventRepository.ExistsForEvent(ctx, tx, message.ID) {
+		return
+	}
+
+	service.StreamEventRepository.Create(ctx, tx, domain.StreamEvent{
+		EventID:       message.ID,
+		AddressBookID: addressBookID,
+		Name:          message.Event.Name(),
+		Payload:       payload,
+		OccurredAt:    message.OccurredAt,
+	})
+}
+
+// Resume returns the ID the stream continues after. A client reconnecting
+// with the Last-Event-ID it saw gets what it missed, a new client only what
+// happens from now on.
+func (service *EventStreamServiceImpl) Resume(ctx *fiber.Ctx, user domain.User, lastEventID string) int64 {
+	if lastEventID != "" {
+		after, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || after < 0 {
+			panic(helper.NewBadRequestError("Last-Event-ID must be an event ID of the stream"))
+		}
+		return after
+	}
+
+	return service.StreamEventRepository.LastID(ctx, service.DB)
+}
+
+// Poll returns up to limit events after the given ID of the address books
+// the user is a member of. Membership is checked on every poll, so a user
+// removed from an address book stops seeing its changes.
+func (service *EventStreamServiceImpl) Poll(ctx *fiber.Ctx, user domain.User, after int64, limit int) []stream.EventResponse {
+	eventResponses := []stream.EventResponse{}
+	for _, streamEvent := range service.StreamEventRepository.FindAfter(ctx, service.DB, user.ID, after, limit) {
+		// An SSE data line ends at a line break
+		data := bytes.Buffer{}
+		helper.PanicIfError(json.Compact(&data, streamEvent.Payload))
+
+		// Contact events are the contact, address events name it
+		var subject struct {
+			ID        int64 `json:"id"`
+			ContactID int64 `json:"contact_id"`
+		}
+		helper.PanicIfError(json.Unmarshal(streamEvent.Payload, &subject))
+		if subject.ContactID == 0 {
+			subject.ContactID = subject.ID
+		}
+
+		eventResponses = append(eventResponses, stream.EventResponse{
+			ID:        streamEvent.ID,
+			Event:     streamEvent.Name,
+			ContactID: subject.ContactID,
+			Data:      data.Bytes(),
+		})
+	}
+	return eventResponses
+}
+
+// SignedIn reports whether the token the stream was opened with is still
+// valid. The user is read again, so logging out, logging in elsewhere or
+// being disabled ends the streams the user has open.
+func (service *EventStreamServiceImpl) SignedIn(ctx *fiber.Ctx, user domain.User) bool {
+	currentUser, err := service.UserRepository.FindByToken(ctx, service.DB, user.Token)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false
+	}
+	helper.PanicIfError(err)
+
+	return currentUser.ID == user.ID &&
+		currentUser.DisabledAt == nil &&
+		time.Now().UnixMilli() < currentUser.TokenExp
+}
+
+// Prune deletes the events older than the retention and returns how many were
+// deleted
+func (service *EventStreamServiceImpl) Prune(ctx *fiber.Ctx, now time.Time) int64 {
+	return service.StreamEventRepository.DeleteBefore(ctx, service.DB, now.Add(-service.Options.Retention))
+}
+
+// streamAddressBook returns the address book of the contact an event is
+// about, the stream shows it to the members of that address book
+func streamAddressBook(e event.Event) (int64, bool) {
+	switch e := e.(type) {
+	case *event.ContactCreated:
+		return e.AddressBookID, true
+	case *event.ContactUpdated:
+		return e.AddressBookID, true
+	case *event.ContactDeleted:
+		return e.AddressBookID, true
+	case *event.AddressCreated:
+		return e.AddressBookID, true
+	case *event.AddressUpdated:
+		return e.AddressBookID, true
+	case *event.AddressDeleted:
+		return e.AddressBookID, true
+	}
+	return 0, false
+}
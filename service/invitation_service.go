@@ -0,0 +1,17 @@
+package service
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/addressbook"
+)
+
+// InvitationService invites users to address books. The owner invites by
+// username and the invited user becomes a member by accepting.
+type InvitationService interface {
+	Create(ctx *fiber.Ctx, user domain.User, addressBookID int64, request *addressbook.InvitationCreateRequest) addressbook.InvitationResponse
+	GetAllByAddressBook(ctx *fiber.Ctx, user domain.User, addressBookID int64) []addressbook.InvitationResponse
+	GetAllReceived(ctx *fiber.Ctx, user domain.User) []addressbook.InvitationResponse
+	Accept(ctx *fiber.Ctx, user domain.User, invitationID int64) addressbook.AddressBookResponse
+	Decline(ctx *fiber.Ctx, user domain.User, invitationID int64) addressbook.InvitationResponse
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/addressbook"
+	"github.com/sorfian/go-contact-management-api/repository"
+	"gorm.io/gorm"
+)
+
+type InvitationServiceImpl struct {
+	InvitationRepository         repository.AddressBookInvitationRepository
+	AddressBookMemberRepository  repository.AddressBookMemberRepository
+	OrganizationMemberRepository repository.OrganizationMemberRepository
+	UserRepository               repository.UserRepository
+	DB                           *gorm.DB
+	Validate                     *validator.Validate
+}
+
+func NewInvitationService(invitationRepository repository.AddressBookInvitationRepository, addressBookMemberRepository repository.AddressBookMemberRepository, organizationMemberRepository repository.OrganizationMemberRepository, userRepository repository.UserRepository, DB *gorm.DB, validate *validator.Validate) InvitationService {
+	return &InvitationServiceImpl{
+		InvitationRepository:         invitationRepository,
+		AddressBookMemberRepository:  addressBookMemberRepository,
+		OrganizationMemberRepository: organizationMemberRepository,
+		UserRepository:               userRepository,
+		DB:                           DB,
+		Validate:                     validate,
+	}
+}
+
+func (service *InvitationServiceImpl) Create(ctx *fiber.Ctx, user domain.User, addressBookID int64, request *addressbook.InvitationCreateRequest) addressbook.InvitationResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	owner := requireAddressBookPermission(ctx, tx, service.AddressBookMemberRepository, addressBookID, user.ID, domain.PermissionOwner)
+
+	// Address books are only shared within their organization
+	invitee, err := service.UserRepository.FindByUsername(ctx, tx, request.Username)
+	if err == nil {
+		_, err = service.OrganizationMemberRepository.Find(ctx, tx, repository.CurrentOrganization(ctx).ID, invitee.ID)
+	}
+	if err != nil {
+		panic(helper.NewNotFoundError("user not found"))
+	}
+
+	if _, err := service.AddressBookMemberRepository.Find(ctx, tx, addressBookID, invitee.ID); err == nil {
+		panic(helper.NewResourceConflictError("user is already a member of the address book"))
+	}
+
+	if _, err := service.InvitationRepository.FindPending(ctx, tx, addressBookID, invitee.ID); err == nil {
+		panic(helper.NewResourceConflictError("user already has a pending invitation"))
+	}
+
+	invitation := service.InvitationRepository.Create(ctx, tx, domain.AddressBookInvitation{
+		AddressBookID: addressBookID,
+		InviterID:     user.ID,
+		InviteeID:     invitee.ID,
+		Permission:    request.Permission,
+		Status:        domain.InvitationPending,
+	})
+	invitation.AddressBook = owner.AddressBook
+	invitation.Inviter = user
+	invitation.Invitee = *invitee
+
+	return toInvitationResponse(&invitation)
+}
+
+func (service *InvitationServiceImpl) GetAllByAddressBook(ctx *fiber.Ctx, user domain.User, addressBookID int64) []addressbook.InvitationResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	requireAddressBookPermission(ctx, tx, service.AddressBookMemberRepository, addressBookID, user.ID, domain.PermissionOwner)
+
+	return toInvitationResponses(service.InvitationRepository.FindAllPendingByAddressBook(ctx, tx, addressBookID))
+}
+
+func (service *InvitationServiceImpl) GetAllReceived(ctx *fiber.Ctx, user domain.User) []addressbook.InvitationResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	return toInvitationResponses(service.InvitationRepository.FindAllPendingByInvitee(ctx, tx, user.ID))
+}
+
+// Accept makes the invited user a member with the permission of the invitation
+func (service *InvitationServiceImpl) Accept(ctx *fiber.Ctx, user domain.User, invitationID int64) addressbook.AddressBookResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	invitation := service.findPendingInvitation(ctx, tx, user, invitationID)
+	invitation.Status = domain.InvitationAccepted
+	service.InvitationRepository.Update(ctx, tx, invitation)
+
+	service.AddressBookMemberRepository.Create(ctx, tx, domain.AddressBookMember{
+		AddressBookID: invitation.AddressBookID,
+		UserID:        user.ID,
+		Permission:    invitation.Permission,
+	})
+
+	return toAddressBookResponse(&invitation.AddressBook, invitation.Permission)
+}
+
+func (service *InvitationServiceImpl) Decline(ctx *fiber.Ctx, user domain.User, invitationID int64) addressbook.InvitationResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	invitation := service.findPendingInvitation(ctx, tx, user, invitationID)
+	invitation.Status = domain.InvitationDeclined
+	service.InvitationRepository.Update(ctx, tx, invitation)
+
+	return toInvitationResponse(invitation)
+}
+
+// findPendingInvitation returns an invitation addressed to the user, the
+// invitations of others are not found
+func (service *InvitationServiceImpl) findPendingInvitation(ctx *fiber.Ctx, tx *gorm.DB, user domain.User, invitationID int64) *domain.AddressBookInvitation {
+	invitation, err := service.InvitationRepository.FindById(ctx, tx, invitationID)
+	if err != nil || invitation.InviteeID != user.ID {
+		panic(helper.NewNotFoundError("invitation not found"))
+	}
+
+	if invitation.Status != domain.InvitationPending {
+		panic(helper.NewResourceConflictError("invitation is already " + invitation.Status))
+	}
+
+	return invitation
+}
+
+func toInvitationResponses(invitations []domain.AddressBookInvitation) []addressbook.InvitationResponse {
+	invitationResponses := []addressbook.InvitationResponse{}
+	for _, invitation := range invitations {
+		invitationResponses = append(invitationResponses, toInvitationResponse(&invitation))
+	}
+	return invitationResponses
+}
+
+func toInvitationResponse(invitation *domain.AddressBookInvitation) addressbook.InvitationResponse {
+	return addressbook.InvitationResponse{
+		ID:              invitation.ID,
+		AddressBookID:   invitation.AddressBookID,
+		AddressBookName: invitation.AddressBook.Name,
+		Inviter:         invitation.Inviter.Username,
+		Invitee:         invitation.Invitee.Username,
+		Permission:      invitation.Permission,
+		Status:          invitation.Status,
+		CreatedAt:       invitation.CreatedAt,
+	}
+}
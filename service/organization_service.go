@@ -0,0 +1,21 @@
+package service
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/organization"
+)
+
+// OrganizationService manages organizations. Every method but Resolve, Create
+// and GetAll works on the organization the request runs in.
+type OrganizationService interface {
+	Resolve(ctx *fiber.Ctx, user domain.User, organizationID int64) *domain.Organization
+	Create(ctx *fiber.Ctx, user domain.User, request *organization.OrganizationCreateRequest) organization.OrganizationResponse
+	GetAll(ctx *fiber.Ctx, user domain.User) []organization.OrganizationResponse
+	Get(ctx *fiber.Ctx, user domain.User) organization.OrganizationResponse
+	Update(ctx *fiber.Ctx, user domain.User, patch []byte) organization.OrganizationResponse
+	GetMembers(ctx *fiber.Ctx, user domain.User) []organization.MemberResponse
+	AddMember(ctx *fiber.Ctx, user domain.User, request *organization.MemberCreateRequest) organization.MemberResponse
+	UpdateMember(ctx *fiber.Ctx, user domain.User, memberUserID int, request *organization.MemberUpdateRequest) organization.MemberResponse
+	RemoveMember(ctx *fiber.Ctx, user domain.User, memberUserID int)
+}
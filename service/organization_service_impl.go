@@ -0,0 +1,318 @@
+package service
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/organization"
+	"github.com/sorfian/go-contact-management-api/repository"
+	"gorm.io/gorm"
+)
+
+type OrganizationServiceImpl struct {
+	OrganizationRepository       repository.OrganizationRepository
+	OrganizationMemberRepository repository.OrganizationMemberRepository
+	UserRepository               repository.UserRepository
+	AddressBookRepository        repository.AddressBookRepository
+	AddressBookMemberRepository  repository.AddressBookMemberRepository
+	ContactRepository            repository.ContactRepository
+	DB                           *gorm.DB
+	Validate                     *validator.Validate
+}
+
+func NewOrganizationService(organizationRepository repository.OrganizationRepository, organizationMemberRepository repository.OrganizationMemberRepository, userRepository repository.UserRepository, addressBookRepository repository.AddressBookRepository, addressBookMemberRepository repository.AddressBookMemberRepository, contactRepository repository.ContactRepository, DB *gorm.DB, validate *validator.Validate) OrganizationService {
+	return &OrganizationServiceImpl{
+		OrganizationRepository:       organizationRepository,
+		OrganizationMemberRepository: organizationMemberRepository,
+		UserRepository:               userRepository,
+		AddressBookRepository:        addressBookRepository,
+		AddressBookMemberRepository:  addressBookMemberRepository,
+		ContactRepository:            contactRepository,
+		DB:                           DB,
+		Validate:                     validate,
+	}
+}
+
+// Resolve returns the organization a request of the user runs in. Without an
+// organization ID that is the user's first organization, users who have none
+// get a personal one. Organizations the user is not a member of are not found.
+func (service *OrganizationServiceImpl) Resolve(ctx *fiber.Ctx, user domain.User, organizationID int64) *domain.Organization {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	if organizationID == 0 {
+		member := service.defaultMembership(ctx, tx, user)
+		return &member.Organization
+	}
+
+	member, err := service.OrganizationMemberRepository.Find(ctx, tx, organizationID, user.ID)
+	if err != nil {
+		panic(helper.NewNotFoundError("organization not found"))
+	}
+
+	return &member.Organization
+}
+
+func (service *OrganizationServiceImpl) Create(ctx *fiber.Ctx, user domain.User, request *organization.OrganizationCreateRequest) organization.OrganizationResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	// The personal organization comes first so it stays the user's default
+	service.defaultMembership(ctx, tx, user)
+	member := service.createOrganization(ctx, tx, user, request.Name)
+
+	return toOrganizationResponse(&member.Organization, member.Role)
+}
+
+func (service *OrganizationServiceImpl) GetAll(ctx *fiber.Ctx, user domain.User) []organization.OrganizationResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	// Every user has an organization of their own to work in
+	service.defaultMembership(ctx, tx, user)
+
+	members := service.OrganizationMemberRepository.FindAllByUser(ctx, tx, user.ID)
+
+	organizationResponses := []organization.OrganizationResponse{}
+	for _, member := range members {
+		organizationResponses = append(organizationResponses, toOrganizationResponse(&member.Organization, member.Role))
+	}
+
+	return organizationResponses
+}
+
+func (service *OrganizationServiceImpl) Get(ctx *fiber.Ctx, user domain.User) organization.OrganizationResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	member := service.requireRole(ctx, tx, user, domain.OrganizationRoleMember)
+
+	return toOrganizationResponse(&member.Organization, member.Role)
+}
+
+// Update applies a JSON merge patch to the name and settings of the
+// organization
+func (service *OrganizationServiceImpl) Update(ctx *fiber.Ctx, user domain.User, patch []byte) organization.OrganizationResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	member := service.requireRole(ctx, tx, user, domain.OrganizationRoleAdmin)
+	organizationEntity := &member.Organization
+
+	merged := organization.OrganizationUpdateRequest{
+		Name:     organizationEntity.Name,
+		Settings: toSettingsResponse(organizationEntity.Settings),
+	}
+	err := helper.ApplyMergePatch(&merged, patch)
+	helper.PanicIfError(err)
+
+	err = service.Validate.Struct(merged)
+	helper.PanicIfError(err)
+
+	organizationEntity.Name = merged.Name
+	organizationEntity.Settings = domain.OrganizationSettings{
+		MaxContacts:            merged.Settings.MaxContacts,
+		DefaultAddressBookName: merged.Settings.DefaultAddressBookName,
+	}
+	updatedOrganization := service.OrganizationRepository.Update(ctx, tx, organizationEntity)
+
+	return toOrganizationResponse(&updatedOrganization, member.Role)
+}
+
+func (service *OrganizationServiceImpl) GetMembers(ctx *fiber.Ctx, user domain.User) []organization.MemberResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	member := service.requireRole(ctx, tx, user, domain.OrganizationRoleMember)
+
+	members := service.OrganizationMemberRepository.FindAllByOrganization(ctx, tx, member.OrganizationID)
+
+	memberResponses := []organization.MemberResponse{}
+	for _, member := range members {
+		memberResponses = append(memberResponses, toOrganizationMemberResponse(&member))
+	}
+
+	return memberResponses
+}
+
+// AddMember adds an existing user to the organization, admins add members and
+// only the owner adds admins
+func (service *OrganizationServiceImpl) AddMember(ctx *fiber.Ctx, user domain.User, request *organization.MemberCreateRequest) organization.MemberResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	actor := service.requireRole(ctx, tx, user, requiredRoleToGrant(request.Role))
+
+	newUser, err := service.UserRepository.FindByUsername(ctx, tx, request.Username)
+	if err != nil {
+		panic(helper.NewNotFoundError("user not found"))
+	}
+
+	if _, err := service.OrganizationMemberRepository.Find(ctx, tx, actor.OrganizationID, newUser.ID); err == nil {
+		panic(helper.NewResourceConflictError("user is already a member of the organization"))
+	}
+
+	member := service.OrganizationMemberRepository.Create(ctx, tx, domain.OrganizationMember{
+		OrganizationID: actor.OrganizationID,
+		UserID:         newUser.ID,
+		Role:           request.Role,
+	})
+	member.User = *newUser
+
+	return toOrganizationMemberResponse(&member)
+}
+
+func (service *OrganizationServiceImpl) UpdateMember(ctx *fiber.Ctx, user domain.User, memberUserID int, request *organization.MemberUpdateRequest) organization.MemberResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	actor := service.requireRole(ctx, tx, user, domain.OrganizationRoleAdmin)
+
+	member := service.findMember(ctx, tx, actor.OrganizationID, memberUserID)
+	if member.Role == domain.OrganizationRoleOwner {
+		panic(helper.NewBadRequestError("the owner role cannot be changed"))
+	}
+	if !actor.Allows(requiredRoleToGrant(member.Role)) || !actor.Allows(requiredRoleToGrant(request.Role)) {
+		panic(helper.NewForbiddenError("organization role " + domain.OrganizationRoleOwner + " required"))
+	}
+
+	member.Role = request.Role
+	updatedMember := service.OrganizationMemberRepository.Update(ctx, tx, member)
+
+	return toOrganizationMemberResponse(&updatedMember)
+}
+
+// RemoveMember lets admins remove members, the owner remove admins and any
+// member leave. The member's empty address books in the organization are
+// deleted with their memberships; address books that still have contacts
+// have to be emptied first.
+func (service *OrganizationServiceImpl) RemoveMember(ctx *fiber.Ctx, user domain.User, memberUserID int) {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	actor := service.requireRole(ctx, tx, user, domain.OrganizationRoleMember)
+
+	member := service.findMember(ctx, tx, actor.OrganizationID, memberUserID)
+	if member.Role == domain.OrganizationRoleOwner {
+		panic(helper.NewBadRequestError("the owner cannot leave the organization"))
+	}
+	if memberUserID != user.ID && !actor.Allows(requiredRoleToGrant(member.Role)) {
+		panic(helper.NewForbiddenError("organization role " + requiredRoleToGrant(member.Role) + " required"))
+	}
+
+	addressBooks := service.AddressBookRepository.FindAllOwned(ctx, tx, memberUserID)
+	for _, addressBook := range addressBooks {
+		if service.ContactRepository.CountByAddressBook(ctx, tx, addressBook.ID) > 0 {
+			panic(helper.NewResourceConflictError("member still owns address books with contacts"))
+		}
+	}
+	for _, addressBook := range addressBooks {
+		err := service.AddressBookRepository.Delete(ctx, tx, &addressBook)
+		helper.PanicIfError(err)
+	}
+
+	err := service.AddressBookMemberRepository.DeleteAllByUser(ctx, tx, memberUserID)
+	helper.PanicIfError(err)
+
+	err = service.OrganizationMemberRepository.Delete(ctx, tx, member)
+	helper.PanicIfError(err)
+}
+
+// defaultMembership returns the user's first membership, creating a personal
+// organization for users who have none
+func (service *OrganizationServiceImpl) defaultMembership(ctx *fiber.Ctx, tx *gorm.DB, user domain.User) *domain.OrganizationMember {
+	member, err := service.OrganizationMemberRepository.FindFirstByUser(ctx, tx, user.ID)
+	if err == nil {
+		return member
+	}
+
+	return service.createOrganization(ctx, tx, user, "Personal")
+}
+
+// createOrganization creates an organization with the user as its owner
+func (service *OrganizationServiceImpl) createOrganization(ctx *fiber.Ctx, tx *gorm.DB, user domain.User, name string) *domain.OrganizationMember {
+	organizationEntity := service.OrganizationRepository.Create(ctx, tx, domain.Organization{Name: name})
+
+	member := service.OrganizationMemberRepository.Create(ctx, tx, domain.OrganizationMember{
+		OrganizationID: organizationEntity.ID,
+		UserID:         user.ID,
+		Role:           domain.OrganizationRoleOwner,
+	})
+	member.Organization = organizationEntity
+	member.User = user
+
+	return &member
+}
+
+func (service *OrganizationServiceImpl) requireRole(ctx *fiber.Ctx, tx *gorm.DB, user domain.User, role string) *domain.OrganizationMember {
+	return requireOrganizationRole(ctx, tx, service.OrganizationMemberRepository, user, role)
+}
+
+func (service *OrganizationServiceImpl) findMember(ctx *fiber.Ctx, tx *gorm.DB, organizationID int64, userID int) *domain.OrganizationMember {
+	return findOrganizationMember(ctx, tx, service.OrganizationMemberRepository, organizationID, userID)
+}
+
+// requireOrganizationRole returns the user's membership of the current
+// organization, members without the role get forbidden
+func requireOrganizationRole(ctx *fiber.Ctx, tx *gorm.DB, organizationMemberRepository repository.OrganizationMemberRepository, user domain.User, role string) *domain.OrganizationMember {
+	member := findOrganizationMember(ctx, tx, organizationMemberRepository, repository.CurrentOrganization(ctx).ID, user.ID)
+
+	if !member.Allows(role) {
+		panic(helper.NewForbiddenError("organization role " + role + " required"))
+	}
+
+	return member
+}
+
+func findOrganizationMember(ctx *fiber.Ctx, tx *gorm.DB, organizationMemberRepository repository.OrganizationMemberRepository, organizationID int64, userID int) *domain.OrganizationMember {
+	member, err := organizationMemberRepository.Find(ctx, tx, organizationID, userID)
+	if err != nil {
+		panic(helper.NewNotFoundError("member not found"))
+	}
+	return member
+}
+
+// requiredRoleToGrant is the role needed to give or take away role, admins
+// manage members and the owner manages admins
+func requiredRoleToGrant(role string) string {
+	if role == domain.OrganizationRoleMember {
+		return domain.OrganizationRoleAdmin
+	}
+	return domain.OrganizationRoleOwner
+}
+
+func toOrganizationResponse(organizationEntity *domain.Organization, role string) organization.OrganizationResponse {
+	return organization.OrganizationResponse{
+		ID:        organizationEntity.ID,
+		Name:      organizationEntity.Name,
+		Role:      role,
+		Settings:  toSettingsResponse(organizationEntity.Settings),
+		CreatedAt: organizationEntity.CreatedAt,
+	}
+}
+
+func toSettingsResponse(settings domain.OrganizationSettings) organization.SettingsResponse {
+	return organization.SettingsResponse{
+		MaxContacts:            settings.MaxContacts,
+		DefaultAddressBookName: settings.DefaultAddressBookName,
+	}
+}
+
+func toOrganizationMemberResponse(member *domain.OrganizationMember) organization.MemberResponse {
+	return organization.MemberResponse{
+		UserID:   member.UserID,
+		Username: member.User.Username,
+		Name:     member.User.Name,
+		Role:     member.Role,
+	}
+}
@@ -0,0 +1,9 @@
+package service
+
+import "github.com/gofiber/fiber/v2"
+
+// OutboxService dispatches the recorded domain events to the subscribers on
+// the event bus. It is run by the outbox dispatcher, outside of a request.
+type OutboxService interface {
+	DispatchPending(ctx *fiber.Ctx, limit int) int
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/event"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/repository"
+	"gorm.io/gorm"
+)
+
+// outboxClaimTimeout is how long a dispatcher holds an event it is
+// dispatching. Events of a dispatcher that stopped midway are dispatched
+// again after it.
+const outboxClaimTimeout = 5 * time.Minute
+
+// maxOutboxBackoff caps the wait between two dispatches of an event
+const maxOutboxBackoff = time.Hour
+
+// maxOutboxErrorLength is the size of outbox_events.error
+const maxOutboxErrorLength = 255
+
+type OutboxServiceImpl struct {
+	OutboxRepository repository.OutboxRepository
+	Bus              *event.Bus
+	DB               *gorm.DB
+	Options          OutboxOptions
+}
+
+func NewOutboxService(outboxRepository repository.OutboxRepository, bus *event.Bus, DB *gorm.DB, options OutboxOptions) OutboxService {
+	return &OutboxServiceImpl{
+		OutboxRepository: outboxRepository,
+		Bus:              bus,
+		DB:               DB,
+		Options:          options,
+	}
+}
+
+// DispatchPending publishes up to limit due events in the order they were
+// recorded and returns how many were found, less than limit means the outbox
+// is drained. An event is only marked dispatched once every subscriber
+// handled it, so a subscriber can see an event more than once.
+func (service *OutboxServiceImpl) DispatchPending(ctx *fiber.Ctx, limit int) int {
+	now := time.Now()
+	outboxEvents := service.OutboxRepository.FindDue(ctx, service.DB, now, limit)
+
+	for index := range outboxEvents {
+		outboxEvent := &outboxEvents[index]
+		if service.OutboxRepository.Claim(ctx, service.DB, outboxEvent, now, now.Add(outboxClaimTimeout)) {
+			service.dispatch(ctx, outboxEvent)
+		}
+	}
+
+	return len(outboxEvents)
+}
+
+// dispatch publishes a claimed event once and saves the outcome. An event
+// that fails is dispatched again later, it is never given up on.
+func (service *OutboxServiceImpl) dispatch(ctx *fiber.Ctx, outboxEvent *domain.OutboxEvent) {
+	err := service.publish(ctx, outboxEvent)
+	now := time.Now()
+
+	outboxEvent.Attempts++
+	if err == nil {
+		outboxEvent.NextAttemptAt = nil
+		outboxEvent.Error = ""
+		outboxEvent.DispatchedAt = &now
+	} else {
+		nextAttemptAt := now.Add(service.backoff(outboxEvent.Attempts))
+		outboxEvent.NextAttemptAt = &nextAttemptAt
+		outboxEvent.Error = err.Error()
+		if len(outboxEvent.Error) > maxOutboxErrorLength {
+			outboxEvent.Error = outboxEvent.Error[:maxOutboxErrorLength]
+		}
+	}
+
+	service.OutboxRepository.Update(ctx, service.DB, outboxEvent)
+}
+
+// publish hands the event to its subscribers as the organization it was
+// recorded in, and turns a panicking subscriber into an error
+func (service *OutboxServiceImpl) publish(ctx *fiber.Ctx, outboxEvent *domain.OutboxEvent) (err error) {
+	decoded, err := event.Decode(outboxEvent.Name, outboxEvent.Payload)
+	if err != nil {
+		return err
+	}
+
+	if outboxEvent.OrganizationID != nil {
+		ctx.Locals("organization", &domain.Organization{ID: *outboxEvent.OrganizationID})
+	} else {
+		ctx.Locals("organization", nil)
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%v", recovered)
+		}
+	}()
+
+	service.Bus.Publish(ctx, event.Message{
+		ID:             outboxEvent.EventID,
+		OrganizationID: outboxEvent.OrganizationID,
+		ActorID:        outboxEvent.ActorID,
+		OccurredAt:     outboxEvent.CreatedAt,
+		Event:          decoded,
+	})
+	return nil
+}
+
+// backoff is the wait before the dispatch after attempts failed ones
+func (service *OutboxServiceImpl) backoff(attempts int) time.Duration {
+	wait := service.Options.RetryBackoff
+	for i := 1; i < attempts && wait < maxOutboxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > maxOutboxBackoff {
+		wait = maxOutboxBackoff
+	}
+	return wait
+}
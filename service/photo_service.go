@@ -0,0 +1,12 @@
+package service
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+)
+
+type PhotoService interface {
+	Upload(ctx *fiber.Ctx, user domain.User, contactID int64, data []byte, expectedVersion int64) contact.ContactResponse
+	Read(ctx *fiber.Ctx, key string) []byte
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/blob"
+	"github.com/sorfian/go-contact-management-api/event"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+	"github.com/sorfian/go-contact-management-api/repository"
+	"gorm.io/gorm"
+)
+
+// PhotoOptions configures contact photos. Uploads over MaxSize bytes are
+// refused, and thumbnails are linked under BaseURL.
+type PhotoOptions struct {
+	MaxSize int
+	BaseURL string
+}
+
+type PhotoServiceImpl struct {
+	ContactRepository           repository.ContactRepository
+	AddressRepository           repository.AddressRepository
+	AddressBookMemberRepository repository.AddressBookMemberRepository
+	AuditLogRepository          repository.AuditLogRepository
+	ContactVersionRepository    repository.ContactVersionRepository
+	OutboxRepository            repository.OutboxRepository
+	BlobStore                   blob.BlobStore
+	DB                          *gorm.DB
+	Options                     PhotoOptions
+}
+
+func NewPhotoService(contactRepository repository.ContactRepository, addressRepository repository.AddressRepository, addressBookMemberRepository repository.AddressBookMemberRepository, auditLogRepository repository.AuditLogRepository, contactVersionRepository repository.ContactVersionRepository, outboxRepository repository.OutboxRepository, blobStore blob.BlobStore, DB *gorm.DB, options PhotoOptions) PhotoService {
+	return &PhotoServiceImpl{
+		ContactRepository:           contactRepository,
+		AddressRepository:           addressRepository,
+		AddressBookMemberRepository: addressBookMemberRepository,
+		AuditLogRepository:          auditLogRepository,
+		ContactVersionRepository:    contactVersionRepository,
+		OutboxRepository:            outboxRepository,
+		BlobStore:                   blobStore,
+		DB:                          DB,
+		Options:                     options,
+	}
+}
+
+// Upload replaces the photo of the contact. The photo is stored as a JPEG
+// thumbnail of every size under a new key, so URLs of the old photo cached
+// anywhere never show the new one. The thumbnails of the photo the contact
+// does not end up with are deleted once the transaction is over.
+func (service *PhotoServiceImpl) Upload(ctx *fiber.Ctx, user domain.User, contactID int64, data []byte, expectedVersion int64) contact.ContactResponse {
+	if len(data) > service.Options.MaxSize {
+		panic(fiber.NewError(fiber.StatusRequestEntityTooLarge, fmt.Sprintf("photo must be at most %d bytes", service.Options.MaxSize)))
+	}
+
+	var stalePhotoKey *string
+	defer func() {
+		if stalePhotoKey != nil {
+			service.deleteThumbnails(ctx, *stalePhotoKey)
+		}
+	}()
+
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	contactEntity := findAuthorizedContact(ctx, tx, service.ContactRepository, service.AddressBookMemberRepository, user, contactID, domain.PermissionEditor)
+	checkContactVersion(contactEntity, expectedVersion)
+
+	photo, err := helper.DecodePhoto(data)
+	if err != nil {
+		panic(err)
+	}
+
+	photoKey := "contacts/" + strconv.FormatInt(contactEntity.ID, 10) + "/" + helper.GenerateBatchID()
+	stalePhotoKey = &photoKey
+	for _, size := range helper.PhotoSizes {
+		err = service.BlobStore.Put(ctx.UserContext(), photoBlobKey(photoKey, size.Name), helper.MIMEImageJPEG, helper.EncodeThumbnail(photo, size.Pixels))
+		helper.PanicIfError(err)
+	}
+
+	before := toContactResponse(contactEntity, service.Options.BaseURL)
+	oldPhotoKey := contactEntity.PhotoKey
+
+	contactEntity.PhotoKey = &photoKey
+	updatedContact, err := service.ContactRepository.UpdatePhoto(ctx, tx, contactEntity)
+	panicIfContactConflict(err)
+	contactResponse := toContactResponse(&updatedContact, service.Options.BaseURL)
+
+	recordAudit(ctx, tx, service.AuditLogRepository, user, domain.AuditActionUpdate, domain.AuditEntityContact, updatedContact.ID, auditChanges(before, contactResponse))
+	recordContactVersion(ctx, tx, service.ContactVersionRepository, service.AddressRepository, user, &updatedContact)
+	recordEvent(ctx, tx, service.OutboxRepository, user, event.ContactUpdated{ContactResponse: contactResponse})
+
+	stalePhotoKey = oldPhotoKey
+	return contactResponse
+}
+
+// Read returns a stored thumbnail by the key its URL ends with
+func (service *PhotoServiceImpl) Read(ctx *fiber.Ctx, key string) []byte {
+	if !isPhotoBlobKey(key) {
+		panic(helper.NewNotFoundError("photo not found"))
+	}
+
+	data, err := service.BlobStore.Get(ctx.UserContext(), key)
+	if errors.Is(err, blob.ErrNotFound) {
+		panic(helper.NewNotFoundError("photo not found"))
+	}
+	helper.PanicIfError(err)
+
+	return data
+}
+
+// deleteThumbnails deletes every thumbnail of a photo. Failures only leave
+// unused blobs behind, so they are logged rather than failing the request.
+func (service *PhotoServiceImpl) deleteThumbnails(ctx *fiber.Ctx, photoKey string) {
+	for _, size := range helper.PhotoSizes {
+		if err := service.BlobStore.Delete(ctx.UserContext(), photoBlobKey(photoKey, size.Name)); err != nil {
+			log.Printf("photo: deleting %s: %v", photoBlobKey(photoKey, size.Name), err)
+		}
+	}
+}
+
+// photoBlobKey returns the key of the thumbnail of a size of a photo
+func photoBlobKey(photoKey string, size string) string {
+	return photoKey + "/" + size + ".jpg"
+}
+
+// isPhotoBlobKey reports whether key is the key of a thumbnail, so nothing
+// else in the store can be read through photo URLs
+func isPhotoBlobKey(key string) bool {
+	parts := strings.Split(key, "/")
+	if len(parts) != 4 || parts[0] != "contacts" || parts[2] == "" {
+		return false
+	}
+	if _, err := strconv.ParseInt(parts[1], 10, 64); err != nil {
+		return false
+	}
+	for _, size := range helper.PhotoSizes {
+		if parts[3] == size.Name+".jpg" {
+			return true
+		}
+	}
+	return false
+}
+
+// photoURLs returns the URLs of the thumbnails of a photo by size, nil
+// without a photo
+func photoURLs(photoKey *string, baseURL string) map[string]string {
+	if photoKey == nil {
+		return nil
+	}
+
+	urls := make(map[string]string, len(helper.PhotoSizes))
+	for _, size := range helper.PhotoSizes {
+		urls[size.Name] = strings.TrimSuffix(baseURL, "/") + "/" + photoBlobKey(*photoKey, size.Name)
+	}
+	return urls
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/delta"
+)
+
+// SyncService lets offline clients catch up with the changes made since they
+// last synced and send the changes they made in the meantime
+type SyncService interface {
+	Pull(ctx *fiber.Ctx, user domain.User, token string) delta.PullResponse
+	Push(ctx *fiber.Ctx, user domain.User, request *delta.PushRequest) delta.PushResult
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/address"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+	"github.com/sorfian/go-contact-management-api/model/web/delta"
+	"github.com/sorfian/go-contact-management-api/repository"
+	"gorm.io/gorm"
+)
+
+// SyncGracePeriod is how far before a pull the token it returns reaches back.
+// A change committed just after the pull read, but stamped before it, is in
+// the next pull. Clients keep the higher version of what they get twice.
+const SyncGracePeriod = 5 * time.Second
+
+type SyncServiceImpl struct {
+	ContactService              ContactService
+	AddressService              AddressService
+	ContactRepository           repository.ContactRepository
+	AddressRepository           repository.AddressRepository
+	AddressBookMemberRepository repository.AddressBookMemberRepository
+	DB                          *gorm.DB
+	Validate                    *validator.Validate
+	PhotoOptions                PhotoOptions
+}
+
+func NewSyncService(contactService ContactService, addressService AddressService, contactRepository repository.ContactRepository, addressRepository repository.AddressRepository, addressBookMemberRepository repository.AddressBookMemberRepository, DB *gorm.DB, validate *validator.Validate, photoOptions PhotoOptions) SyncService {
+	return &SyncServiceImpl{
+		ContactService:              contactService,
+		AddressService:              addressService,
+		ContactRepository:           contactRepository,
+		AddressRepository:           addressRepository,
+		AddressBookMemberRepository: addressBookMemberRepository,
+		DB:                          DB,
+		Validate:                    validate,
+		PhotoOptions:                photoOptions,
+	}
+}
+
+// Pull returns the contacts and addresses changed since the token, or all of
+// them without one. Everything in an address book the user joined since is
+// returned as well, and a contact comes with all its addresses, so one moved
+// into view is complete.
+func (service *SyncServiceImpl) Pull(ctx *fiber.Ctx, user domain.User, token string) delta.PullResponse {
+	since := decodeSyncToken(token)
+	now := time.Now()
+
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	response := delta.PullResponse{
+		Token:            encodeSyncToken(now.Add(-SyncGracePeriod)),
+		AddressBookIDs:   []int64{},
+		Contacts:         []contact.ContactResponse{},
+		Addresses:        []delta.AddressResponse{},
+		DeletedContacts:  []int64{},
+		DeletedAddresses: []int64{},
+	}
+
+	visible := map[int64]bool{}
+	joined := []int64{}
+	for _, member := range service.AddressBookMemberRepository.FindAllByUser(ctx, tx, user.ID) {
+		visible[member.AddressBookID] = true
+		response.AddressBookIDs = append(response.AddressBookIDs, member.AddressBookID)
+		if since.IsZero() || !member.CreatedAt.Before(since) {
+			joined = append(joined, member.AddressBookID)
+		}
+	}
+
+	contacts := service.ContactRepository.FindAllByAddressBooks(ctx, tx, joined)
+	if !since.IsZero() {
+		sent := map[int64]bool{}
+		for _, contactEntity := range contacts {
+			sent[contactEntity.ID] = true
+		}
+
+		// A contact moved to an address book the user is not a member of is
+		// gone for them, the same as a deleted one
+		for _, contactEntity := range service.ContactRepository.FindChangedSince(ctx, tx, response.AddressBookIDs, since) {
+			switch {
+			case sent[contactEntity.ID]:
+			case contactEntity.DeletedAt.Valid || !visible[contactEntity.AddressBookID]:
+				response.DeletedContacts = append(response.DeletedContacts, contactEntity.ID)
+			default:
+				contacts = append(contacts, contactEntity)
+			}
+		}
+	}
+
+	contactIDs := make([]int64, 0, len(contacts))
+	for _, contactEntity := range contacts {
+		contactIDs = append(contactIDs, contactEntity.ID)
+		response.Contacts = append(response.Contacts, toContactResponse(&contactEntity, service.PhotoOptions.BaseURL))
+	}
+
+	sentAddresses := map[int64]bool{}
+	for _, addressEntity := range service.AddressRepository.FindAllByContacts(ctx, tx, contactIDs) {
+		sentAddresses[addressEntity.ID] = true
+		response.Addresses = append(response.Addresses, toSyncAddressResponse(&addressEntity))
+	}
+
+	if !since.IsZero() {
+		for _, addressEntity := range service.AddressRepository.FindChangedSince(ctx, tx, response.AddressBookIDs, since) {
+			switch {
+			case sentAddresses[addressEntity.ID]:
+			case addressEntity.DeletedAt.Valid || addressEntity.Contact.DeletedAt.Valid || !visible[addressEntity.Contact.AddressBookID]:
+				response.DeletedAddresses = append(response.DeletedAddresses, addressEntity.ID)
+			default:
+				response.Addresses = append(response.Addresses, toSyncAddressResponse(&addressEntity))
+			}
+		}
+	}
+
+	return response
+}
+
+// Push applies the changes of the client in order. Each one commits on its
+// own, a change failing does not undo the others. A change to a version the
+// server no longer has is a conflict, reported with the server copy.
+func (service *SyncServiceImpl) Push(ctx *fiber.Ctx, user domain.User, request *delta.PushRequest) delta.PushResult {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	result := delta.PushResult{Results: make([]delta.ChangeResult, 0, len(request.Changes))}
+
+	// The contacts created by this push by client ID, 0 when the create failed
+	created := map[string]int64{}
+
+	for index, change := range request.Changes {
+		changeResult := service.apply(ctx, user, index, change, created)
+
+		switch {
+		case changeResult.Code == fiber.StatusConflict:
+			result.Conflicts++
+		case changeResult.Code < fiber.StatusBadRequest:
+			result.Applied++
+		}
+
+		if change.Entity == delta.EntityContact && change.Operation == delta.OperationCreate && change.ClientID != "" {
+			createdContact, _ := changeResult.Data.(contact.ContactResponse)
+			created[change.ClientID] = createdContact.ID
+		}
+
+		result.Results = append(result.Results, changeResult)
+	}
+
+	return result
+}
+
+// apply runs one change, turning a panic into the same error envelope the
+// single-item endpoints respond with
+func (service *SyncServiceImpl) apply(ctx *fiber.Ctx, user domain.User, index int, change delta.Change, created map[string]int64) (result delta.ChangeResult) {
+	result = delta.ChangeResult{Index: index, Entity: change.Entity, Operation: change.Operation, ClientID: change.ClientID}
+
+	defer func() {
+		if r := recover(); r != nil {
+			if conflict, ok := r.(helper.PreconditionFailedError); ok {
+				result.Code = fiber.StatusConflict
+				result.Status = helper.GetStatusText(fiber.StatusConflict)
+				result.Data = delta.Conflict{Message: conflict.Err, Current: service.current(ctx, user, change, created)}
+				return
+			}
+
+			err, ok := r.(error)
+			if !ok {
+				err = fmt.Errorf("%v", r)
+			}
+			response := helper.NewErrorResponse(err)
+			result.Code = response.Code
+			result.Status = response.Status
+			result.Data = response.Data
+		}
+	}()
+
+	if change.Operation == delta.OperationUpdate && len(change.Data) == 0 {
+		panic(helper.NewBadRequestError("change data is required"))
+	}
+
+	switch change.Entity {
+	case delta.EntityContact:
+		result.Code, result.Data = service.applyContact(ctx, user, change)
+	case delta.EntityAddress:
+		result.Code, result.Data = service.applyAddress(ctx, user, change, syncContactID(change, created))
+	}
+	result.Status = helper.GetStatusText(result.Code)
+
+	return result
+}
+
+func (service *SyncServiceImpl) applyContact(ctx *fiber.Ctx, user domain.User, change delta.Change) (int, interface{}) {
+	switch change.Operation {
+	case delta.OperationCreate:
+		request := contact.ContactCreateRequest{}
+		decodeBulkData(change.Data, &request)
+		return fiber.StatusCreated, service.ContactService.Create(ctx, user, &request)
+	case delta.OperationUpdate:
+		return fiber.StatusOK, service.ContactService.Update(ctx, user, change.ID, change.Data, change.Version)
+	default:
+		service.ContactService.Delete(ctx, user, change.ID, change.Version)
+		return fiber.StatusOK, "Contact deleted successfully"
+	}
+}
+
+func (service *SyncServiceImpl) applyAddress(ctx *fiber.Ctx, user domain.User, change delta.Change, contactID int64) (int, interface{}) {
+	switch change.Operation {
+	case delta.OperationCreate:
+		request := address.AddressCreateRequest{}
+		decodeBulkData(change.Data, &request)
+		return fiber.StatusCreated, service.AddressService.Create(ctx, user, contactID, &request)
+	case delta.OperationUpdate:
+		return fiber.StatusOK, service.AddressService.Update(ctx, user, contactID, change.ID, change.Data, change.Version)
+	default:
+		service.AddressService.Delete(ctx, user, contactID, change.ID, change.Version)
+		return fiber.StatusOK, "Address deleted successfully"
+	}
+}
+
+// current returns the server copy of what a conflicting change changed, nil
+// when it is gone
+func (service *SyncServiceImpl) current(ctx *fiber.Ctx, user domain.User, change delta.Change, created map[string]int64) (current interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(helper.NotFoundError); !ok {
+				panic(r)
+			}
+			current = nil
+		}
+	}()
+
+	if change.Entity == delta.EntityContact {
+		return service.ContactService.Get(ctx, user, change.ID, nil, nil)
+	}
+	return service.AddressService.Get(ctx, user, syncContactID(change, created), change.ID, nil)
+}
+
+// syncContactID returns the contact of an address change
+func syncContactID(change delta.Change, created map[string]int64) int64 {
+	if change.ContactClientID == "" {
+		if change.ContactID == 0 {
+			panic(helper.NewBadRequestError("contact_id or contact_client_id is required"))
+		}
+		return change.ContactID
+	}
+
+	contactID, ok := created[change.ContactClientID]
+	if !ok {
+		panic(helper.NewBadRequestError("contact_client_id must be the client_id of a contact created earlier in the push"))
+	}
+	if contactID == 0 {
+		panic(fiber.NewError(fiber.StatusFailedDependency, "the contact of the address was not created"))
+	}
+	return contactID
+}
+
+// encodeSyncToken returns the token of a pull that reads the changes since
+// the given time. Clients treat it as opaque.
+func encodeSyncToken(since time.Time) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(since.Unix(), 10)))
+}
+
+// decodeSyncToken returns the time a sync token reads the changes since,
+// the zero time for no token
+func decodeSyncToken(token string) time.Time {
+	if token == "" {
+		return time.Time{}
+	}
+
+	since, ok := parseSyncToken(token)
+	if !ok {
+		panic(helper.NewBadRequestError("since must be a token returned by an earlier sync"))
+	}
+	return since
+}
+
+// parseSyncToken returns the time of a token, and whether it is one
+func parseSyncToken(token string) (time.Time, bool) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	seconds, err := strconv.ParseInt(string(decoded), 10, 64)
+	if err != nil || seconds < 1 {
+		return time.Time{}, false
+	}
+	return time.Unix(seconds, 0), true
+}
+
+func toSyncAddressResponse(addressEntity *domain.Address) delta.AddressResponse {
+	return delta.AddressResponse{
+		ContactID:       addressEntity.ContactID,
+		AddressResponse: toAddressResponse(addressEntity),
+	}
+}
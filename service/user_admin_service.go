@@ -0,0 +1,17 @@
+package service
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/web/user"
+)
+
+// UserAdminService is user management for operators, it is not exposed over HTTP
+type UserAdminService interface {
+	List(ctx *fiber.Ctx, includeDeleted bool) []user.UserAdminResponse
+	ResetPassword(ctx *fiber.Ctx, request *user.UserResetPasswordRequest)
+	RevokeTokens(ctx *fiber.Ctx, username string)
+	SetRole(ctx *fiber.Ctx, username string, request *user.UserRoleRequest)
+	Disable(ctx *fiber.Ctx, username string)
+	Enable(ctx *fiber.Ctx, username string)
+	Delete(ctx *fiber.Ctx, username string, hard bool)
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/user"
+	"github.com/sorfian/go-contact-management-api/repository"
+	"gorm.io/gorm"
+)
+
+type UserAdminServiceImpl struct {
+	UserRepository        repository.UserRepository
+	AdminActionRepository repository.AdminActionRepository
+	DB                    *gorm.DB
+	Validate              *validator.Validate
+}
+
+func NewUserAdminService(userRepository repository.UserRepository, adminActionRepository repository.AdminActionRepository, DB *gorm.DB, validate *validator.Validate) UserAdminService {
+	return &UserAdminServiceImpl{
+		UserRepository:        userRepository,
+		AdminActionRepository: adminActionRepository,
+		DB:                    DB,
+		Validate:              validate,
+	}
+}
+
+func (service *UserAdminServiceImpl) List(ctx *fiber.Ctx, includeDeleted bool) []user.UserAdminResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	scope := tx
+	if includeDeleted {
+		scope = tx.Unscoped()
+	}
+	users := service.UserRepository.FindAll(ctx, scope)
+
+	userResponses := []user.UserAdminResponse{}
+	for _, userEntity := range users {
+		userResponses = append(userResponses, toUserAdminResponse(&userEntity))
+	}
+
+	return userResponses
+}
+
+func (service *UserAdminServiceImpl) ResetPassword(ctx *fiber.Ctx, request *user.UserResetPasswordRequest) {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	userEntity := service.findUser(ctx, tx, request.Username)
+
+	hashedPassword, err := helper.HashPassword(request.Password)
+	helper.PanicIfError(err)
+
+	// Sessions opened with the old password end with it
+	userEntity.Password = hashedPassword
+	userEntity.Token = ""
+	userEntity.TokenExp = 0
+
+	service.UserRepository.Update(ctx, tx, userEntity)
+	service.record(ctx, tx, userEntity, domain.AdminActionResetPassword, "")
+}
+
+func (service *UserAdminServiceImpl) RevokeTokens(ctx *fiber.Ctx, username string) {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	userEntity := service.findUser(ctx, tx, username)
+	userEntity.Token = ""
+	userEntity.TokenExp = 0
+
+	service.UserRepository.Update(ctx, tx, userEntity)
+	service.record(ctx, tx, userEntity, domain.AdminActionRevokeTokens, "")
+}
+
+func (service *UserAdminServiceImpl) SetRole(ctx *fiber.Ctx, username string, request *user.UserRoleRequest) {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	userEntity := service.findUser(ctx, tx, username)
+	details := fmt.Sprintf("%s -> %s", userEntity.Role, request.Role)
+	userEntity.Role = request.Role
+
+	service.UserRepository.Update(ctx, tx, userEntity)
+	service.record(ctx, tx, userEntity, domain.AdminActionSetRole, details)
+}
+
+// Disable stops the user from logging in and ends their session
+func (service *UserAdminServiceImpl) Disable(ctx *fiber.Ctx, username string) {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	userEntity := service.findUser(ctx, tx, username)
+	if userEntity.DisabledAt == nil {
+		disabledAt := time.Now()
+		userEntity.DisabledAt = &disabledAt
+	}
+	userEntity.Token = ""
+	userEntity.TokenExp = 0
+
+	service.UserRepository.Update(ctx, tx, userEntity)
+	service.record(ctx, tx, userEntity, domain.AdminActionDisable, "")
+}
+
+// Enable lets a disabled user log in again
+func (service *UserAdminServiceImpl) Enable(ctx *fiber.Ctx, username string) {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	userEntity := service.findUser(ctx, tx, username)
+	userEntity.DisabledAt = nil
+
+	service.UserRepository.Update(ctx, tx, userEntity)
+	service.record(ctx, tx, userEntity, domain.AdminActionEnable, "")
+}
+
+// Delete soft deletes the user so it can no longer log in. A hard delete also
+// removes users that were soft deleted before, along with their contacts and
+// addresses.
+func (service *UserAdminServiceImpl) Delete(ctx *fiber.Ctx, username string, hard bool) {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	if hard {
+		tx = tx.Unscoped()
+	}
+
+	userEntity := service.findUser(ctx, tx, username)
+	userEntity.Token = ""
+	userEntity.TokenExp = 0
+	if !hard {
+		service.UserRepository.Update(ctx, tx, userEntity)
+	}
+
+	err := service.UserRepository.Delete(ctx, tx, userEntity)
+	helper.PanicIfError(err)
+
+	action := domain.AdminActionDelete
+	if hard {
+		action = domain.AdminActionHardDelete
+	}
+	service.record(ctx, tx, userEntity, action, userEntity.Username)
+}
+
+// record logs a change made from the command line, which has no admin user
+func (service *UserAdminServiceImpl) record(ctx *fiber.Ctx, tx *gorm.DB, target *domain.User, action string, details string) {
+	service.AdminActionRepository.Create(ctx, tx, domain.AdminAction{
+		TargetUserID: target.ID,
+		Action:       action,
+		Details:      details,
+	})
+}
+
+func (service *UserAdminServiceImpl) findUser(ctx *fiber.Ctx, tx *gorm.DB, username string) *domain.User {
+	userEntity, err := service.UserRepository.FindByUsername(ctx, tx, username)
+	if err != nil {
+		panic(helper.NewNotFoundError("user not found"))
+	}
+	return userEntity
+}
@@ -12,5 +12,5 @@ type UserService interface {
 	Login(ctx *fiber.Ctx, request *user.UserLoginRequest) web.TokenResponse
 	Get(ctx *fiber.Ctx, user domain.User) user.UserResponse
 	Logout(ctx *fiber.Ctx, user domain.User)
-	Update(ctx *fiber.Ctx, user domain.User, request user.UserUpdateRequest) user.UserResponse
+	Update(ctx *fiber.Ctx, user domain.User, patch []byte) user.UserResponse
 }
@@ -3,6 +3,7 @@ package service
 import (
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/event"
 	"github.com/sorfian/go-contact-management-api/helper"
 	"github.com/sorfian/go-contact-management-api/model/domain"
 	"github.com/sorfian/go-contact-management-api/model/web"
@@ -12,13 +13,15 @@ import (
 )
 
 type UserServiceImpl struct {
-	UserRepository repository.UserRepository
-	DB             *gorm.DB
-	Validate       *validator.Validate
+	UserRepository     repository.UserRepository
+	AuditLogRepository repository.AuditLogRepository
+	OutboxRepository   repository.OutboxRepository
+	DB                 *gorm.DB
+	Validate           *validator.Validate
 }
 
-func NewUserService(userRepository repository.UserRepository, DB *gorm.DB, validate *validator.Validate) UserService {
-	return &UserServiceImpl{UserRepository: userRepository, DB: DB, Validate: validate}
+func NewUserService(userRepository repository.UserRepository, auditLogRepository repository.AuditLogRepository, outboxRepository repository.OutboxRepository, DB *gorm.DB, validate *validator.Validate) UserService {
+	return &UserServiceImpl{UserRepository: userRepository, AuditLogRepository: auditLogRepository, OutboxRepository: outboxRepository, DB: DB, Validate: validate}
 }
 
 func (service *UserServiceImpl) Register(ctx *fiber.Ctx, request *user.UserRegisterRequest) web.TokenResponse {
@@ -51,6 +54,11 @@ func (service *UserServiceImpl) Register(ctx *fiber.Ctx, request *user.UserRegis
 
 	createdUser := service.UserRepository.Create(ctx, tx, userData)
 
+	// A new user is the actor of their own registration
+	changes := auditChanges(nil, toUserResponse(&createdUser))
+	changes["password"] = domain.AuditChange{After: redactedAuditValue}
+	recordAudit(ctx, tx, service.AuditLogRepository, createdUser, domain.AuditActionCreate, domain.AuditEntityUser, int64(createdUser.ID), changes)
+
 	return web.TokenResponse{Token: createdUser.Token, TokenExp: createdUser.TokenExp}
 }
 
@@ -71,6 +79,10 @@ func (service *UserServiceImpl) Login(ctx *fiber.Ctx, request *user.UserLoginReq
 		panic(helper.NewNotFoundError("password is incorrect"))
 	}
 
+	if newUser.DisabledAt != nil {
+		panic(helper.NewForbiddenError("account is disabled"))
+	}
+
 	token, err := helper.GenerateToken()
 	helper.PanicIfError(err)
 
@@ -81,6 +93,8 @@ func (service *UserServiceImpl) Login(ctx *fiber.Ctx, request *user.UserLoginReq
 
 	updatedUser := service.UserRepository.Update(ctx, tx, newUser)
 
+	recordEvent(ctx, tx, service.OutboxRepository, updatedUser, event.UserLoggedIn{UserID: updatedUser.ID, Username: updatedUser.Username})
+
 	return web.TokenResponse{
 		Token:    updatedUser.Token,
 		TokenExp: updatedUser.TokenExp,
@@ -88,10 +102,7 @@ func (service *UserServiceImpl) Login(ctx *fiber.Ctx, request *user.UserLoginReq
 }
 
 func (service *UserServiceImpl) Get(ctx *fiber.Ctx, newUser domain.User) user.UserResponse {
-	return user.UserResponse{
-		Username: newUser.Username,
-		Name:     newUser.Name,
-	}
+	return toUserResponse(&newUser)
 }
 
 func (service *UserServiceImpl) Logout(ctx *fiber.Ctx, user domain.User) {
@@ -104,27 +115,43 @@ func (service *UserServiceImpl) Logout(ctx *fiber.Ctx, user domain.User) {
 	service.UserRepository.Update(ctx, tx, &user)
 }
 
-func (service *UserServiceImpl) Update(ctx *fiber.Ctx, newUser domain.User, request user.UserUpdateRequest) user.UserResponse {
-	err := service.Validate.Struct(request)
+func (service *UserServiceImpl) Update(ctx *fiber.Ctx, newUser domain.User, patch []byte) user.UserResponse {
+	// The password is write-only, so the merged document starts without it
+	merged := user.UserUpdateRequest{Name: newUser.Name}
+	err := helper.ApplyMergePatch(&merged, patch)
+	helper.PanicIfError(err)
+
+	err = service.Validate.Struct(merged)
 	helper.PanicIfError(err)
 
 	tx := service.DB.Begin()
 	defer helper.CommitOrRollback(tx)
 
-	if request.Name != "" {
-		newUser.Name = request.Name
-	}
+	before := toUserResponse(&newUser)
+	newUser.Name = merged.Name
 
-	if request.Password != "" {
-		hashedPassword, err := helper.HashPassword(request.Password)
+	if merged.Password != "" {
+		hashedPassword, err := helper.HashPassword(merged.Password)
 		helper.PanicIfError(err)
 		newUser.Password = hashedPassword
 	}
 
 	updatedUser := service.UserRepository.Update(ctx, tx, &newUser)
+	userResponse := toUserResponse(&updatedUser)
+
+	changes := auditChanges(before, userResponse)
+	if merged.Password != "" {
+		changes["password"] = domain.AuditChange{Before: redactedAuditValue, After: redactedAuditValue}
+	}
+	recordAudit(ctx, tx, service.AuditLogRepository, newUser, domain.AuditActionUpdate, domain.AuditEntityUser, int64(newUser.ID), changes)
+
+	return userResponse
+}
 
+func toUserResponse(userEntity *domain.User) user.UserResponse {
 	return user.UserResponse{
-		Username: updatedUser.Username,
-		Name:     updatedUser.Name,
+		Username: userEntity.Username,
+		Name:     userEntity.Name,
+		Role:     userEntity.Role,
 	}
 }
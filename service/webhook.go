@@ -0,0 +1,92 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/webhook"
+)
+
+// Headers of a webhook delivery
+const (
+	WebhookHeaderEvent     = "X-Webhook-Event"
+	WebhookHeaderEventID   = "X-Webhook-ID"
+	WebhookHeaderDelivery  = "X-Webhook-Delivery"
+	WebhookHeaderSignature = "X-Webhook-Signature"
+)
+
+// WebhookOptions configures how deliveries are sent. A failed delivery is
+// retried after RetryBackoff, doubling with every attempt, until it failed
+// MaxAttempts times. Webhook URLs whose host is not public are refused unless
+// AllowPrivateNetworks is set, the Client refuses to connect to them as well.
+type WebhookOptions struct {
+	Client               *http.Client
+	MaxAttempts          int
+	RetryBackoff         time.Duration
+	PollInterval         time.Duration
+	AllowPrivateNetworks bool
+}
+
+// checkWebhookURL refuses a webhook URL whose host is not public or does not
+// resolve, unless the options allow private networks
+func checkWebhookURL(ctx *fiber.Ctx, options WebhookOptions, rawURL string) {
+	if options.AllowPrivateNetworks {
+		return
+	}
+
+	webhookURL, err := url.Parse(rawURL)
+	helper.PanicIfError(err)
+
+	err = helper.CheckPublicHost(ctx.UserContext(), webhookURL.Hostname())
+	if errors.Is(err, helper.ErrNotPublicAddress) {
+		panic(helper.NewBadRequestError("url must not be a loopback, private, link-local or reserved address"))
+	}
+	if err != nil {
+		panic(helper.NewBadRequestError("url host does not resolve"))
+	}
+}
+
+// signWebhookPayload returns the X-Webhook-Signature of a payload, the hex
+// HMAC-SHA256 of the body keyed with the webhook secret
+func signWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func toWebhookResponse(webhookEntity *domain.Webhook) webhook.WebhookResponse {
+	return webhook.WebhookResponse{
+		ID:        webhookEntity.ID,
+		URL:       webhookEntity.URL,
+		Events:    webhookEntity.Events,
+		Active:    webhookEntity.Active,
+		CreatedBy: webhookEntity.CreatedBy,
+		CreatedAt: webhookEntity.CreatedAt,
+		UpdatedAt: webhookEntity.UpdatedAt,
+	}
+}
+
+func toDeliveryResponse(delivery *domain.WebhookDelivery) webhook.DeliveryResponse {
+	return webhook.DeliveryResponse{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		EventID:        delivery.EventID,
+		Event:          delivery.Event,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		Error:          delivery.Error,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
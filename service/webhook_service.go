@@ -0,0 +1,23 @@
+package service
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/event"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/webhook"
+)
+
+// WebhookService manages the webhooks of the organization the request runs in
+// and sends their deliveries. QueueDeliveries subscribes to the event bus and
+// DeliverDue is run by the delivery worker, both outside of a request.
+type WebhookService interface {
+	Create(ctx *fiber.Ctx, user domain.User, request *webhook.WebhookCreateRequest) webhook.WebhookResponse
+	GetAll(ctx *fiber.Ctx, user domain.User) []webhook.WebhookResponse
+	Get(ctx *fiber.Ctx, user domain.User, webhookID int64) webhook.WebhookResponse
+	Update(ctx *fiber.Ctx, user domain.User, webhookID int64, patch []byte) webhook.WebhookResponse
+	Delete(ctx *fiber.Ctx, user domain.User, webhookID int64)
+	GetDeliveries(ctx *fiber.Ctx, user domain.User, webhookID int64, status string, page int, size int) webhook.DeliveryListResult
+	Redeliver(ctx *fiber.Ctx, user domain.User, webhookID int64, deliveryID int64) webhook.DeliveryResponse
+	QueueDeliveries(ctx *fiber.Ctx, message event.Message)
+	DeliverDue(ctx *fiber.Ctx, limit int) int
+}
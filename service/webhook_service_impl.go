@@ -0,0 +1,348 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/event"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/webhook"
+	"github.com/sorfian/go-contact-management-api/repository"
+	"gorm.io/gorm"
+)
+
+// webhookClaimTimeout is how long a worker holds a delivery it is sending.
+// Deliveries of a worker that stopped mid-attempt are sent again after it.
+const webhookClaimTimeout = 5 * time.Minute
+
+// maxWebhookBackoff caps the wait between two attempts
+const maxWebhookBackoff = 6 * time.Hour
+
+// maxWebhookErrorLength is the size of webhook_deliveries.error
+const maxWebhookErrorLength = 255
+
+type WebhookServiceImpl struct {
+	WebhookRepository            repository.WebhookRepository
+	WebhookDeliveryRepository    repository.WebhookDeliveryRepository
+	OrganizationMemberRepository repository.OrganizationMemberRepository
+	DB                           *gorm.DB
+	Validate                     *validator.Validate
+	Options                      WebhookOptions
+}
+
+func NewWebhookService(webhookRepository repository.WebhookRepository, webhookDeliveryRepository repository.WebhookDeliveryRepository, organizationMemberRepository repository.OrganizationMemberRepository, DB *gorm.DB, validate *validator.Validate, options WebhookOptions) WebhookService {
+	return &WebhookServiceImpl{
+		WebhookRepository:            webhookRepository,
+		WebhookDeliveryRepository:    webhookDeliveryRepository,
+		OrganizationMemberRepository: organizationMemberRepository,
+		DB:                           DB,
+		Validate:                     validate,
+		Options:                      options,
+	}
+}
+
+func (service *WebhookServiceImpl) Create(ctx *fiber.Ctx, user domain.User, request *webhook.WebhookCreateRequest) webhook.WebhookResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+	checkWebhookURL(ctx, service.Options, request.URL)
+
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	requireOrganizationRole(ctx, tx, service.OrganizationMemberRepository, user, domain.OrganizationRoleAdmin)
+
+	active := true
+	if request.Active != nil {
+		active = *request.Active
+	}
+
+	createdWebhook := service.WebhookRepository.Create(ctx, tx, domain.Webhook{
+		CreatedBy: &user.ID,
+		URL:       request.URL,
+		Secret:    request.Secret,
+		Events:    request.Events,
+		Active:    active,
+	})
+
+	return toWebhookResponse(&createdWebhook)
+}
+
+func (service *WebhookServiceImpl) GetAll(ctx *fiber.Ctx, user domain.User) []webhook.WebhookResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	requireOrganizationRole(ctx, tx, service.OrganizationMemberRepository, user, domain.OrganizationRoleAdmin)
+
+	webhookResponses := []webhook.WebhookResponse{}
+	for _, webhookEntity := range service.WebhookRepository.FindAll(ctx, tx) {
+		webhookResponses = append(webhookResponses, toWebhookResponse(&webhookEntity))
+	}
+
+	return webhookResponses
+}
+
+func (service *WebhookServiceImpl) Get(ctx *fiber.Ctx, user domain.User, webhookID int64) webhook.WebhookResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	requireOrganizationRole(ctx, tx, service.OrganizationMemberRepository, user, domain.OrganizationRoleAdmin)
+
+	return toWebhookResponse(service.findWebhook(ctx, tx, webhookID))
+}
+
+// Update applies a JSON merge patch to the webhook
+func (service *WebhookServiceImpl) Update(ctx *fiber.Ctx, user domain.User, webhookID int64, patch []byte) webhook.WebhookResponse {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	requireOrganizationRole(ctx, tx, service.OrganizationMemberRepository, user, domain.OrganizationRoleAdmin)
+
+	webhookEntity := service.findWebhook(ctx, tx, webhookID)
+
+	merged := webhook.WebhookUpdateRequest{
+		URL:    webhookEntity.URL,
+		Secret: webhookEntity.Secret,
+		Events: webhookEntity.Events,
+		Active: webhookEntity.Active,
+	}
+	err := helper.ApplyMergePatch(&merged, patch)
+	helper.PanicIfError(err)
+
+	err = service.Validate.Struct(merged)
+	helper.PanicIfError(err)
+	checkWebhookURL(ctx, service.Options, merged.URL)
+
+	webhookEntity.URL = merged.URL
+	webhookEntity.Secret = merged.Secret
+	webhookEntity.Events = merged.Events
+	webhookEntity.Active = merged.Active
+	updatedWebhook := service.WebhookRepository.Update(ctx, tx, webhookEntity)
+
+	return toWebhookResponse(&updatedWebhook)
+}
+
+func (service *WebhookServiceImpl) Delete(ctx *fiber.Ctx, user domain.User, webhookID int64) {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	requireOrganizationRole(ctx, tx, service.OrganizationMemberRepository, user, domain.OrganizationRoleAdmin)
+
+	webhookEntity := service.findWebhook(ctx, tx, webhookID)
+
+	err := service.WebhookRepository.Delete(ctx, tx, webhookEntity)
+	helper.PanicIfError(err)
+}
+
+// GetDeliveries returns a page of the deliveries of the webhook, newest first
+func (service *WebhookServiceImpl) GetDeliveries(ctx *fiber.Ctx, user domain.User, webhookID int64, status string, page int, size int) webhook.DeliveryListResult {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	requireOrganizationRole(ctx, tx, service.OrganizationMemberRepository, user, domain.OrganizationRoleAdmin)
+
+	webhookEntity := service.findWebhook(ctx, tx, webhookID)
+
+	deliveries, totalItem := service.WebhookDeliveryRepository.FindAll(ctx, tx, webhookEntity.ID, status, (page-1)*size, size)
+
+	deliveryResponses := []webhook.DeliveryResponse{}
+	for _, delivery := range deliveries {
+		deliveryResponses = append(deliveryResponses, toDeliveryResponse(&delivery))
+	}
+
+	return webhook.DeliveryListResult{
+		Deliveries: deliveryResponses,
+		Paging: web.PagingResponse{
+			Page:      page,
+			Size:      size,
+			TotalPage: (totalItem + size - 1) / size,
+			TotalItem: totalItem,
+		},
+	}
+}
+
+// Redeliver queues the payload of an earlier delivery again as a new delivery
+// and sends it right away. When that attempt fails the new delivery is retried
+// like any other.
+func (service *WebhookServiceImpl) Redeliver(ctx *fiber.Ctx, user domain.User, webhookID int64, deliveryID int64) webhook.DeliveryResponse {
+	delivery := service.queueRedelivery(ctx, user, webhookID, deliveryID)
+
+	now := time.Now()
+	if service.WebhookDeliveryRepository.Claim(ctx, service.DB, delivery, now, now.Add(webhookClaimTimeout)) {
+		service.attempt(ctx, delivery)
+	}
+
+	return toDeliveryResponse(delivery)
+}
+
+// QueueDeliveries queues a delivery of the event for every active webhook of
+// the organization that subscribes to it. An event the dispatcher delivers
+// again is not queued twice for the same webhook.
+func (service *WebhookServiceImpl) QueueDeliveries(ctx *fiber.Ctx, message event.Message) {
+	if message.OrganizationID == nil {
+		return
+	}
+
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	payload, err := json.Marshal(webhook.EventPayload{
+		ID:             message.ID,
+		Event:          message.Event.Name(),
+		OrganizationID: *message.OrganizationID,
+		OccurredAt:     message.OccurredAt,
+		Data:           message.Event,
+	})
+	helper.PanicIfError(err)
+
+	// Truncated like the column, so the delivery is due right away
+	now := time.Now().Truncate(time.Second)
+
+	for _, webhookEntity := range service.WebhookRepository.FindAllActive(ctx, tx) {
+		if !webhookEntity.Subscribes(message.Event.Name()) || service.WebhookDeliveryRepository.ExistsForEvent(ctx, tx, webhookEntity.ID, message.ID) {
+			continue
+		}
+
+		service.WebhookDeliveryRepository.Create(ctx, tx, domain.WebhookDelivery{
+			WebhookID:     webhookEntity.ID,
+			EventID:       message.ID,
+			Event:         message.Event.Name(),
+			Payload:       payload,
+			Status:        domain.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+		})
+	}
+}
+
+// DeliverDue sends up to limit deliveries that are due and returns how many
+// were found, less than limit means the queue is drained
+func (service *WebhookServiceImpl) DeliverDue(ctx *fiber.Ctx, limit int) int {
+	now := time.Now()
+	deliveries := service.WebhookDeliveryRepository.FindDue(ctx, service.DB, now, limit)
+
+	for index := range deliveries {
+		delivery := &deliveries[index]
+		if service.WebhookDeliveryRepository.Claim(ctx, service.DB, delivery, now, now.Add(webhookClaimTimeout)) {
+			service.attempt(ctx, delivery)
+		}
+	}
+
+	return len(deliveries)
+}
+
+func (service *WebhookServiceImpl) queueRedelivery(ctx *fiber.Ctx, user domain.User, webhookID int64, deliveryID int64) *domain.WebhookDelivery {
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	requireOrganizationRole(ctx, tx, service.OrganizationMemberRepository, user, domain.OrganizationRoleAdmin)
+
+	webhookEntity := service.findWebhook(ctx, tx, webhookID)
+
+	original, err := service.WebhookDeliveryRepository.FindById(ctx, tx, deliveryID, webhookEntity.ID)
+	if err != nil {
+		panic(helper.NewNotFoundError("webhook delivery not found"))
+	}
+
+	now := time.Now().Truncate(time.Second)
+	delivery := service.WebhookDeliveryRepository.Create(ctx, tx, domain.WebhookDelivery{
+		WebhookID:     webhookEntity.ID,
+		EventID:       original.EventID,
+		Event:         original.Event,
+		Payload:       original.Payload,
+		Status:        domain.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+	})
+	delivery.Webhook = *webhookEntity
+
+	return &delivery
+}
+
+// attempt sends a claimed delivery once and saves the outcome
+func (service *WebhookServiceImpl) attempt(ctx *fiber.Ctx, delivery *domain.WebhookDelivery) {
+	responseStatus, err := service.send(ctx, delivery)
+	now := time.Now()
+
+	delivery.Attempts++
+	delivery.ResponseStatus = responseStatus
+	delivery.NextAttemptAt = nil
+
+	switch {
+	case err == nil:
+		delivery.Status = domain.WebhookDeliverySucceeded
+		delivery.Error = ""
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= service.Options.MaxAttempts:
+		delivery.Status = domain.WebhookDeliveryFailed
+		delivery.Error = truncateWebhookError(err)
+	default:
+		nextAttemptAt := now.Add(service.backoff(delivery.Attempts))
+		delivery.NextAttemptAt = &nextAttemptAt
+		delivery.Error = truncateWebhookError(err)
+	}
+
+	service.WebhookDeliveryRepository.Update(ctx, service.DB, delivery)
+}
+
+// send posts the payload to the webhook, any response but a 2xx is a failure
+func (service *WebhookServiceImpl) send(ctx *fiber.Ctx, delivery *domain.WebhookDelivery) (int, error) {
+	request, err := http.NewRequestWithContext(ctx.UserContext(), http.MethodPost, delivery.Webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	request.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	request.Header.Set(fiber.HeaderUserAgent, "go-contact-management-api-webhooks")
+	request.Header.Set(WebhookHeaderEvent, delivery.Event)
+	request.Header.Set(WebhookHeaderEventID, delivery.EventID)
+	request.Header.Set(WebhookHeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	request.Header.Set(WebhookHeaderSignature, signWebhookPayload(delivery.Webhook.Secret, delivery.Payload))
+
+	response, err := service.Options.Client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("endpoint responded with status %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
+
+// backoff is the wait before the attempt after attempts failed ones
+func (service *WebhookServiceImpl) backoff(attempts int) time.Duration {
+	wait := service.Options.RetryBackoff
+	for i := 1; i < attempts && wait < maxWebhookBackoff; i++ {
+		wait *= 2
+	}
+	if wait > maxWebhookBackoff {
+		wait = maxWebhookBackoff
+	}
+	return wait
+}
+
+func (service *WebhookServiceImpl) findWebhook(ctx *fiber.Ctx, tx *gorm.DB, webhookID int64) *domain.Webhook {
+	webhookEntity, err := service.WebhookRepository.FindById(ctx, tx, webhookID)
+	if err != nil {
+		panic(helper.NewNotFoundError("webhook not found"))
+	}
+	return webhookEntity
+}
+
+func truncateWebhookError(err error) string {
+	message := err.Error()
+	if len(message) > maxWebhookErrorLength {
+		message = message[:maxWebhookErrorLength]
+	}
+	return message
+}
@@ -7,4 +7,17 @@ var Set = wire.NewSet(
 	NewUserService,
 	NewContactService,
 	NewAddressService,
+	NewUserAdminService,
+	NewAdminService,
+	NewAddressBookService,
+	NewInvitationService,
+	NewOrganizationService,
+	NewWebhookService,
+	NewOutboxService,
+	NewEventStreamService,
+	NewCollaborationService,
+	NewSyncService,
+	NewCardDAVService,
+	NewPhotoService,
+	NewEventBus,
 )
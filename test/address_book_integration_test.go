@@ -0,0 +1,304 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/web/addressbook"
+	"github.com/stretchr/testify/assert"
+)
+
+// shareAddressBook invites username to the address book and accepts the
+// invitation with their token
+func shareAddressBook(t *testing.T, ownerToken string, addressBookID float64, username, token, permission string) {
+	status, response := adminRequest(t, "POST", fmt.Sprintf("/api/address-books/%d/invitations", int64(addressBookID)), ownerToken, addressbook.InvitationCreateRequest{
+		Username:   username,
+		Permission: permission,
+	})
+	assert.Equal(t, fiber.StatusCreated, status)
+
+	invitationID := response.Data.(map[string]interface{})["id"].(float64)
+	status, _ = adminRequest(t, "POST", fmt.Sprintf("/api/invitations/%d/accept", int64(invitationID)), token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+}
+
+// defaultAddressBookID returns the ID of the first address book listed for the user
+func defaultAddressBookID(t *testing.T, token string) float64 {
+	status, response := adminRequest(t, "GET", "/api/address-books", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	addressBooks := response.Data.([]interface{})
+	assert.NotEmpty(t, addressBooks)
+	return addressBooks[0].(map[string]interface{})["id"].(float64)
+}
+
+func TestAddressBookDefault(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testbookdefault", "password123", "Test Book Default")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+
+	status, response := adminRequest(t, "GET", "/api/address-books", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	addressBooks := response.Data.([]interface{})
+	assert.Len(t, addressBooks, 1)
+	addressBook := addressBooks[0].(map[string]interface{})
+	assert.Equal(t, "Contacts", addressBook["name"])
+	assert.Equal(t, "owner", addressBook["permission"])
+
+	status, response = adminRequest(t, "GET", "/api/contacts/"+contactID, token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, addressBook["id"], response.Data.(map[string]interface{})["address_book_id"])
+
+	cleanupTestData()
+}
+
+func TestAddressBookCreateUpdateDelete(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testbookcrud", "password123", "Test Book CRUD")
+
+	status, response := adminRequest(t, "POST", "/api/address-books", token, addressbook.AddressBookCreateRequest{Name: "Family"})
+	assert.Equal(t, fiber.StatusCreated, status)
+	addressBookID := response.Data.(map[string]interface{})["id"].(float64)
+	url := fmt.Sprintf("/api/address-books/%d", int64(addressBookID))
+
+	status, _ = adminRequest(t, "POST", "/api/address-books", token, addressbook.AddressBookCreateRequest{})
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	status, response = adminRequest(t, "PATCH", url, token, map[string]interface{}{"name": "Relatives"})
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "Relatives", response.Data.(map[string]interface{})["name"])
+
+	status, _ = adminRequest(t, "POST", "/api/contacts", token, map[string]interface{}{
+		"address_book_id": addressBookID,
+		"first_name":      "Jane",
+		"last_name":       "Doe",
+		"email":           "jane@example.com",
+		"phone":           "08123456789",
+	})
+	assert.Equal(t, fiber.StatusCreated, status)
+
+	status, response = adminRequest(t, "GET", fmt.Sprintf("/api/contacts?address_book_id=%d", int64(addressBookID)), token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	contacts := response.Data.(map[string]interface{})["contacts"].([]interface{})
+	assert.Len(t, contacts, 1)
+
+	status, response = adminRequest(t, "DELETE", url, token, nil)
+	assert.Equal(t, fiber.StatusConflict, status)
+	assert.Equal(t, "address book still has contacts", response.Data)
+
+	contactID := fmt.Sprintf("%d", int64(contacts[0].(map[string]interface{})["id"].(float64)))
+	status, _ = adminRequest(t, "DELETE", "/api/contacts/"+contactID, token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	status, _ = adminRequest(t, "DELETE", url, token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	status, _ = adminRequest(t, "GET", url, token, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	cleanupTestData()
+}
+
+func TestAddressBookHiddenFromNonMembers(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testbookowner", "password123", "Test Book Owner")
+	otherToken := registerAndLogin(t, "testbookother", "password123", "Test Book Other")
+	contactID := createTestContact(t, ownerToken, "John", "Doe", "john@example.com", "08123456789")
+	addressBookID := defaultAddressBookID(t, ownerToken)
+
+	status, _ := adminRequest(t, "GET", fmt.Sprintf("/api/address-books/%d", int64(addressBookID)), otherToken, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	status, _ = adminRequest(t, "GET", fmt.Sprintf("/api/contacts?address_book_id=%d", int64(addressBookID)), otherToken, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	status, _ = adminRequest(t, "GET", "/api/contacts/"+contactID, otherToken, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	cleanupTestData()
+}
+
+func TestAddressBookViewerCanOnlyRead(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testbookowner", "password123", "Test Book Owner")
+	viewerToken := registerAndLogin(t, "testbookviewer", "password123", "Test Book Viewer")
+	joinOrganization(t, ownerToken, "testbookviewer")
+	contactID := createTestContact(t, ownerToken, "John", "Doe", "john@example.com", "08123456789")
+	createTestAddress(t, ownerToken, contactID, "Jl. Test", "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+	addressBookID := defaultAddressBookID(t, ownerToken)
+	shareAddressBook(t, ownerToken, addressBookID, "testbookviewer", viewerToken, "viewer")
+
+	status, response := adminRequest(t, "GET", "/api/contacts", viewerToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, response.Data.(map[string]interface{})["contacts"], 1)
+
+	status, _ = adminRequest(t, "GET", "/api/contacts/"+contactID+"/addresses", viewerToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	status, response = adminRequest(t, "PATCH", "/api/contacts/"+contactID, viewerToken, map[string]interface{}{"first_name": "Jack"})
+	assert.Equal(t, fiber.StatusForbidden, status)
+	assert.Equal(t, "address book permission editor required", response.Data)
+
+	status, _ = adminRequest(t, "DELETE", "/api/contacts/"+contactID, viewerToken, nil)
+	assert.Equal(t, fiber.StatusForbidden, status)
+
+	status, _ = adminRequest(t, "POST", "/api/contacts", viewerToken, map[string]interface{}{
+		"address_book_id": addressBookID,
+		"first_name":      "Jane",
+		"last_name":       "Doe",
+		"email":           "jane@example.com",
+		"phone":           "08123456789",
+	})
+	assert.Equal(t, fiber.StatusForbidden, status)
+
+	status, _ = adminRequest(t, "POST", "/api/contacts/"+contactID+"/addresses", viewerToken, map[string]interface{}{
+		"street":      "Jl. Other",
+		"city":        "Bandung",
+		"province":    "Jawa Barat",
+		"country":     "Indonesia",
+		"postal_code": "40111",
+	})
+	assert.Equal(t, fiber.StatusForbidden, status)
+
+	cleanupTestData()
+}
+
+func TestAddressBookEditorCanWrite(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testbookowner", "password123", "Test Book Owner")
+	editorToken := registerAndLogin(t, "testbookeditor", "password123", "Test Book Editor")
+	joinOrganization(t, ownerToken, "testbookeditor")
+	contactID := createTestContact(t, ownerToken, "John", "Doe", "john@example.com", "08123456789")
+	addressBookID := defaultAddressBookID(t, ownerToken)
+	shareAddressBook(t, ownerToken, addressBookID, "testbookeditor", editorToken, "editor")
+
+	status, response := adminRequest(t, "PATCH", "/api/contacts/"+contactID, editorToken, map[string]interface{}{"first_name": "Jack"})
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "Jack", response.Data.(map[string]interface{})["first_name"])
+
+	status, response = adminRequest(t, "POST", "/api/contacts", editorToken, map[string]interface{}{
+		"address_book_id": addressBookID,
+		"first_name":      "Jane",
+		"last_name":       "Doe",
+		"email":           "jane@example.com",
+		"phone":           "08123456789",
+	})
+	assert.Equal(t, fiber.StatusCreated, status)
+	assert.Equal(t, addressBookID, response.Data.(map[string]interface{})["address_book_id"])
+
+	// Contacts added by an editor belong to the owner and outlive the editor
+	testDB.Exec("DELETE FROM users WHERE username = 'testbookeditor'")
+	status, response = adminRequest(t, "GET", "/api/contacts", ownerToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, response.Data.(map[string]interface{})["contacts"], 2)
+
+	cleanupTestData()
+}
+
+func TestAddressBookMoveContact(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testbookowner", "password123", "Test Book Owner")
+	editorToken := registerAndLogin(t, "testbookeditor", "password123", "Test Book Editor")
+	joinOrganization(t, ownerToken, "testbookeditor")
+	contactID := createTestContact(t, editorToken, "John", "Doe", "john@example.com", "08123456789")
+	sharedID := defaultAddressBookID(t, ownerToken)
+
+	status, _ := adminRequest(t, "PATCH", "/api/contacts/"+contactID, editorToken, map[string]interface{}{"address_book_id": sharedID})
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	shareAddressBook(t, ownerToken, sharedID, "testbookeditor", editorToken, "editor")
+
+	status, response := adminRequest(t, "PATCH", "/api/contacts/"+contactID, editorToken, map[string]interface{}{"address_book_id": sharedID})
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, sharedID, response.Data.(map[string]interface{})["address_book_id"])
+
+	status, _ = adminRequest(t, "GET", "/api/contacts/"+contactID, ownerToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	cleanupTestData()
+}
+
+func TestAddressBookMembers(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testbookowner", "password123", "Test Book Owner")
+	memberToken := registerAndLogin(t, "testbookmember", "password123", "Test Book Member")
+	joinOrganization(t, ownerToken, "testbookmember")
+	addressBookID := defaultAddressBookID(t, ownerToken)
+	shareAddressBook(t, ownerToken, addressBookID, "testbookmember", memberToken, "viewer")
+	owner := findTestUser("testbookowner")
+	member := findTestUser("testbookmember")
+	url := fmt.Sprintf("/api/address-books/%d/members", int64(addressBookID))
+
+	status, response := adminRequest(t, "GET", url, memberToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, response.Data, 2)
+
+	status, _ = adminRequest(t, "PUT", fmt.Sprintf("%s/%d", url, member.ID), memberToken, addressbook.MemberUpdateRequest{Permission: "editor"})
+	assert.Equal(t, fiber.StatusForbidden, status)
+
+	status, response = adminRequest(t, "PUT", fmt.Sprintf("%s/%d", url, member.ID), ownerToken, addressbook.MemberUpdateRequest{Permission: "editor"})
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "editor", response.Data.(map[string]interface{})["permission"])
+
+	status, _ = adminRequest(t, "PUT", fmt.Sprintf("%s/%d", url, owner.ID), ownerToken, addressbook.MemberUpdateRequest{Permission: "viewer"})
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	status, _ = adminRequest(t, "DELETE", fmt.Sprintf("%s/%d", url, owner.ID), ownerToken, nil)
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	// Members can leave on their own
+	status, _ = adminRequest(t, "DELETE", fmt.Sprintf("%s/%d", url, member.ID), memberToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	status, _ = adminRequest(t, "GET", url, memberToken, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	cleanupTestData()
+}
+
+func TestAddressBookInvitations(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testbookowner", "password123", "Test Book Owner")
+	inviteeToken := registerAndLogin(t, "testbookinvitee", "password123", "Test Book Invitee")
+	joinOrganization(t, ownerToken, "testbookinvitee")
+	addressBookID := defaultAddressBookID(t, ownerToken)
+	url := fmt.Sprintf("/api/address-books/%d/invitations", int64(addressBookID))
+	request := addressbook.InvitationCreateRequest{Username: "testbookinvitee", Permission: "viewer"}
+
+	status, response := adminRequest(t, "POST", url, ownerToken, request)
+	assert.Equal(t, fiber.StatusCreated, status)
+	invitation := response.Data.(map[string]interface{})
+	assert.Equal(t, "pending", invitation["status"])
+	assert.Equal(t, "testbookowner", invitation["inviter"])
+
+	status, _ = adminRequest(t, "POST", url, ownerToken, request)
+	assert.Equal(t, fiber.StatusConflict, status)
+
+	status, _ = adminRequest(t, "POST", url, ownerToken, addressbook.InvitationCreateRequest{Username: "testbookmissing", Permission: "viewer"})
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	status, response = adminRequest(t, "GET", url, ownerToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, response.Data, 1)
+
+	status, response = adminRequest(t, "GET", "/api/invitations", inviteeToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, response.Data, 1)
+
+	invitationURL := fmt.Sprintf("/api/invitations/%d", int64(invitation["id"].(float64)))
+	status, _ = adminRequest(t, "POST", invitationURL+"/accept", ownerToken, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	status, response = adminRequest(t, "POST", invitationURL+"/decline", inviteeToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "declined", response.Data.(map[string]interface{})["status"])
+
+	status, _ = adminRequest(t, "POST", invitationURL+"/accept", inviteeToken, nil)
+	assert.Equal(t, fiber.StatusConflict, status)
+
+	status, _ = adminRequest(t, "GET", fmt.Sprintf("/api/address-books/%d", int64(addressBookID)), inviteeToken, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	cleanupTestData()
+}
@@ -197,11 +197,11 @@ func TestUpdateAddressSuccess(t *testing.T) {
 
 	// Update address
 	updateBody := address.AddressUpdateRequest{
-		Street:     "New Street",
-		City:       "New City",
-		Province:   "New Province",
-		Country:    "Indonesia",
-		PostalCode: "99999",
+		Street:     web.SetString("New Street"),
+		City:       web.SetString("New City"),
+		Province:   web.SetString("New Province"),
+		Country:    web.SetString("Indonesia"),
+		PostalCode: web.SetString("99999"),
 	}
 
 	updateJSON, _ := json.Marshal(updateBody)
@@ -234,7 +234,7 @@ func TestUpdateAddressNotFound(t *testing.T) {
 	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
 
 	updateBody := address.AddressUpdateRequest{
-		Street: "Updated Street",
+		Street: web.SetString("Updated Street"),
 	}
 
 	updateJSON, _ := json.Marshal(updateBody)
@@ -0,0 +1,235 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/stretchr/testify/assert"
+)
+
+// registerAdmin registers a user, makes it an admin and returns its token
+func registerAdmin(t *testing.T, username string) string {
+	token := registerAndLogin(t, username, "password123", "Test Admin")
+	testDB.Model(&domain.User{}).Where("username = ?", username).Update("role", domain.RoleAdmin)
+	return token
+}
+
+func findTestUser(username string) domain.User {
+	userEntity := domain.User{}
+	testDB.Unscoped().Where("username = ?", username).First(&userEntity)
+	return userEntity
+}
+
+func adminRequest(t *testing.T, method, url, token string, body interface{}) (int, web.Response) {
+	var reader io.Reader
+	if body != nil {
+		bodyJSON, _ := json.Marshal(body)
+		reader = bytes.NewReader(bodyJSON)
+	}
+	req := httptest.NewRequest(method, url, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+
+	response := web.Response{}
+	responseBody, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(responseBody, &response)
+	return resp.StatusCode, response
+}
+
+func TestAdminAPIRequiresAdminRole(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testroleuser", "password123", "Test Role User")
+
+	status, response := adminRequest(t, "GET", "/api/admin/users", token, nil)
+	assert.Equal(t, fiber.StatusForbidden, status)
+	assert.Equal(t, "Insufficient role", response.Data)
+
+	status, _ = adminRequest(t, "GET", "/api/admin/users", "invalid-token", nil)
+	assert.Equal(t, fiber.StatusUnauthorized, status)
+
+	cleanupTestData()
+}
+
+func TestAdminAPISearchUsers(t *testing.T) {
+	cleanupTestData()
+	token := registerAdmin(t, "testroleadmin")
+	registerAndLogin(t, "testrolesearch1", "password123", "Test Search One")
+	registerAndLogin(t, "testrolesearch2", "password123", "Test Search Two")
+
+	status, response := adminRequest(t, "GET", "/api/admin/users?username=testrolesearch&size=1", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	data := response.Data.(map[string]interface{})
+	users := data["users"].([]interface{})
+	assert.Len(t, users, 1)
+	assert.Equal(t, "testrolesearch1", users[0].(map[string]interface{})["username"])
+	assert.Equal(t, "user", users[0].(map[string]interface{})["role"])
+	paging := data["paging"].(map[string]interface{})
+	assert.Equal(t, float64(2), paging["total_item"])
+	assert.Equal(t, float64(2), paging["total_page"])
+
+	status, response = adminRequest(t, "GET", "/api/admin/users?username=testrole&role=admin", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	users = response.Data.(map[string]interface{})["users"].([]interface{})
+	assert.Len(t, users, 1)
+	assert.Equal(t, "testroleadmin", users[0].(map[string]interface{})["username"])
+
+	cleanupTestData()
+}
+
+func TestAdminAPIDisableAndEnable(t *testing.T) {
+	cleanupTestData()
+	adminToken := registerAdmin(t, "testroleadmin")
+	userToken := registerAndLogin(t, "testroledisable", "password123", "Test Disable")
+	target := findTestUser("testroledisable")
+	url := fmt.Sprintf("/api/admin/users/%d", target.ID)
+
+	status, response := adminRequest(t, "POST", url+"/disable", adminToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.NotNil(t, response.Data.(map[string]interface{})["disabled_at"])
+
+	assert.Equal(t, fiber.StatusUnauthorized, currentUserStatus(t, userToken))
+	assert.Equal(t, fiber.StatusForbidden, loginStatus(t, "testroledisable", "password123"))
+
+	status, response = adminRequest(t, "GET", "/api/admin/users?username=testroledisable&disabled=true", adminToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, response.Data.(map[string]interface{})["users"], 1)
+
+	status, response = adminRequest(t, "POST", url+"/enable", adminToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Nil(t, response.Data.(map[string]interface{})["disabled_at"])
+
+	assert.Equal(t, fiber.StatusOK, loginStatus(t, "testroledisable", "password123"))
+
+	cleanupTestData()
+}
+
+func TestAdminAPIDisableSelf(t *testing.T) {
+	cleanupTestData()
+	adminToken := registerAdmin(t, "testroleadmin")
+	admin := findTestUser("testroleadmin")
+
+	status, _ := adminRequest(t, "POST", fmt.Sprintf("/api/admin/users/%d/disable", admin.ID), adminToken, nil)
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	status, _ = adminRequest(t, "PUT", fmt.Sprintf("/api/admin/users/%d/role", admin.ID), adminToken, map[string]string{"role": "user"})
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	cleanupTestData()
+}
+
+func TestAdminAPIForceLogout(t *testing.T) {
+	cleanupTestData()
+	adminToken := registerAdmin(t, "testroleadmin")
+	userToken := registerAndLogin(t, "testrolelogout", "password123", "Test Logout")
+	target := findTestUser("testrolelogout")
+
+	status, _ := adminRequest(t, "POST", fmt.Sprintf("/api/admin/users/%d/logout", target.ID), adminToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	assert.Equal(t, fiber.StatusUnauthorized, currentUserStatus(t, userToken))
+	assert.Equal(t, fiber.StatusOK, loginStatus(t, "testrolelogout", "password123"))
+
+	cleanupTestData()
+}
+
+func TestAdminAPIUserNotFound(t *testing.T) {
+	cleanupTestData()
+	adminToken := registerAdmin(t, "testroleadmin")
+
+	status, response := adminRequest(t, "POST", "/api/admin/users/999999/logout", adminToken, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+	assert.Equal(t, "user not found", response.Data)
+
+	cleanupTestData()
+}
+
+func TestAdminAPISetRole(t *testing.T) {
+	cleanupTestData()
+	adminToken := registerAdmin(t, "testroleadmin")
+	userToken := registerAndLogin(t, "testrolepromote", "password123", "Test Promote")
+	target := findTestUser("testrolepromote")
+	url := fmt.Sprintf("/api/admin/users/%d/role", target.ID)
+
+	status, _ := adminRequest(t, "PUT", url, adminToken, map[string]string{"role": "superuser"})
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	status, response := adminRequest(t, "PUT", url, adminToken, map[string]string{"role": "admin"})
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "admin", response.Data.(map[string]interface{})["role"])
+
+	// The promoted user reaches the admin API with the session it already had
+	status, _ = adminRequest(t, "GET", "/api/admin/users/stats", userToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	status, response = adminRequest(t, "GET", "/api/users/current", userToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "admin", response.Data.(map[string]interface{})["role"])
+
+	cleanupTestData()
+}
+
+func TestAdminAPIStats(t *testing.T) {
+	cleanupTestData()
+	adminToken := registerAdmin(t, "testroleadmin")
+	userToken := registerAndLogin(t, "testrolestats", "password123", "Test Stats")
+	createTestContact(t, userToken, "Stats", "Contact", "stats.contact@example.com", "081234567890")
+
+	status, response := adminRequest(t, "GET", "/api/admin/users/stats", adminToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	stats := response.Data.(map[string]interface{})
+	assert.GreaterOrEqual(t, stats["total_users"], float64(2))
+	assert.GreaterOrEqual(t, stats["admin_users"], float64(1))
+	assert.GreaterOrEqual(t, stats["active_sessions"], float64(2))
+	assert.GreaterOrEqual(t, stats["new_users_last_30_days"], float64(2))
+	assert.GreaterOrEqual(t, stats["total_contacts"], float64(1))
+
+	cleanupTestData()
+}
+
+func TestAdminAPIRecordsActions(t *testing.T) {
+	cleanupTestData()
+	adminToken := registerAdmin(t, "testroleadmin")
+	admin := findTestUser("testroleadmin")
+	registerAndLogin(t, "testroleaudit", "password123", "Test Audit")
+	target := findTestUser("testroleaudit")
+
+	adminRequest(t, "POST", fmt.Sprintf("/api/admin/users/%d/disable", target.ID), adminToken, nil)
+	adminRequest(t, "PUT", fmt.Sprintf("/api/admin/users/%d/role", target.ID), adminToken, map[string]string{"role": "admin"})
+	code, _, _ := runAdmin("users", "revoke-tokens", "testroleaudit")
+	assert.Equal(t, 0, code)
+
+	status, response := adminRequest(t, "GET", "/api/admin/actions?size=3", adminToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	actions := response.Data.(map[string]interface{})["actions"].([]interface{})
+	assert.Len(t, actions, 3)
+
+	// Newest first, the command line has no admin user
+	revoke := actions[0].(map[string]interface{})
+	assert.Equal(t, "revoke_tokens", revoke["action"])
+	assert.Nil(t, revoke["admin_id"])
+
+	setRole := actions[1].(map[string]interface{})
+	assert.Equal(t, "set_role", setRole["action"])
+	assert.Equal(t, "user -> admin", setRole["details"])
+	assert.Equal(t, float64(admin.ID), setRole["admin_id"])
+	assert.Equal(t, float64(target.ID), setRole["target_user_id"])
+
+	assert.Equal(t, "disable", actions[2].(map[string]interface{})["action"])
+
+	cleanupTestData()
+}
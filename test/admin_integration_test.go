@@ -0,0 +1,191 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/admin"
+	"github.com/sorfian/go-contact-management-api/app"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/user"
+	"github.com/sorfian/go-contact-management-api/repository"
+	"github.com/sorfian/go-contact-management-api/service"
+	"github.com/stretchr/testify/assert"
+)
+
+// runAdmin runs an operator command against the test database
+func runAdmin(args ...string) (int, string, string) {
+	userAdminService := service.NewUserAdminService(testUserRepository, repository.NewAdminActionRepository(), testDB, app.ProvideValidator())
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := admin.NewAdmin(userAdminService).Run(args, stdout, stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func loginStatus(t *testing.T, username, password string) int {
+	loginJSON, _ := json.Marshal(user.UserLoginRequest{Username: username, Password: password})
+	req := httptest.NewRequest("POST", "/api/users/login", bytes.NewReader(loginJSON))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	return resp.StatusCode
+}
+
+func currentUserStatus(t *testing.T, token string) int {
+	req := httptest.NewRequest("GET", "/api/users/current", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	return resp.StatusCode
+}
+
+func TestAdminListUsers(t *testing.T) {
+	cleanupTestData()
+	registerAndLogin(t, "testadmin1", "password123", "Test Admin 1")
+	registerAndLogin(t, "testadmin2", "password123", "Test Admin 2")
+
+	code, _, _ := runAdmin("users", "delete", "testadmin2")
+	assert.Equal(t, 0, code)
+
+	code, stdout, _ := runAdmin("users", "list")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "testadmin1")
+	assert.NotContains(t, stdout, "testadmin2")
+
+	code, stdout, _ = runAdmin("users", "list", "-deleted")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "testadmin1")
+	assert.Contains(t, stdout, "testadmin2")
+
+	cleanupTestData()
+}
+
+func TestAdminResetPassword(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testadmin3", "password123", "Test Admin 3")
+
+	code, stdout, _ := runAdmin("users", "reset-password", "testadmin3", "-password", "newpassword")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "testadmin3")
+
+	assert.Equal(t, fiber.StatusUnauthorized, currentUserStatus(t, token))
+	assert.Equal(t, fiber.StatusNotFound, loginStatus(t, "testadmin3", "password123"))
+	assert.Equal(t, fiber.StatusOK, loginStatus(t, "testadmin3", "newpassword"))
+
+	cleanupTestData()
+}
+
+func TestAdminResetPasswordValidationFailed(t *testing.T) {
+	cleanupTestData()
+	registerAndLogin(t, "testadmin4", "password123", "Test Admin 4")
+
+	code, _, stderr := runAdmin("users", "reset-password", "testadmin4", "-password", "ab")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "Validation failed")
+
+	assert.Equal(t, fiber.StatusOK, loginStatus(t, "testadmin4", "password123"))
+
+	cleanupTestData()
+}
+
+func TestAdminRevokeTokens(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testadmin5", "password123", "Test Admin 5")
+	assert.Equal(t, fiber.StatusOK, currentUserStatus(t, token))
+
+	code, _, _ := runAdmin("users", "revoke-tokens", "testadmin5")
+	assert.Equal(t, 0, code)
+
+	assert.Equal(t, fiber.StatusUnauthorized, currentUserStatus(t, token))
+	assert.Equal(t, fiber.StatusOK, loginStatus(t, "testadmin5", "password123"))
+
+	cleanupTestData()
+}
+
+func TestAdminUserNotFound(t *testing.T) {
+	cleanupTestData()
+
+	code, _, stderr := runAdmin("users", "revoke-tokens", "testadminmissing")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "user not found")
+}
+
+func TestAdminUnknownCommand(t *testing.T) {
+	code, _, stderr := runAdmin("users", "promote", "testadmin")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "unknown users subcommand")
+}
+
+func TestAdminDeleteUser(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testadmin6", "password123", "Test Admin 6")
+	createTestContact(t, token, "Admin", "Contact", "admin.contact@example.com", "081234567890")
+
+	code, _, _ := runAdmin("users", "delete", "testadmin6")
+	assert.Equal(t, 0, code)
+
+	assert.Equal(t, fiber.StatusUnauthorized, currentUserStatus(t, token))
+	assert.NotEqual(t, fiber.StatusOK, loginStatus(t, "testadmin6", "password123"))
+
+	var count int64
+	testDB.Unscoped().Model(&domain.User{}).Where("username = ?", "testadmin6").Count(&count)
+	assert.Equal(t, int64(1), count)
+
+	cleanupTestData()
+}
+
+func TestAdminHardDeleteUser(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testadmin7", "password123", "Test Admin 7")
+	createTestContact(t, token, "Admin", "Contact", "admin.contact@example.com", "081234567890")
+
+	var userEntity domain.User
+	testDB.Where("username = ?", "testadmin7").First(&userEntity)
+
+	// A soft deleted user can still be removed for good
+	code, _, _ := runAdmin("users", "delete", "testadmin7")
+	assert.Equal(t, 0, code)
+	code, stdout, _ := runAdmin("users", "delete", "testadmin7", "-hard")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "removed")
+
+	var count int64
+	testDB.Unscoped().Model(&domain.User{}).Where("username = ?", "testadmin7").Count(&count)
+	assert.Equal(t, int64(0), count)
+	testDB.Unscoped().Model(&domain.Contact{}).Where("user_id = ?", userEntity.ID).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestAdminDisableAndEnableUser(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testadmin8", "password123", "Test Admin 8")
+
+	code, stdout, _ := runAdmin("users", "disable", "testadmin8")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "testadmin8")
+
+	assert.Equal(t, fiber.StatusUnauthorized, currentUserStatus(t, token))
+	assert.Equal(t, fiber.StatusForbidden, loginStatus(t, "testadmin8", "password123"))
+
+	var userEntity domain.User
+	testDB.Where("username = ?", "testadmin8").First(&userEntity)
+	assert.NotNil(t, userEntity.DisabledAt)
+
+	code, _, _ = runAdmin("users", "enable", "testadmin8")
+	assert.Equal(t, 0, code)
+
+	userEntity = domain.User{}
+	testDB.Where("username = ?", "testadmin8").First(&userEntity)
+	assert.Nil(t, userEntity.DisabledAt)
+	assert.Equal(t, fiber.StatusOK, loginStatus(t, "testadmin8", "password123"))
+
+	var actions []string
+	testDB.Model(&domain.AdminAction{}).Where("target_user_id = ?", userEntity.ID).Order("id").Pluck("action", &actions)
+	assert.Equal(t, []string{domain.AdminActionDisable, domain.AdminActionEnable}, actions)
+
+	cleanupTestData()
+}
@@ -0,0 +1,171 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+	"github.com/stretchr/testify/assert"
+)
+
+// auditLogs returns the logs of a contact history or audit log search response
+func auditLogs(data interface{}) []interface{} {
+	return data.(map[string]interface{})["logs"].([]interface{})
+}
+
+func TestContactHistory(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testaudituser", "password123", "Test Audit User")
+	actor := findTestUser("testaudituser")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+
+	bodyJSON, _ := json.Marshal(map[string]interface{}{"phone": "08987654321"})
+	req := httptest.NewRequest("PATCH", "/api/contacts/"+contactID, bytes.NewReader(bodyJSON))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set(fiber.HeaderXRequestID, "test-audit-request")
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "test-audit-request", resp.Header.Get(fiber.HeaderXRequestID))
+
+	status, response := adminRequest(t, "GET", "/api/contacts/"+contactID+"/history", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	logs := auditLogs(response.Data)
+	assert.Len(t, logs, 2)
+
+	update := logs[0].(map[string]interface{})
+	assert.Equal(t, "update", update["action"])
+	assert.Equal(t, "contact", update["entity_type"])
+	assert.Equal(t, float64(actor.ID), update["actor_id"])
+	assert.Equal(t, "test-audit-request", update["request_id"])
+	assert.NotEmpty(t, update["ip"])
+	assert.Equal(t, map[string]interface{}{
+		"phone": map[string]interface{}{"before": "08123456789", "after": "08987654321"},
+	}, update["changes"])
+
+	create := logs[1].(map[string]interface{})
+	assert.Equal(t, "create", create["action"])
+	assert.Equal(t, map[string]interface{}{"before": nil, "after": "John"}, create["changes"].(map[string]interface{})["first_name"])
+
+	status, _ = adminRequest(t, "DELETE", "/api/contacts/"+contactID, token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	var deleteLog domain.AuditLog
+	testDB.Where("entity_type = ? AND entity_id = ? AND action = ?", "contact", parseTestID(contactID), "delete").First(&deleteLog)
+	assert.Equal(t, "08987654321", deleteLog.Changes["phone"].Before)
+	assert.Nil(t, deleteLog.Changes["phone"].After)
+
+	cleanupTestData()
+}
+
+func TestContactHistoryNotFound(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testauditowner", "password123", "Test Audit Owner")
+	otherToken := registerAndLogin(t, "testauditother", "password123", "Test Audit Other")
+	contactID := createTestContact(t, ownerToken, "John", "Doe", "john@example.com", "08123456789")
+
+	status, _ := adminRequest(t, "GET", "/api/contacts/"+contactID+"/history", otherToken, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	cleanupTestData()
+}
+
+func TestAuditLogsOfAddressesAndUsers(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testaudituser", "password123", "Test Audit User")
+	actor := findTestUser("testaudituser")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	addressID := createTestAddress(t, token, contactID, "Jl. Test", "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+
+	status, _ := adminRequest(t, "PATCH", "/api/users/current", token, map[string]interface{}{"name": "Renamed", "password": "newpassword123"})
+	assert.Equal(t, fiber.StatusOK, status)
+
+	var addressLog domain.AuditLog
+	testDB.Where("entity_type = ? AND entity_id = ?", "address", parseTestID(addressID)).First(&addressLog)
+	assert.Equal(t, domain.AuditActionCreate, addressLog.Action)
+	assert.Equal(t, "Jakarta", addressLog.Changes["city"].After)
+	assert.NotNil(t, addressLog.OrganizationID)
+
+	var userLogs []domain.AuditLog
+	testDB.Where("entity_type = ? AND entity_id = ?", "user", actor.ID).Order("id").Find(&userLogs)
+	assert.Len(t, userLogs, 2)
+	assert.Equal(t, domain.AuditActionCreate, userLogs[0].Action)
+	assert.Nil(t, userLogs[0].OrganizationID)
+	assert.Equal(t, domain.AuditChange{Before: "Test Audit User", After: "Renamed"}, userLogs[1].Changes["name"])
+	assert.Equal(t, domain.AuditChange{Before: "[redacted]", After: "[redacted]"}, userLogs[1].Changes["password"])
+
+	cleanupTestData()
+}
+
+func TestAuditLogRolledBackWithChange(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testaudituser", "password123", "Test Audit User")
+	actor := findTestUser("testaudituser")
+
+	result := sendBulkRequest(t, token, contact.BulkRequest{
+		Operations: []contact.BulkOperation{
+			{Operation: "create", Data: json.RawMessage(`{"first_name":"Jane","last_name":"Doe","email":"jane@example.com","phone":"08123456789"}`)},
+			{Operation: "delete", ID: 999999999},
+		},
+	})
+	assert.Equal(t, false, result["committed"])
+
+	var count int64
+	testDB.Model(&domain.AuditLog{}).Where("actor_id = ? AND entity_type = ?", actor.ID, "contact").Count(&count)
+	assert.Equal(t, int64(0), count)
+
+	cleanupTestData()
+}
+
+func TestAuditLogsAreImmutable(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testaudituser", "password123", "Test Audit User")
+	actor := findTestUser("testaudituser")
+
+	assert.Error(t, testDB.Exec("UPDATE audit_logs SET action = 'delete' WHERE actor_id = ?", actor.ID).Error)
+	assert.Error(t, testDB.Exec("DELETE FROM audit_logs WHERE actor_id = ?", actor.ID).Error)
+
+	status, response := adminRequest(t, "GET", "/api/contacts", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.NotNil(t, response.Data)
+
+	cleanupTestData()
+}
+
+func TestAdminSearchAuditLogs(t *testing.T) {
+	cleanupTestData()
+	adminToken := registerAdmin(t, "testauditadmin")
+	token := registerAndLogin(t, "testaudituser", "password123", "Test Audit User")
+	actor := findTestUser("testaudituser")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	status, _ := adminRequest(t, "PATCH", "/api/contacts/"+contactID, token, map[string]interface{}{"phone": "08987654321"})
+	assert.Equal(t, fiber.StatusOK, status)
+
+	url := fmt.Sprintf("/api/admin/audit-logs?actor_id=%d&entity_type=contact", actor.ID)
+	status, response := adminRequest(t, "GET", url, adminToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, auditLogs(response.Data), 2)
+	assert.Equal(t, float64(2), response.Data.(map[string]interface{})["paging"].(map[string]interface{})["total_item"])
+
+	status, response = adminRequest(t, "GET", url+"&action=update", adminToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, auditLogs(response.Data), 1)
+
+	status, response = adminRequest(t, "GET", url+"&from=2999-01-01T00:00:00Z", adminToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, auditLogs(response.Data), 0)
+
+	status, _ = adminRequest(t, "GET", url+"&from=yesterday", adminToken, nil)
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	status, _ = adminRequest(t, "GET", "/api/admin/audit-logs", token, nil)
+	assert.Equal(t, fiber.StatusForbidden, status)
+
+	cleanupTestData()
+}
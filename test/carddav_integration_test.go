@@ -0,0 +1,308 @@
+package test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// davMultistatus is the part of a multistatus the tests read
+type davMultistatus struct {
+	Responses []struct {
+		Href      string `xml:"DAV: href"`
+		Status    string `xml:"DAV: status"`
+		Propstats []struct {
+			Status string `xml:"DAV: status"`
+			Prop   struct {
+				ETag        string `xml:"DAV: getetag"`
+				DisplayName string `xml:"DAV: displayname"`
+				SyncToken   string `xml:"DAV: sync-token"`
+				AddressData string `xml:"urn:ietf:params:xml:ns:carddav address-data"`
+				InnerXML    string `xml:",innerxml"`
+			} `xml:"DAV: prop"`
+		} `xml:"DAV: propstat"`
+	} `xml:"DAV: response"`
+	SyncToken string `xml:"DAV: sync-token"`
+}
+
+// davRequest sends a CardDAV request with the Basic credentials of the user
+func davRequest(t *testing.T, method, path, username, password string, headers map[string]string, body string) (*http.Response, string) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := testApp.Test(req, -1)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	responseBody, _ := io.ReadAll(resp.Body)
+	return resp, string(responseBody)
+}
+
+// davMultistatusRequest sends a PROPFIND or REPORT and returns its multistatus
+func davMultistatusRequest(t *testing.T, method, path, username, depth, body string) davMultistatus {
+	resp, responseBody := davRequest(t, method, path, username, "password123", map[string]string{"Depth": depth, "Content-Type": "application/xml"}, body)
+	if !assert.Equal(t, fiber.StatusMultiStatus, resp.StatusCode, responseBody) {
+		t.FailNow()
+	}
+
+	multistatus := davMultistatus{}
+	assert.NoError(t, xml.Unmarshal([]byte(responseBody), &multistatus))
+	return multistatus
+}
+
+// davHrefs returns the hrefs of the responses with the given status, the
+// status of their first propstat for those that have one
+func davHrefs(multistatus davMultistatus, status string) []string {
+	hrefs := []string{}
+	for _, response := range multistatus.Responses {
+		responseStatus := response.Status
+		if len(response.Propstats) > 0 {
+			responseStatus = response.Propstats[0].Status
+		}
+		if strings.Contains(responseStatus, status) {
+			hrefs = append(hrefs, response.Href)
+		}
+	}
+	return hrefs
+}
+
+// davAddressBookPath returns the CardDAV collection of the user's default
+// address book
+func davAddressBookPath(t *testing.T, token string) string {
+	return fmt.Sprintf("/dav/addressbooks/%s/%d/", defaultOrganizationID(t, token), int64(defaultAddressBookID(t, token)))
+}
+
+func testVCard(uid, fullName, email string) string {
+	return "BEGIN:VCARD\r\n" +
+		"VERSION:3.0\r\n" +
+		"UID:" + uid + "\r\n" +
+		"FN:" + fullName + "\r\n" +
+		"EMAIL;TYPE=INTERNET:" + email + "\r\n" +
+		"TEL;TYPE=CELL:08123456789\r\n" +
+		"ADR;TYPE=HOME:;;Jl. Test;Jakarta;DKI Jakarta;12345;Indonesia\r\n" +
+		"NOTE:Not kept\r\n" +
+		"END:VCARD\r\n"
+}
+
+const davPropfindBody = `<?xml version="1.0" encoding="utf-8"?>
+<D:propfind xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:carddav">
+  <D:prop><D:resourcetype/><D:displayname/><D:current-user-principal/><C:addressbook-home-set/><D:sync-token/><D:getetag/></D:prop>
+</D:propfind>`
+
+func TestCardDAVDiscovery(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testdavuser", "password123", "Test DAV User")
+	addressBookPath := davAddressBookPath(t, token)
+
+	resp, _ := davRequest(t, "PROPFIND", "/.well-known/carddav", "", "", nil, "")
+	assert.Equal(t, fiber.StatusMovedPermanently, resp.StatusCode)
+	assert.Equal(t, "/dav/", resp.Header.Get("Location"))
+
+	resp, _ = davRequest(t, "OPTIONS", "/dav/", "", "", nil, "")
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("DAV"), "addressbook")
+
+	// The root leads to the principal and the home
+	multistatus := davMultistatusRequest(t, "PROPFIND", "/dav/", "testdavuser", "0", davPropfindBody)
+	assert.Len(t, multistatus.Responses, 1)
+	assert.Contains(t, multistatus.Responses[0].Propstats[0].Prop.InnerXML, "<D:href>/dav/principal/</D:href>")
+	assert.Contains(t, multistatus.Responses[0].Propstats[0].Prop.InnerXML, "<D:href>/dav/addressbooks/</D:href>")
+	assert.Contains(t, multistatus.Responses[0].Propstats[1].Status, "404")
+
+	multistatus = davMultistatusRequest(t, "PROPFIND", "/dav/principal/", "testdavuser", "0", "")
+	assert.Equal(t, "Test DAV User", multistatus.Responses[0].Propstats[0].Prop.DisplayName)
+
+	// The home lists the address books
+	multistatus = davMultistatusRequest(t, "PROPFIND", "/dav/addressbooks/", "testdavuser", "1", davPropfindBody)
+	assert.Equal(t, []string{"/dav/addressbooks/", addressBookPath}, davHrefs(multistatus, "200"))
+	addressBook := multistatus.Responses[1].Propstats[0].Prop
+	assert.Equal(t, "Contacts", addressBook.DisplayName)
+	assert.Contains(t, addressBook.InnerXML, "<C:addressbook/>")
+	assert.NotEmpty(t, addressBook.SyncToken)
+
+	// Credentials are asked for
+	resp, _ = davRequest(t, "PROPFIND", "/dav/", "", "", nil, "")
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("WWW-Authenticate"), "Basic")
+
+	resp, _ = davRequest(t, "PROPFIND", "/dav/", "testdavuser", "wrongpassword", nil, "")
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+
+	cleanupTestData()
+}
+
+func TestCardDAVCards(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testdavuser", "password123", "Test DAV User")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	createTestAddress(t, token, contactID, "Jl. Test", "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+	addressBookPath := davAddressBookPath(t, token)
+
+	// Contacts of the API are cards named by their ID
+	resp, body := davRequest(t, "GET", addressBookPath+contactID+".vcf", "testdavuser", "password123", nil, "")
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/vcard")
+	assert.Contains(t, body, "FN:John Doe\r\n")
+	assert.Contains(t, body, "ADR:;;Jl. Test;Jakarta;DKI Jakarta;12345;Indonesia\r\n")
+
+	// A card the client creates keeps its name and UID
+	cardPath := addressBookPath + "new-card.vcf"
+	resp, body = davRequest(t, "PUT", cardPath, "testdavuser", "password123", map[string]string{"If-None-Match": "*"}, testVCard("new-card-uid", "Jane Doe", "jane@example.com"))
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode, body)
+	assert.Empty(t, resp.Header.Get("ETag"))
+
+	resp, body = davRequest(t, "GET", cardPath, "testdavuser", "password123", nil, "")
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Contains(t, body, "UID:new-card-uid\r\n")
+	assert.Contains(t, body, "N:Doe;Jane;;;\r\n")
+	assert.NotContains(t, body, "NOTE")
+	etag := resp.Header.Get("ETag")
+
+	status, response := adminRequest(t, "GET", "/api/contacts?name=jane", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	contacts := response.Data.(map[string]interface{})["contacts"].([]interface{})
+	assert.Len(t, contacts, 1)
+	janeID := fmt.Sprintf("%.0f", contacts[0].(map[string]interface{})["id"].(float64))
+	status, response = adminRequest(t, "GET", "/api/contacts/"+janeID+"/addresses", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, response.Data, 1)
+
+	resp, _ = davRequest(t, "PUT", cardPath, "testdavuser", "password123", map[string]string{"If-None-Match": "*"}, testVCard("new-card-uid", "Jane Doe", "jane@example.com"))
+	assert.Equal(t, fiber.StatusPreconditionFailed, resp.StatusCode)
+
+	// Replacing needs the current entity tag
+	changed := strings.Replace(testVCard("new-card-uid", "Jane Roe", "jane@example.com"), "ADR;TYPE=HOME:;;Jl. Test;Jakarta;DKI Jakarta;12345;Indonesia\r\n", "", 1)
+	resp, _ = davRequest(t, "PUT", cardPath, "testdavuser", "password123", map[string]string{"If-Match": `"stale"`}, changed)
+	assert.Equal(t, fiber.StatusPreconditionFailed, resp.StatusCode)
+	resp, body = davRequest(t, "PUT", cardPath, "testdavuser", "password123", map[string]string{"If-Match": etag}, changed)
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode, body)
+
+	status, response = adminRequest(t, "GET", "/api/contacts/"+janeID, token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "Roe", response.Data.(map[string]interface{})["last_name"])
+	status, response = adminRequest(t, "GET", "/api/contacts/"+janeID+"/addresses", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Empty(t, response.Data)
+
+	// Several cards at once, by href or by filter
+	multistatus := davMultistatusRequest(t, "REPORT", addressBookPath, "testdavuser", "1", `<?xml version="1.0" encoding="utf-8"?>
+<C:addressbook-multiget xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:carddav">
+  <D:prop><D:getetag/><C:address-data/></D:prop>
+  <D:href>`+addressBookPath+contactID+`.vcf</D:href>
+  <D:href>`+cardPath+`</D:href>
+  <D:href>`+addressBookPath+`missing.vcf</D:href>
+</C:addressbook-multiget>`)
+	assert.Equal(t, []string{addressBookPath + contactID + ".vcf", cardPath}, davHrefs(multistatus, "200"))
+	assert.Equal(t, []string{addressBookPath + "missing.vcf"}, davHrefs(multistatus, "404"))
+	assert.Contains(t, multistatus.Responses[1].Propstats[0].Prop.AddressData, "FN:Jane Roe\r\n")
+	assert.NotEmpty(t, multistatus.Responses[1].Propstats[0].Prop.ETag)
+
+	multistatus = davMultistatusRequest(t, "REPORT", addressBookPath, "testdavuser", "1", `<?xml version="1.0" encoding="utf-8"?>
+<C:addressbook-query xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:carddav">
+  <D:prop><D:getetag/></D:prop>
+  <C:filter><C:prop-filter name="EMAIL"><C:text-match match-type="starts-with">JANE@</C:text-match></C:prop-filter></C:filter>
+</C:addressbook-query>`)
+	assert.Equal(t, []string{cardPath}, davHrefs(multistatus, "200"))
+
+	// Listing the collection
+	multistatus = davMultistatusRequest(t, "PROPFIND", addressBookPath, "testdavuser", "1", davPropfindBody)
+	assert.Equal(t, []string{addressBookPath, addressBookPath + contactID + ".vcf", cardPath}, davHrefs(multistatus, "200"))
+
+	resp, _ = davRequest(t, "DELETE", cardPath, "testdavuser", "password123", nil, "")
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	resp, _ = davRequest(t, "GET", cardPath, "testdavuser", "password123", nil, "")
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+	cleanupTestData()
+}
+
+func TestCardDAVSyncCollection(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testdavuser", "password123", "Test DAV User")
+	updatedID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	deletedID := createTestContact(t, token, "Jane", "Doe", "jane@example.com", "08123456789")
+	unchangedID := createTestContact(t, token, "Jim", "Doe", "jim@example.com", "08123456789")
+	addressBookPath := davAddressBookPath(t, token)
+	backdateSyncData()
+
+	syncBody := func(token string) string {
+		return `<?xml version="1.0" encoding="utf-8"?>
+<D:sync-collection xmlns:D="DAV:">
+  <D:sync-token>` + token + `</D:sync-token>
+  <D:sync-level>1</D:sync-level>
+  <D:prop><D:getetag/></D:prop>
+</D:sync-collection>`
+	}
+
+	// Without a token everything there is
+	multistatus := davMultistatusRequest(t, "REPORT", addressBookPath, "testdavuser", "0", syncBody(""))
+	assert.ElementsMatch(t, []string{addressBookPath + updatedID + ".vcf", addressBookPath + deletedID + ".vcf", addressBookPath + unchangedID + ".vcf"}, davHrefs(multistatus, "200"))
+	assert.NotEmpty(t, multistatus.SyncToken)
+	syncToken := multistatus.SyncToken
+
+	status, _ := adminRequest(t, "PATCH", "/api/contacts/"+updatedID, token, map[string]interface{}{"phone": "08987654321"})
+	assert.Equal(t, fiber.StatusOK, status)
+	status, _ = adminRequest(t, "DELETE", "/api/contacts/"+deletedID, token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	resp, _ := davRequest(t, "PUT", addressBookPath+"created.vcf", "testdavuser", "password123", nil, testVCard("created-uid", "Jack Doe", "jack@example.com"))
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	multistatus = davMultistatusRequest(t, "REPORT", addressBookPath, "testdavuser", "0", syncBody(syncToken))
+	assert.ElementsMatch(t, []string{addressBookPath + updatedID + ".vcf", addressBookPath + "created.vcf"}, davHrefs(multistatus, "200"))
+	assert.Equal(t, []string{addressBookPath + deletedID + ".vcf"}, davHrefs(multistatus, "404"))
+
+	resp, _ = davRequest(t, "REPORT", addressBookPath, "testdavuser", "password123", nil, syncBody("yesterday"))
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+
+	cleanupTestData()
+}
+
+func TestCardDAVPermissions(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testdavowner", "password123", "Test DAV Owner")
+	viewerToken := registerAndLogin(t, "testdavviewer", "password123", "Test DAV Viewer")
+	registerAndLogin(t, "testdavother", "password123", "Test DAV Other")
+	joinOrganization(t, ownerToken, "testdavviewer")
+	contactID := createTestContact(t, ownerToken, "John", "Doe", "john@example.com", "08123456789")
+	shareAddressBook(t, ownerToken, defaultAddressBookID(t, ownerToken), "testdavviewer", viewerToken, "viewer")
+	addressBookPath := davAddressBookPath(t, ownerToken)
+
+	// Viewers read but do not write
+	resp, _ := davRequest(t, "GET", addressBookPath+contactID+".vcf", "testdavviewer", "password123", nil, "")
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	resp, _ = davRequest(t, "PUT", addressBookPath+contactID+".vcf", "testdavviewer", "password123", nil, testVCard("x", "John Roe", "john@example.com"))
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	resp, _ = davRequest(t, "DELETE", addressBookPath+contactID+".vcf", "testdavviewer", "password123", nil, "")
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+
+	multistatus := davMultistatusRequest(t, "PROPFIND", addressBookPath, "testdavviewer", "0", "")
+	privileges := multistatus.Responses[0].Propstats[0].Prop.InnerXML
+	assert.Contains(t, privileges, "<D:read/>")
+	assert.NotContains(t, privileges, "<D:write/>")
+
+	// The viewer's home has the shared address book along with their own
+	multistatus = davMultistatusRequest(t, "PROPFIND", "/dav/addressbooks/", "testdavviewer", "1", davPropfindBody)
+	assert.Contains(t, davHrefs(multistatus, "200"), addressBookPath)
+
+	// Others find nothing
+	resp, _ = davRequest(t, "GET", addressBookPath+contactID+".vcf", "testdavother", "password123", nil, "")
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+	// A bad vCard
+	resp, _ = davRequest(t, "PUT", addressBookPath+"bad.vcf", "testdavowner", "password123", nil, "FN:John Doe\r\n")
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	cleanupTestData()
+}
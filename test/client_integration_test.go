@@ -0,0 +1,169 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/client"
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/address"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+	"github.com/sorfian/go-contact-management-api/model/web/user"
+	"github.com/stretchr/testify/assert"
+)
+
+// appDoer sends client requests straight to the Fiber app
+type appDoer struct {
+	app *fiber.App
+}
+
+func (doer appDoer) Do(req *http.Request) (*http.Response, error) {
+	return doer.app.Test(req, -1)
+}
+
+func newTestClient() *client.Client {
+	return client.NewClient("http://localhost/api", appDoer{app: testApp})
+}
+
+func TestClientContactsAndAddresses(t *testing.T) {
+	cleanupTestData()
+
+	ctx := context.Background()
+	apiClient := newTestClient()
+
+	_, err := apiClient.Register(ctx, &user.UserRegisterRequest{Username: "testclient1", Password: "password123", Name: "Test Client User 1"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, apiClient.Token())
+
+	currentUser, err := apiClient.GetCurrentUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "testclient1", currentUser.Username)
+
+	created, err := apiClient.CreateContact(ctx, &contact.ContactCreateRequest{FirstName: "John", LastName: "Doe", Email: "john@example.com", Phone: "08123456789"})
+	assert.NoError(t, err)
+	assert.Equal(t, "John", created.FirstName)
+	assert.Equal(t, int64(1), created.Version)
+
+	updated, err := apiClient.UpdateContact(ctx, created.ID, &contact.ContactUpdateRequest{Phone: web.SetString("08999999999")}, created.Version)
+	assert.NoError(t, err)
+	assert.Equal(t, "John", updated.FirstName)
+	assert.Equal(t, "08999999999", updated.Phone)
+
+	// The first version is stale now
+	_, err = apiClient.UpdateContact(ctx, created.ID, &contact.ContactUpdateRequest{Phone: web.SetString("08111111111")}, created.Version)
+	assert.True(t, client.IsPreconditionFailed(err))
+
+	searchResult, err := apiClient.SearchContacts(ctx, contact.SearchParams{Name: "John"})
+	assert.NoError(t, err)
+	assert.Len(t, searchResult.Contacts, 1)
+	assert.Equal(t, 1, searchResult.Paging.TotalItem)
+
+	createdAddress, err := apiClient.CreateAddress(ctx, created.ID, &address.AddressCreateRequest{Street: "Main Street", City: "Jakarta", Province: "DKI Jakarta", Country: "Indonesia", PostalCode: "12345"})
+	assert.NoError(t, err)
+
+	addresses, err := apiClient.ListAddresses(ctx, created.ID)
+	assert.NoError(t, err)
+	assert.Len(t, addresses, 1)
+
+	replacedAddress, err := apiClient.ReplaceAddress(ctx, created.ID, createdAddress.ID, &address.AddressCreateRequest{Street: "Second Street", City: "Bandung", Province: "West Java", Country: "Indonesia", PostalCode: "40111"}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bandung", replacedAddress.City)
+
+	err = apiClient.DeleteContact(ctx, created.ID, 0)
+	assert.NoError(t, err)
+
+	_, err = apiClient.GetContact(ctx, created.ID)
+	assert.True(t, client.IsNotFound(err))
+
+	var apiError *client.APIError
+	assert.ErrorAs(t, err, &apiError)
+	assert.Equal(t, "Not Found", apiError.Status)
+	assert.Equal(t, "contact not found", apiError.Message)
+
+	cleanupTestData()
+}
+
+func TestClientUpdateContactClearsMember(t *testing.T) {
+	cleanupTestData()
+
+	ctx := context.Background()
+	apiClient := newTestClient()
+
+	_, err := apiClient.Register(ctx, &user.UserRegisterRequest{Username: "testclient3", Password: "password123", Name: "Test Client User 3"})
+	assert.NoError(t, err)
+
+	created, err := apiClient.CreateContact(ctx, &contact.ContactCreateRequest{FirstName: "John", LastName: "Doe", Email: "john@example.com", Phone: "08123456789"})
+	assert.NoError(t, err)
+
+	// Phone is sent as null, the members left unset are kept
+	updated, err := apiClient.UpdateContact(ctx, created.ID, &contact.ContactUpdateRequest{Phone: web.ClearString()}, created.Version)
+	assert.NoError(t, err)
+	assert.Equal(t, "", updated.Phone)
+	assert.Equal(t, "john@example.com", updated.Email)
+	assert.Equal(t, "John", updated.FirstName)
+
+	fetched, err := apiClient.GetContact(ctx, created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "", fetched.Phone)
+
+	cleanupTestData()
+}
+
+func TestClientUpdateAddressClearsMember(t *testing.T) {
+	cleanupTestData()
+
+	ctx := context.Background()
+	apiClient := newTestClient()
+
+	_, err := apiClient.Register(ctx, &user.UserRegisterRequest{Username: "testclient4", Password: "password123", Name: "Test Client User 4"})
+	assert.NoError(t, err)
+
+	createdContact, err := apiClient.CreateContact(ctx, &contact.ContactCreateRequest{FirstName: "John", LastName: "Doe", Email: "john@example.com", Phone: "08123456789"})
+	assert.NoError(t, err)
+	created, err := apiClient.CreateAddress(ctx, createdContact.ID, &address.AddressCreateRequest{Street: "Main Street", City: "Jakarta", Province: "DKI Jakarta", Country: "Indonesia", PostalCode: "12345"})
+	assert.NoError(t, err)
+
+	updated, err := apiClient.UpdateAddress(ctx, createdContact.ID, created.ID, &address.AddressUpdateRequest{Province: web.ClearString(), PostalCode: web.ClearString()}, created.Version)
+	assert.NoError(t, err)
+	assert.Equal(t, "", updated.Province)
+	assert.Equal(t, "", updated.PostalCode)
+	assert.Equal(t, "Main Street", updated.Street)
+
+	cleanupTestData()
+}
+
+func TestClientRefreshesTokenOnUnauthorized(t *testing.T) {
+	cleanupTestData()
+
+	ctx := context.Background()
+	apiClient := newTestClient()
+
+	_, err := apiClient.Register(ctx, &user.UserRegisterRequest{Username: "testclient2", Password: "password123", Name: "Test Client User 2"})
+	assert.NoError(t, err)
+
+	refreshed := 0
+	apiClient.OnUnauthorized = func(ctx context.Context, apiClient *client.Client) (string, error) {
+		refreshed++
+		tokenResponse, err := apiClient.Login(ctx, &user.UserLoginRequest{Username: "testclient2", Password: "password123"})
+		return tokenResponse.Token, err
+	}
+
+	apiClient.SetToken("expired-token")
+
+	currentUser, err := apiClient.GetCurrentUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "testclient2", currentUser.Username)
+	assert.Equal(t, 1, refreshed)
+
+	cleanupTestData()
+}
+
+func TestClientUnauthorizedWithoutRefresh(t *testing.T) {
+	ctx := context.Background()
+	apiClient := newTestClient()
+
+	_, err := apiClient.GetCurrentUser(ctx)
+	assert.True(t, client.IsUnauthorized(err))
+}
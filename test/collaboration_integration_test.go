@@ -0,0 +1,181 @@
+package test
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fasthttp/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// openCollaboration connects to the collaboration endpoint with the
+// credentials in the query, the way a browser does. The connection is closed
+// when the test ends.
+func openCollaboration(t *testing.T, token, organizationID string) *websocket.Conn {
+	query := url.Values{"token": {token}}
+	if organizationID != "" {
+		query.Set("organization_id", organizationID)
+	}
+	endpoint := strings.Replace(serveTestApp(), "http://", "ws://", 1) + "/api/collaboration?" + query.Encode()
+
+	conn, resp, err := websocket.DefaultDialer.Dial(endpoint, nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, fiber.StatusSwitchingProtocols, resp.StatusCode)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func sendCollaboration(t *testing.T, conn *websocket.Conn, messageType, contactID string) {
+	message := map[string]interface{}{"type": messageType}
+	if contactID != "" {
+		message["contact_id"] = parseTestID(contactID)
+	}
+	assert.NoError(t, conn.WriteJSON(message))
+}
+
+// nextCollaboration returns the next message of the given type, skipping others
+func nextCollaboration(t *testing.T, conn *websocket.Conn, messageType string) map[string]interface{} {
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		message := map[string]interface{}{}
+		if err := conn.ReadJSON(&message); err != nil {
+			t.Fatalf("no %s message: %v", messageType, err)
+		}
+		if message["type"] == messageType {
+			return message
+		}
+	}
+}
+
+func presenceUsernames(message map[string]interface{}) []string {
+	usernames := []string{}
+	for _, viewer := range message["viewers"].([]interface{}) {
+		usernames = append(usernames, viewer.(map[string]interface{})["username"].(string))
+	}
+	return usernames
+}
+
+func TestCollaborationPresence(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testcollabowner", "password123", "Test Collab Owner")
+	viewerToken := registerAndLogin(t, "testcollabviewer", "password123", "Test Collab Viewer")
+	joinOrganization(t, ownerToken, "testcollabviewer")
+	contactID := createTestContact(t, ownerToken, "John", "Doe", "john@example.com", "08123456789")
+	shareAddressBook(t, ownerToken, defaultAddressBookID(t, ownerToken), "testcollabviewer", viewerToken, "viewer")
+	organizationID := defaultOrganizationID(t, ownerToken)
+
+	owner := openCollaboration(t, ownerToken, organizationID)
+	sendCollaboration(t, owner, "subscribe", contactID)
+	assert.Equal(t, float64(parseTestID(contactID)), nextCollaboration(t, owner, "subscribed")["contact_id"])
+	assert.Empty(t, presenceUsernames(nextCollaboration(t, owner, "presence")))
+
+	viewer := openCollaboration(t, viewerToken, organizationID)
+	sendCollaboration(t, viewer, "subscribe", contactID)
+	nextCollaboration(t, viewer, "subscribed")
+	assert.Equal(t, []string{"testcollabowner"}, presenceUsernames(nextCollaboration(t, viewer, "presence")))
+	assert.Equal(t, []string{"testcollabviewer"}, presenceUsernames(nextCollaboration(t, owner, "presence")))
+
+	// Leaving shows right away, closing the connection as well
+	sendCollaboration(t, viewer, "unsubscribe", contactID)
+	nextCollaboration(t, viewer, "unsubscribed")
+	assert.Empty(t, presenceUsernames(nextCollaboration(t, owner, "presence")))
+
+	sendCollaboration(t, viewer, "subscribe", contactID)
+	assert.Equal(t, []string{"testcollabviewer"}, presenceUsernames(nextCollaboration(t, owner, "presence")))
+	viewer.Close()
+	assert.Empty(t, presenceUsernames(nextCollaboration(t, owner, "presence")))
+
+	cleanupTestData()
+}
+
+func TestCollaborationChanges(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testcollabowner", "password123", "Test Collab Owner")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	otherContactID := createTestContact(t, token, "Jane", "Doe", "jane@example.com", "08123456789")
+	dispatchPendingEvents()
+
+	conn := openCollaboration(t, token, "")
+	sendCollaboration(t, conn, "subscribe", contactID)
+	nextCollaboration(t, conn, "subscribed")
+
+	// Only the subscribed contact
+	status, _ := adminRequest(t, "PATCH", "/api/contacts/"+otherContactID, token, map[string]interface{}{"phone": "08111111111"})
+	assert.Equal(t, fiber.StatusOK, status)
+	createTestAddress(t, token, contactID, "Jl. Test", "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+	dispatchPendingEvents()
+
+	message := nextCollaboration(t, conn, "change")
+	assert.Equal(t, "address.created", message["event"])
+	assert.Equal(t, float64(parseTestID(contactID)), message["contact_id"])
+	assert.Equal(t, "Jakarta", message["data"].(map[string]interface{})["address"].(map[string]interface{})["city"])
+
+	// The whole list
+	sendCollaboration(t, conn, "subscribe", "")
+	nextCollaboration(t, conn, "subscribed")
+	status, _ = adminRequest(t, "PATCH", "/api/contacts/"+otherContactID, token, map[string]interface{}{"phone": "08222222222"})
+	assert.Equal(t, fiber.StatusOK, status)
+	dispatchPendingEvents()
+
+	message = nextCollaboration(t, conn, "change")
+	assert.Equal(t, "contact.updated", message["event"])
+	assert.Equal(t, float64(parseTestID(otherContactID)), message["contact_id"])
+	assert.Equal(t, "08222222222", message["data"].(map[string]interface{})["phone"])
+
+	cleanupTestData()
+}
+
+func TestCollaborationEndsOnLogout(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testcollabowner", "password123", "Test Collab Owner")
+
+	conn := openCollaboration(t, token, "")
+	status, _ := adminRequest(t, "DELETE", "/api/users/logout", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	// The server closes the connection, rather than the read timing out
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var err error
+	for err == nil {
+		_, _, err = conn.ReadMessage()
+	}
+	var netErr net.Error
+	assert.False(t, errors.As(err, &netErr) && netErr.Timeout(), "connection still open")
+
+	cleanupTestData()
+}
+
+func TestCollaborationRejected(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testcollabowner", "password123", "Test Collab Owner")
+	otherToken := registerAndLogin(t, "testcollabother", "password123", "Test Collab Other")
+	contactID := createTestContact(t, otherToken, "John", "Doe", "john@example.com", "08123456789")
+
+	// Someone else's contact
+	conn := openCollaboration(t, token, "")
+	sendCollaboration(t, conn, "subscribe", contactID)
+	message := nextCollaboration(t, conn, "error")
+	assert.Equal(t, float64(parseTestID(contactID)), message["contact_id"])
+	assert.Equal(t, "contact not found", message["message"])
+
+	assert.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("hello")))
+	assert.Equal(t, "message must be a JSON object", nextCollaboration(t, conn, "error")["message"])
+
+	// Not a WebSocket handshake, or no token
+	status, _ := adminRequest(t, "GET", "/api/collaboration", token, nil)
+	assert.Equal(t, fiber.StatusUpgradeRequired, status)
+
+	_, resp, err := websocket.DefaultDialer.Dial(strings.Replace(serveTestApp(), "http://", "ws://", 1)+"/api/collaboration", nil)
+	assert.ErrorIs(t, err, websocket.ErrBadHandshake)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	cleanupTestData()
+}
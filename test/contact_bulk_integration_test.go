@@ -0,0 +1,149 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkContactsTransactionSuccess(t *testing.T) {
+	cleanupTestData()
+
+	token := registerAndLogin(t, "testbulk1", "password123", "Test Bulk User 1")
+	contactID := createTestContact(t, token, "Original", "Name", "original@example.com", "08111111111")
+	deleteID := createTestContact(t, token, "ToDelete", "User", "delete@example.com", "08222222222")
+
+	requestBody := contact.BulkRequest{
+		Operations: []contact.BulkOperation{
+			{Operation: "create", Data: json.RawMessage(`{"first_name":"John","last_name":"Doe","email":"john@example.com","phone":"08123456789"}`)},
+			{Operation: "update", ID: parseTestID(contactID), Data: json.RawMessage(`{"first_name":"Updated"}`)},
+			{Operation: "delete", ID: parseTestID(deleteID)},
+		},
+	}
+
+	result := sendBulkRequest(t, token, requestBody)
+
+	assert.Equal(t, "transaction", result["mode"])
+	assert.Equal(t, true, result["committed"])
+
+	results := result["results"].([]interface{})
+	assert.Len(t, results, 3)
+	assert.Equal(t, float64(201), results[0].(map[string]interface{})["code"])
+	assert.Equal(t, float64(200), results[1].(map[string]interface{})["code"])
+	assert.Equal(t, "Updated", results[1].(map[string]interface{})["data"].(map[string]interface{})["first_name"])
+	assert.Equal(t, float64(200), results[2].(map[string]interface{})["code"])
+
+	// Verify the deleted contact is gone
+	req := httptest.NewRequest("GET", "/api/contacts/"+deleteID, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 404, resp.StatusCode)
+
+	cleanupTestData()
+}
+
+func TestBulkContactsTransactionRollback(t *testing.T) {
+	cleanupTestData()
+
+	token := registerAndLogin(t, "testbulk2", "password123", "Test Bulk User 2")
+	contactID := createTestContact(t, token, "Original", "Name", "original@example.com", "08111111111")
+
+	requestBody := contact.BulkRequest{
+		Mode: "transaction",
+		Operations: []contact.BulkOperation{
+			{Operation: "update", ID: parseTestID(contactID), Data: json.RawMessage(`{"first_name":"Updated"}`)},
+			{Operation: "delete", ID: 99999},
+			{Operation: "delete", ID: parseTestID(contactID)},
+		},
+	}
+
+	result := sendBulkRequest(t, token, requestBody)
+
+	assert.Equal(t, false, result["committed"])
+
+	results := result["results"].([]interface{})
+	assert.Len(t, results, 3)
+	assert.Equal(t, float64(404), results[1].(map[string]interface{})["code"])
+	assert.Equal(t, "contact not found", results[1].(map[string]interface{})["data"])
+	assert.Equal(t, float64(424), results[2].(map[string]interface{})["code"])
+
+	// Verify the update was rolled back
+	req := httptest.NewRequest("GET", "/api/contacts/"+contactID, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var response web.Response
+	err = json.Unmarshal(body, &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Original", response.Data.(map[string]interface{})["first_name"])
+
+	cleanupTestData()
+}
+
+func TestBulkContactsBestEffort(t *testing.T) {
+	cleanupTestData()
+
+	token := registerAndLogin(t, "testbulk3", "password123", "Test Bulk User 3")
+
+	requestBody := contact.BulkRequest{
+		Mode: "best_effort",
+		Operations: []contact.BulkOperation{
+			{Operation: "create", Data: json.RawMessage(`{"first_name":"","last_name":"","email":"invalid-email","phone":""}`)},
+			{Operation: "create", Data: json.RawMessage(`{"first_name":"Jane","last_name":"Doe","email":"jane@example.com","phone":"08123456789"}`)},
+		},
+	}
+
+	result := sendBulkRequest(t, token, requestBody)
+
+	assert.Equal(t, "best_effort", result["mode"])
+
+	results := result["results"].([]interface{})
+	assert.Len(t, results, 2)
+	assert.Equal(t, float64(400), results[0].(map[string]interface{})["code"])
+	assert.Equal(t, "Bad Request", results[0].(map[string]interface{})["status"])
+	assert.Equal(t, float64(201), results[1].(map[string]interface{})["code"])
+
+	cleanupTestData()
+}
+
+// Helper function to send a bulk request and return its data
+func sendBulkRequest(t *testing.T, token string, requestBody contact.BulkRequest) map[string]interface{} {
+	bodyJSON, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest("POST", "/api/contacts/bulk", bytes.NewReader(bodyJSON))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var response web.Response
+	err = json.Unmarshal(body, &response)
+	assert.NoError(t, err)
+
+	result, ok := response.Data.(map[string]interface{})
+	if !ok {
+		t.Fatal("Failed to get bulk result")
+	}
+
+	return result
+}
+
+func parseTestID(id string) int64 {
+	parsedID, _ := strconv.ParseInt(id, 10, 64)
+	return parsedID
+}
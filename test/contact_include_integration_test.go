@@ -0,0 +1,79 @@
+package test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllContactsIncludeAddresses(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testincludeuser", "password123", "Test Include User")
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		contactID := createTestContact(t, token, name, "Doe", "include@example.com", "08123456789")
+		createTestAddress(t, token, contactID, "Jl. "+name, "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+		createTestAddress(t, token, contactID, "Jl. "+name+" 2", "Bandung", "Jawa Barat", "Indonesia", "40111")
+	}
+	createTestContact(t, token, "Dave", "Doe", "include@example.com", "08123456789")
+
+	stopCounting := countQueries(t, "addresses")
+	status, response := adminRequest(t, "GET", "/api/contacts?name=doe&include=addresses", token, nil)
+	addressQueries := stopCounting()
+	assert.Equal(t, fiber.StatusOK, status)
+
+	contacts := response.Data.(map[string]interface{})["contacts"].([]interface{})
+	assert.Len(t, contacts, 4)
+
+	addressCounts := map[string]int{}
+	for _, item := range contacts {
+		contact := item.(map[string]interface{})
+		addressCounts[contact["first_name"].(string)] = len(contact["addresses"].([]interface{}))
+	}
+	assert.Equal(t, map[string]int{"Alice": 2, "Bob": 2, "Carol": 2, "Dave": 0}, addressCounts)
+
+	// The addresses of the whole page come from a single query
+	assert.Equal(t, int64(1), addressQueries)
+
+	// Without include there are no addresses and no query for them
+	stopCounting = countQueries(t, "addresses")
+	status, response = adminRequest(t, "GET", "/api/contacts?name=doe", token, nil)
+	assert.Equal(t, int64(0), stopCounting())
+	assert.Equal(t, fiber.StatusOK, status)
+	for _, item := range response.Data.(map[string]interface{})["contacts"].([]interface{}) {
+		assert.NotContains(t, item.(map[string]interface{}), "addresses")
+	}
+
+	cleanupTestData()
+}
+
+func TestGetContactIncludeAddresses(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testincludeuser", "password123", "Test Include User")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	createTestAddress(t, token, contactID, "Jl. Test", "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+
+	status, response := adminRequest(t, "GET", "/api/contacts/"+contactID+"?include=addresses", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	addresses := response.Data.(map[string]interface{})["addresses"].([]interface{})
+	if assert.Len(t, addresses, 1) {
+		assert.Equal(t, "Jl. Test", addresses[0].(map[string]interface{})["street"])
+		assert.Equal(t, "12345", addresses[0].(map[string]interface{})["postal_code"])
+	}
+
+	// The contact's version does not cover its addresses, so a response
+	// embedding them is always sent in full
+	req := httptest.NewRequest("GET", "/api/contacts/"+contactID+"?include=addresses", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("If-None-Match", `"1"`)
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"1"`, resp.Header.Get("ETag"))
+
+	status, _ = adminRequest(t, "GET", "/api/contacts/"+contactID+"?include=phones", token, nil)
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	cleanupTestData()
+}
@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/sorfian/go-contact-management-api/model/domain"
 	"github.com/sorfian/go-contact-management-api/model/web"
 	"github.com/sorfian/go-contact-management-api/model/web/contact"
 	"github.com/stretchr/testify/assert"
@@ -192,10 +193,10 @@ func TestUpdateContactSuccess(t *testing.T) {
 
 	// Update contact
 	updateBody := contact.ContactUpdateRequest{
-		FirstName: "Updated",
-		LastName:  "Name",
-		Email:     "updated@example.com",
-		Phone:     "08999999999",
+		FirstName: web.SetString("Updated"),
+		LastName:  web.SetString("Name"),
+		Email:     web.SetString("updated@example.com"),
+		Phone:     web.SetString("08999999999"),
 	}
 
 	updateJSON, _ := json.Marshal(updateBody)
@@ -226,7 +227,7 @@ func TestUpdateContactNotFound(t *testing.T) {
 	token := registerAndLogin(t, "testcontact7", "password123", "Test Contact User 7")
 
 	updateBody := contact.ContactUpdateRequest{
-		FirstName: "Updated",
+		FirstName: web.SetString("Updated"),
 	}
 
 	updateJSON, _ := json.Marshal(updateBody)
@@ -283,6 +284,41 @@ func TestDeleteContactNotFound(t *testing.T) {
 	cleanupTestData()
 }
 
+func TestDeleteContactCascadesAddresses(t *testing.T) {
+	cleanupTestData()
+
+	token := registerAndLogin(t, "testcontact10", "password123", "Test Contact User 10")
+
+	contactID := createTestContact(t, token, "Cascade", "User", "cascade@example.com", "08111111111")
+	createTestAddress(t, token, contactID, "Street 1", "City", "Province", "Indonesia", "12345")
+	createTestAddress(t, token, contactID, "Street 2", "City", "Province", "Indonesia", "12345")
+
+	req := httptest.NewRequest("DELETE", "/api/contacts/"+contactID, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	// Verify addresses are soft-deleted under the contact's batch
+	var deletedContact domain.Contact
+	err = testDB.Unscoped().Where("id = ?", contactID).First(&deletedContact).Error
+	assert.NoError(t, err)
+	assert.True(t, deletedContact.DeletedAt.Valid)
+	assert.NotNil(t, deletedContact.DeletionBatchID)
+
+	var addresses []domain.Address
+	err = testDB.Unscoped().Where("contact_id = ?", contactID).Find(&addresses).Error
+	assert.NoError(t, err)
+	assert.Len(t, addresses, 2)
+	for _, deletedAddress := range addresses {
+		assert.True(t, deletedAddress.DeletedAt.Valid)
+		assert.Equal(t, deletedContact.DeletionBatchID, deletedAddress.DeletionBatchID)
+	}
+
+	cleanupTestData()
+}
+
 // Helper function to create a test contact and return its ID
 func createTestContact(t *testing.T, token, firstName, lastName, email, phone string) string {
 	requestBody := contact.ContactCreateRequest{
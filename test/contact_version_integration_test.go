@@ -0,0 +1,154 @@
+package test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// contactVersions returns the versions of a version list response
+func contactVersions(data interface{}) []interface{} {
+	return data.(map[string]interface{})["versions"].([]interface{})
+}
+
+func versionSnapshot(version interface{}) map[string]interface{} {
+	return version.(map[string]interface{})["snapshot"].(map[string]interface{})
+}
+
+func TestContactVersions(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testversionuser", "password123", "Test Version User")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	createTestAddress(t, token, contactID, "Jl. Test", "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+	status, _ := adminRequest(t, "PATCH", "/api/contacts/"+contactID, token, map[string]interface{}{"phone": "08987654321"})
+	assert.Equal(t, fiber.StatusOK, status)
+
+	status, response := adminRequest(t, "GET", "/api/contacts/"+contactID+"/versions", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	versions := contactVersions(response.Data)
+	assert.Len(t, versions, 3)
+	assert.Equal(t, float64(3), versions[0].(map[string]interface{})["number"])
+	assert.Equal(t, float64(2), versions[0].(map[string]interface{})["contact_version"])
+	assert.Equal(t, "08987654321", versionSnapshot(versions[0])["phone"])
+	assert.Len(t, versionSnapshot(versions[0])["addresses"], 1)
+	assert.Len(t, versionSnapshot(versions[2])["addresses"], 0)
+
+	status, response = adminRequest(t, "GET", "/api/contacts/"+contactID+"/versions/1", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "08123456789", versionSnapshot(response.Data)["phone"])
+
+	status, response = adminRequest(t, "GET", "/api/contacts/"+contactID+"/versions/9", token, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+	assert.Equal(t, "contact version not found", response.Data)
+
+	cleanupTestData()
+}
+
+func TestContactRevert(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testversionuser", "password123", "Test Version User")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	status, _ := adminRequest(t, "PATCH", "/api/contacts/"+contactID, token, map[string]interface{}{"phone": "08987654321"})
+	assert.Equal(t, fiber.StatusOK, status)
+	createTestAddress(t, token, contactID, "Jl. Test", "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+
+	status, response := adminRequest(t, "POST", "/api/contacts/"+contactID+"/versions/1/revert", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	reverted := response.Data.(map[string]interface{})
+	assert.Equal(t, "08123456789", reverted["phone"])
+	assert.Equal(t, float64(3), reverted["version"])
+
+	// The address added after version 1 is gone again
+	status, response = adminRequest(t, "GET", "/api/contacts/"+contactID+"/addresses", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, response.Data, 0)
+
+	// Reverting adds a version and keeps the ones it went back over
+	status, response = adminRequest(t, "GET", "/api/contacts/"+contactID+"/versions", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	versions := contactVersions(response.Data)
+	assert.Len(t, versions, 4)
+	assert.Equal(t, "08123456789", versionSnapshot(versions[0])["phone"])
+	assert.Len(t, versionSnapshot(versions[0])["addresses"], 0)
+
+	status, response = adminRequest(t, "GET", "/api/contacts/"+contactID+"/history", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "update", auditLogs(response.Data)[0].(map[string]interface{})["action"])
+
+	cleanupTestData()
+}
+
+func TestContactRevertClearsMembers(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testversionuser", "password123", "Test Version User")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+
+	// Version 2 has neither email nor phone, version 3 sets them again
+	status, _ := adminRequest(t, "PATCH", "/api/contacts/"+contactID, token, map[string]interface{}{"email": nil, "phone": nil})
+	assert.Equal(t, fiber.StatusOK, status)
+	status, _ = adminRequest(t, "PATCH", "/api/contacts/"+contactID, token, map[string]interface{}{"email": "jane@example.com", "phone": "08987654321"})
+	assert.Equal(t, fiber.StatusOK, status)
+
+	status, response := adminRequest(t, "POST", "/api/contacts/"+contactID+"/versions/2/revert", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	reverted := response.Data.(map[string]interface{})
+	assert.Equal(t, "", reverted["email"])
+	assert.Equal(t, "", reverted["phone"])
+	assert.Equal(t, float64(4), reverted["version"])
+
+	status, response = adminRequest(t, "GET", "/api/contacts/"+contactID, token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "", response.Data.(map[string]interface{})["email"])
+	assert.Equal(t, "", response.Data.(map[string]interface{})["phone"])
+
+	cleanupTestData()
+}
+
+func TestContactRevertRestoresDeletedAddress(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testversionuser", "password123", "Test Version User")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	addressID := createTestAddress(t, token, contactID, "Jl. Test", "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+	status, _ := adminRequest(t, "PATCH", "/api/contacts/"+contactID+"/addresses/"+addressID, token, map[string]interface{}{"city": "Bandung"})
+	assert.Equal(t, fiber.StatusOK, status)
+	status, _ = adminRequest(t, "DELETE", "/api/contacts/"+contactID+"/addresses/"+addressID, token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	status, _ = adminRequest(t, "POST", "/api/contacts/"+contactID+"/versions/2/revert", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	status, response := adminRequest(t, "GET", "/api/contacts/"+contactID+"/addresses", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	addresses := response.Data.([]interface{})
+	assert.Len(t, addresses, 1)
+	assert.Equal(t, "Jakarta", addresses[0].(map[string]interface{})["city"])
+
+	cleanupTestData()
+}
+
+func TestContactRevertPreconditions(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testversionuser", "password123", "Test Version User")
+	otherToken := registerAndLogin(t, "testversionother", "password123", "Test Version Other")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+
+	req := httptest.NewRequest("POST", "/api/contacts/"+contactID+"/versions/1/revert", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set(fiber.HeaderIfMatch, `"7"`)
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusPreconditionFailed, resp.StatusCode)
+
+	status, _ := adminRequest(t, "POST", "/api/contacts/"+contactID+"/versions/5/revert", token, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	status, _ = adminRequest(t, "POST", "/api/contacts/"+contactID+"/versions/1/revert", otherToken, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	status, _ = adminRequest(t, "GET", "/api/contacts/"+contactID+"/versions", otherToken, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	cleanupTestData()
+}
@@ -0,0 +1,100 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/address"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetContactNotModified(t *testing.T) {
+	cleanupTestData()
+
+	token := registerAndLogin(t, "testetag1", "password123", "Test ETag User 1")
+	contactID := createTestContact(t, token, "Jane", "Doe", "jane@example.com", "08123456789")
+
+	req := httptest.NewRequest("GET", "/api/contacts/"+contactID, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, `"1"`, resp.Header.Get("ETag"))
+
+	// Same tag again returns 304 without a body
+	req2 := httptest.NewRequest("GET", "/api/contacts/"+contactID, nil)
+	req2.Header.Set("Authorization", "Bearer "+token)
+	req2.Header.Set("If-None-Match", resp.Header.Get("ETag"))
+
+	resp2, err := testApp.Test(req2, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 304, resp2.StatusCode)
+
+	cleanupTestData()
+}
+
+func TestUpdateContactPreconditionFailed(t *testing.T) {
+	cleanupTestData()
+
+	token := registerAndLogin(t, "testetag2", "password123", "Test ETag User 2")
+	contactID := createTestContact(t, token, "Original", "Name", "original@example.com", "08111111111")
+
+	// First writer holds version 1 and succeeds
+	updateJSON, _ := json.Marshal(contact.ContactUpdateRequest{FirstName: web.SetString("First")})
+	req := httptest.NewRequest("PATCH", "/api/contacts/"+contactID, bytes.NewReader(updateJSON))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("If-Match", `"1"`)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, `"2"`, resp.Header.Get("ETag"))
+
+	// Second writer still holds version 1 and is rejected
+	updateJSON2, _ := json.Marshal(contact.ContactUpdateRequest{FirstName: web.SetString("Second")})
+	req2 := httptest.NewRequest("PATCH", "/api/contacts/"+contactID, bytes.NewReader(updateJSON2))
+	req2.Header.Set("Content-Type", "application/json")
+	req2.Header.Set("Authorization", "Bearer "+token)
+	req2.Header.Set("If-Match", `"1"`)
+
+	resp2, err := testApp.Test(req2, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 412, resp2.StatusCode)
+
+	// Delete with a stale tag is rejected as well
+	req3 := httptest.NewRequest("DELETE", "/api/contacts/"+contactID, nil)
+	req3.Header.Set("Authorization", "Bearer "+token)
+	req3.Header.Set("If-Match", `"1"`)
+
+	resp3, err := testApp.Test(req3, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 412, resp3.StatusCode)
+
+	cleanupTestData()
+}
+
+func TestUpdateAddressPreconditionFailed(t *testing.T) {
+	cleanupTestData()
+
+	token := registerAndLogin(t, "testetag3", "password123", "Test ETag User 3")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	addressID := createTestAddress(t, token, contactID, "Old Street", "City", "Province", "Indonesia", "12345")
+
+	updateJSON, _ := json.Marshal(address.AddressUpdateRequest{Street: web.SetString("New Street")})
+	req := httptest.NewRequest("PATCH", "/api/contacts/"+contactID+"/addresses/"+addressID, bytes.NewReader(updateJSON))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("If-Match", `"5"`)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 412, resp.StatusCode)
+
+	cleanupTestData()
+}
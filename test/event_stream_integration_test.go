@@ -0,0 +1,278 @@
+package test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/repository"
+	"github.com/sorfian/go-contact-management-api/service"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testServerURL  string
+	testServerOnce sync.Once
+)
+
+// serveTestApp serves the test app on a local port, app.Test waits for the
+// whole response and an event stream never ends
+func serveTestApp() string {
+	testServerOnce.Do(func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			panic(err)
+		}
+		go testApp.Listener(listener)
+		testServerURL = "http://" + listener.Addr().String()
+	})
+	return testServerURL
+}
+
+// streamMessage is one message of an event stream, or a comment
+type streamMessage struct {
+	ID      string
+	Event   string
+	Data    string
+	Comment string
+}
+
+// openEventStream connects to the event stream and returns its messages. The
+// connection is closed when the test ends.
+func openEventStream(t *testing.T, token, lastEventID string) <-chan streamMessage {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", serveTestApp()+"/api/events/stream", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get(fiber.HeaderContentType))
+
+	messages := make(chan streamMessage, 100)
+	go func() {
+		defer resp.Body.Close()
+		defer close(messages)
+
+		message := streamMessage{}
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				messages <- message
+				message = streamMessage{}
+			case strings.HasPrefix(line, ":"):
+				message.Comment = strings.TrimSpace(line[1:])
+			case strings.HasPrefix(line, "id: "):
+				message.ID = line[len("id: "):]
+			case strings.HasPrefix(line, "event: "):
+				message.Event = line[len("event: "):]
+			case strings.HasPrefix(line, "data: "):
+				message.Data = line[len("data: "):]
+			}
+		}
+	}()
+	return messages
+}
+
+// nextStreamEvent returns the next event of the stream, skipping heartbeats
+func nextStreamEvent(t *testing.T, messages <-chan streamMessage) streamMessage {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case message, ok := <-messages:
+			if !ok {
+				t.Fatal("event stream closed")
+			}
+			if message.Event != "" {
+				return message
+			}
+		case <-timeout:
+			t.Fatal("no event on the stream")
+		}
+	}
+}
+
+// awaitStreamEnd waits for the server to close the stream
+func awaitStreamEnd(t *testing.T, messages <-chan streamMessage) {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-messages:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("event stream still open")
+		}
+	}
+}
+
+func streamData(t *testing.T, message streamMessage) map[string]interface{} {
+	data := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(message.Data), &data))
+	return data
+}
+
+func TestEventStream(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "teststreamuser", "password123", "Test Stream User")
+	otherToken := registerAndLogin(t, "teststreamother", "password123", "Test Stream Other")
+	createTestContact(t, token, "Old", "Doe", "old@example.com", "08123456789")
+	dispatchPendingEvents()
+
+	messages := openEventStream(t, token, "")
+
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	createTestAddress(t, token, contactID, "Jl. Test", "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+	createTestContact(t, otherToken, "Jane", "Roe", "jane@example.com", "08123456789")
+	status, _ := adminRequest(t, "DELETE", "/api/contacts/"+contactID, token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	dispatchPendingEvents()
+
+	// Only the changes made since the stream opened, and none of the other user
+	message := nextStreamEvent(t, messages)
+	assert.Equal(t, "contact.created", message.Event)
+	assert.Equal(t, "John", streamData(t, message)["first_name"])
+
+	message = nextStreamEvent(t, messages)
+	assert.Equal(t, "address.created", message.Event)
+	assert.Equal(t, float64(parseTestID(contactID)), streamData(t, message)["contact_id"])
+	assert.Equal(t, "Jakarta", streamData(t, message)["address"].(map[string]interface{})["city"])
+
+	message = nextStreamEvent(t, messages)
+	assert.Equal(t, "contact.deleted", message.Event)
+	assert.Equal(t, float64(parseTestID(contactID)), streamData(t, message)["id"])
+
+	cleanupTestData()
+}
+
+func TestEventStreamResume(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "teststreamuser", "password123", "Test Stream User")
+
+	messages := openEventStream(t, token, "")
+	createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	dispatchPendingEvents()
+	lastEventID := nextStreamEvent(t, messages).ID
+
+	// Changes made while the client was away
+	contactID := createTestContact(t, token, "Jane", "Doe", "jane@example.com", "08123456789")
+	status, _ := adminRequest(t, "PATCH", "/api/contacts/"+contactID, token, map[string]interface{}{"phone": "08987654321"})
+	assert.Equal(t, fiber.StatusOK, status)
+	dispatchPendingEvents()
+
+	messages = openEventStream(t, token, lastEventID)
+	message := nextStreamEvent(t, messages)
+	assert.Equal(t, "contact.created", message.Event)
+	assert.Equal(t, "Jane", streamData(t, message)["first_name"])
+
+	message = nextStreamEvent(t, messages)
+	assert.Equal(t, "contact.updated", message.Event)
+	assert.Equal(t, "08987654321", streamData(t, message)["phone"])
+
+	cleanupTestData()
+}
+
+func TestEventStreamHeartbeat(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "teststreamuser", "password123", "Test Stream User")
+
+	messages := openEventStream(t, token, "")
+	timeout := time.After(5 * time.Second)
+	for heartbeat := false; !heartbeat; {
+		select {
+		case message := <-messages:
+			heartbeat = message.Comment == "heartbeat"
+		case <-timeout:
+			t.Fatal("no heartbeat on the stream")
+		}
+	}
+
+	cleanupTestData()
+}
+
+func TestEventStreamRejected(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "teststreamuser", "password123", "Test Stream User")
+
+	status, _ := adminRequest(t, "GET", "/api/events/stream", "", nil)
+	assert.Equal(t, fiber.StatusUnauthorized, status)
+
+	req, _ := http.NewRequest("GET", "/api/events/stream", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Last-Event-ID", "latest")
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	cleanupTestData()
+}
+
+func TestEventStreamEndsOnLogout(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "teststreamuser", "password123", "Test Stream User")
+
+	messages := openEventStream(t, token, "")
+	status, _ := adminRequest(t, "DELETE", "/api/users/logout", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	awaitStreamEnd(t, messages)
+
+	cleanupTestData()
+}
+
+func TestEventStreamEndsWhenDisabled(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "teststreamuser", "password123", "Test Stream User")
+
+	messages := openEventStream(t, token, "")
+	testDB.Model(&domain.User{}).Where("username = ?", "teststreamuser").Update("disabled_at", time.Now())
+
+	awaitStreamEnd(t, messages)
+
+	cleanupTestData()
+}
+
+func TestEventStreamPrune(t *testing.T) {
+	cleanupTestData()
+
+	now := time.Now()
+	// Organization 0 does not exist, cleanupTestData deletes what is left
+	for eventID, occurredAt := range map[string]time.Time{
+		"test-stream-prune-old":    now.Add(-25 * time.Hour),
+		"test-stream-prune-recent": now.Add(-23 * time.Hour),
+	} {
+		testDB.Create(&domain.StreamEvent{
+			EventID:    eventID,
+			Name:       "contact.created",
+			Payload:    []byte("{}"),
+			OccurredAt: occurredAt,
+		})
+	}
+
+	eventStreamService := service.NewEventStreamService(repository.NewStreamEventRepository(), repository.NewUserRepository(), testDB, service.EventStreamOptions{Retention: 24 * time.Hour})
+	eventStreamService.Prune(helper.NewContext(context.Background()), now)
+
+	var eventIDs []string
+	testDB.Model(&domain.StreamEvent{}).Where("event_id LIKE ?", "test-stream-prune-%").Pluck("event_id", &eventIDs)
+	assert.Equal(t, []string{"test-stream-prune-recent"}, eventIDs)
+
+	cleanupTestData()
+}
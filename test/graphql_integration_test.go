@@ -0,0 +1,229 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// graphQLResponse is a GraphQL response with its data decoded generically
+type graphQLResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Path       []interface{}          `json:"path"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func graphQLRequest(t *testing.T, token, query string, variables map[string]interface{}) graphQLResponse {
+	body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	req := httptest.NewRequest("POST", "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	response := graphQLResponse{}
+	responseBody, _ := io.ReadAll(resp.Body)
+	assert.NoError(t, json.Unmarshal(responseBody, &response), string(responseBody))
+	return response
+}
+
+// countQueries counts the queries of the table run until the returned
+// function is called, which returns the count
+func countQueries(t *testing.T, table string) func() int64 {
+	var count int64
+	name := "test:count_" + table
+	err := testDB.Callback().Query().After("gorm:query").Register(name, func(db *gorm.DB) {
+		if db.Statement.Table == table {
+			atomic.AddInt64(&count, 1)
+		}
+	})
+	assert.NoError(t, err)
+
+	return func() int64 {
+		testDB.Callback().Query().Remove(name)
+		return atomic.LoadInt64(&count)
+	}
+}
+
+func TestGraphQLContactsWithAddresses(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testgraphqluser", "password123", "Test GraphQL User")
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		contactID := createTestContact(t, token, name, "Doe", "graphql@example.com", "08123456789")
+		createTestAddress(t, token, contactID, "Jl. "+name, "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+		createTestAddress(t, token, contactID, "Jl. "+name+" 2", "Bandung", "Jawa Barat", "Indonesia", "40111")
+	}
+	createTestContact(t, token, "Dave", "Doe", "graphql@example.com", "08123456789")
+
+	stopCounting := countQueries(t, "addresses")
+	response := graphQLRequest(t, token, `query {
+  me { username name }
+  contacts(name: "doe", size: 10) {
+    contacts { firstName addresses { street city } }
+    paging { page totalItem }
+  }
+}`, nil)
+	addressQueries := stopCounting()
+
+	assert.Empty(t, response.Errors)
+	assert.Equal(t, "testgraphqluser", response.Data["me"].(map[string]interface{})["username"])
+
+	page := response.Data["contacts"].(map[string]interface{})
+	assert.Equal(t, float64(4), page["paging"].(map[string]interface{})["totalItem"])
+	contacts := page["contacts"].([]interface{})
+	assert.Len(t, contacts, 4)
+
+	addressCounts := map[string]int{}
+	for _, item := range contacts {
+		contact := item.(map[string]interface{})
+		addressCounts[contact["firstName"].(string)] = len(contact["addresses"].([]interface{}))
+	}
+	assert.Equal(t, map[string]int{"Alice": 2, "Bob": 2, "Carol": 2, "Dave": 0}, addressCounts)
+
+	// The addresses of all the contacts come from a single query
+	assert.Equal(t, int64(1), addressQueries)
+
+	cleanupTestData()
+}
+
+func TestGraphQLContact(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testgraphqluser", "password123", "Test GraphQL User")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	createTestAddress(t, token, contactID, "Jl. Test", "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+
+	response := graphQLRequest(t, token, `query($id: ID!) {
+  contact(id: $id) { id firstName version addresses { street postalCode } }
+}`, map[string]interface{}{"id": contactID})
+
+	assert.Empty(t, response.Errors)
+	contact := response.Data["contact"].(map[string]interface{})
+	assert.Equal(t, contactID, contact["id"])
+	assert.Equal(t, "John", contact["firstName"])
+	assert.Equal(t, float64(1), contact["version"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"street": "Jl. Test", "postalCode": "12345"}}, contact["addresses"])
+
+	// Errors carry the code of the REST error
+	response = graphQLRequest(t, token, `{ contact(id: "999999999") { id } }`, nil)
+	assert.Nil(t, response.Data["contact"])
+	if assert.Len(t, response.Errors, 1) {
+		assert.Equal(t, "contact not found", response.Errors[0].Message)
+		assert.Equal(t, float64(fiber.StatusNotFound), response.Errors[0].Extensions["code"])
+		assert.Equal(t, []interface{}{"contact"}, response.Errors[0].Path)
+	}
+
+	response = graphQLRequest(t, token, `{ contacts(size: 1000) { paging { page } } }`, nil)
+	if assert.Len(t, response.Errors, 1) {
+		assert.Equal(t, float64(fiber.StatusBadRequest), response.Errors[0].Extensions["code"])
+	}
+
+	cleanupTestData()
+}
+
+func TestGraphQLMutations(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testgraphqluser", "password123", "Test GraphQL User")
+
+	response := graphQLRequest(t, token, `mutation($input: ContactInput!) {
+  createContact(input: $input) { id firstName lastName version }
+}`, map[string]interface{}{"input": map[string]interface{}{
+		"firstName": "John",
+		"lastName":  "Doe",
+		"email":     "john@example.com",
+		"phone":     "08123456789",
+	}})
+	assert.Empty(t, response.Errors)
+	contactID := response.Data["createContact"].(map[string]interface{})["id"].(string)
+
+	response = graphQLRequest(t, token, `mutation($id: ID!) {
+  createAddress(contactId: $id, input: {street: "Jl. Test", city: "Jakarta", province: "DKI Jakarta", country: "Indonesia", postalCode: "12345"}) { id }
+}`, map[string]interface{}{"id": contactID})
+	assert.Empty(t, response.Errors)
+	addressID := response.Data["createAddress"].(map[string]interface{})["id"].(string)
+
+	// Only the given fields change
+	response = graphQLRequest(t, token, `mutation($id: ID!) {
+  updateContact(id: $id, input: {lastName: "Roe"}, version: 1) { firstName lastName version addresses { id } }
+}`, map[string]interface{}{"id": contactID})
+	assert.Empty(t, response.Errors)
+	contact := response.Data["updateContact"].(map[string]interface{})
+	assert.Equal(t, "John", contact["firstName"])
+	assert.Equal(t, "Roe", contact["lastName"])
+	assert.Equal(t, float64(2), contact["version"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": addressID}}, contact["addresses"])
+
+	status, restResponse := adminRequest(t, "GET", "/api/contacts/"+contactID, token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "Roe", restResponse.Data.(map[string]interface{})["last_name"])
+
+	// A stale version is not changed
+	response = graphQLRequest(t, token, `mutation($id: ID!) {
+  updateContact(id: $id, input: {lastName: "Poe"}, version: 1) { lastName }
+}`, map[string]interface{}{"id": contactID})
+	if assert.Len(t, response.Errors, 1) {
+		assert.Equal(t, float64(fiber.StatusPreconditionFailed), response.Errors[0].Extensions["code"])
+	}
+
+	response = graphQLRequest(t, token, `mutation($contactId: ID!, $id: ID!) {
+  updateAddress(contactId: $contactId, id: $id, input: {city: "Bandung"}) { city street }
+}`, map[string]interface{}{"contactId": contactID, "id": addressID})
+	assert.Empty(t, response.Errors)
+	assert.Equal(t, map[string]interface{}{"city": "Bandung", "street": "Jl. Test"}, response.Data["updateAddress"])
+
+	// Validation is the same as for the REST API
+	response = graphQLRequest(t, token, `mutation($id: ID!) {
+  updateContact(id: $id, input: {email: "not an email"}) { email }
+}`, map[string]interface{}{"id": contactID})
+	if assert.Len(t, response.Errors, 1) {
+		assert.Equal(t, float64(fiber.StatusBadRequest), response.Errors[0].Extensions["code"])
+	}
+
+	response = graphQLRequest(t, token, `mutation($contactId: ID!, $id: ID!) {
+  deleteAddress(contactId: $contactId, id: $id)
+  deleteContact(id: $contactId)
+}`, map[string]interface{}{"contactId": contactID, "id": addressID})
+	assert.Empty(t, response.Errors)
+	assert.Equal(t, true, response.Data["deleteContact"])
+
+	status, _ = adminRequest(t, "GET", "/api/contacts/"+contactID, token, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	cleanupTestData()
+}
+
+func TestGraphQLAuthorization(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testgraphqlowner", "password123", "Test GraphQL Owner")
+	otherToken := registerAndLogin(t, "testgraphqlother", "password123", "Test GraphQL Other")
+	contactID := createTestContact(t, ownerToken, "John", "Doe", "john@example.com", "08123456789")
+
+	response := graphQLRequest(t, otherToken, `query($id: ID!) { contact(id: $id) { id } }`, map[string]interface{}{"id": contactID})
+	if assert.Len(t, response.Errors, 1) {
+		assert.Equal(t, float64(fiber.StatusNotFound), response.Errors[0].Extensions["code"])
+	}
+
+	response = graphQLRequest(t, otherToken, `{ contacts { contacts { id } } }`, nil)
+	assert.Empty(t, response.Errors)
+	assert.Empty(t, response.Data["contacts"].(map[string]interface{})["contacts"])
+
+	// Without a token
+	req := httptest.NewRequest("POST", "/graphql", bytes.NewReader([]byte(`{"query":"{ me { username } }"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+
+	cleanupTestData()
+}
@@ -0,0 +1,206 @@
+package test
+
+import (
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	pb "github.com/sorfian/go-contact-management-api/proto/contactmanagement/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var (
+	grpcListener     *bufconn.Listener
+	grpcListenerOnce sync.Once
+)
+
+// dialGRPC connects to the test gRPC server, which is served in memory
+func dialGRPC(t *testing.T) *grpc.ClientConn {
+	grpcListenerOnce.Do(func() {
+		grpcListener = bufconn.Listen(1024 * 1024)
+		go testGRPCServer.Serve(grpcListener)
+	})
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return grpcListener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// grpcContext returns a context with the token of the user as call metadata
+func grpcContext(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestGRPCContacts(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testgrpcuser", "password123", "Test gRPC User")
+	contacts := pb.NewContactServiceClient(dialGRPC(t))
+	ctx := grpcContext(token)
+
+	created, err := contacts.CreateContact(ctx, &pb.CreateContactRequest{
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "john@example.com",
+		Phone:     "08123456789",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "John", created.GetFirstName())
+	assert.Equal(t, int64(1), created.GetVersion())
+	assert.NotZero(t, created.GetAddressBookId())
+
+	// The contact is the same one the REST API serves
+	code, response := adminRequest(t, "GET", "/api/contacts/"+strconv.FormatInt(created.GetId(), 10), token, nil)
+	assert.Equal(t, 200, code)
+	assert.Equal(t, "Doe", response.Data.(map[string]interface{})["last_name"])
+
+	got, err := contacts.GetContact(ctx, &pb.GetContactRequest{Id: created.GetId()})
+	assert.NoError(t, err)
+	assert.Equal(t, created.GetEmail(), got.GetEmail())
+
+	// Only the fields set change
+	lastName := "Roe"
+	updated, err := contacts.UpdateContact(ctx, &pb.UpdateContactRequest{Id: created.GetId(), LastName: &lastName, ExpectedVersion: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "John", updated.GetFirstName())
+	assert.Equal(t, "Roe", updated.GetLastName())
+	assert.Equal(t, int64(2), updated.GetVersion())
+
+	_, err = contacts.UpdateContact(ctx, &pb.UpdateContactRequest{Id: created.GetId(), LastName: &lastName, ExpectedVersion: 1})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	invalidEmail := "not an email"
+	_, err = contacts.UpdateContact(ctx, &pb.UpdateContactRequest{Id: created.GetId(), Email: &invalidEmail})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	searched, err := contacts.SearchContacts(ctx, &pb.SearchContactsRequest{Name: "roe"})
+	assert.NoError(t, err)
+	assert.Len(t, searched.GetContacts(), 1)
+	assert.Equal(t, int32(1), searched.GetPaging().GetPage())
+	assert.Equal(t, int32(10), searched.GetPaging().GetSize())
+	assert.Equal(t, int32(1), searched.GetPaging().GetTotalItem())
+
+	_, err = contacts.SearchContacts(ctx, &pb.SearchContactsRequest{Size: 1000})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = contacts.DeleteContact(ctx, &pb.DeleteContactRequest{Id: created.GetId()})
+	assert.NoError(t, err)
+
+	_, err = contacts.GetContact(ctx, &pb.GetContactRequest{Id: created.GetId()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	cleanupTestData()
+}
+
+func TestGRPCListContactsStreams(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testgrpcuser", "password123", "Test gRPC User")
+	contacts := pb.NewContactServiceClient(dialGRPC(t))
+
+	// More than one page of the listing
+	for i := 0; i < 105; i++ {
+		createTestContact(t, token, "Bulk", strconv.Itoa(i), "bulk"+strconv.Itoa(i)+"@example.com", "08123456789")
+	}
+	createTestContact(t, token, "Other", "Person", "other@example.com", "08123456789")
+
+	stream, err := contacts.ListContacts(grpcContext(token), &pb.ListContactsRequest{Email: "bulk"})
+	assert.NoError(t, err)
+
+	ids := map[int64]bool{}
+	for {
+		contact, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			break
+		}
+		ids[contact.GetId()] = true
+	}
+	assert.Len(t, ids, 105)
+
+	cleanupTestData()
+}
+
+func TestGRPCAddresses(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testgrpcuser", "password123", "Test gRPC User")
+	contactID := parseTestID(createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789"))
+	addresses := pb.NewAddressServiceClient(dialGRPC(t))
+	ctx := grpcContext(token)
+
+	created, err := addresses.CreateAddress(ctx, &pb.CreateAddressRequest{
+		ContactId:  contactID,
+		Street:     "Jl. Test",
+		City:       "Jakarta",
+		Province:   "DKI Jakarta",
+		Country:    "Indonesia",
+		PostalCode: "12345",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, contactID, created.GetContactId())
+
+	city := "Bandung"
+	updated, err := addresses.UpdateAddress(ctx, &pb.UpdateAddressRequest{ContactId: contactID, Id: created.GetId(), City: &city})
+	assert.NoError(t, err)
+	assert.Equal(t, "Bandung", updated.GetCity())
+	assert.Equal(t, "Jl. Test", updated.GetStreet())
+
+	listed, err := addresses.ListAddresses(ctx, &pb.ListAddressesRequest{ContactId: contactID})
+	assert.NoError(t, err)
+	assert.Len(t, listed.GetAddresses(), 1)
+
+	_, err = addresses.DeleteAddress(ctx, &pb.DeleteAddressRequest{ContactId: contactID, Id: created.GetId(), ExpectedVersion: updated.GetVersion()})
+	assert.NoError(t, err)
+
+	_, err = addresses.GetAddress(ctx, &pb.GetAddressRequest{ContactId: contactID, Id: created.GetId()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	cleanupTestData()
+}
+
+func TestGRPCAuthentication(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testgrpcowner", "password123", "Test gRPC Owner")
+	otherToken := registerAndLogin(t, "testgrpcother", "password123", "Test gRPC Other")
+	contactID := parseTestID(createTestContact(t, ownerToken, "John", "Doe", "john@example.com", "08123456789"))
+	contacts := pb.NewContactServiceClient(dialGRPC(t))
+
+	_, err := contacts.GetContact(context.Background(), &pb.GetContactRequest{Id: contactID})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = contacts.GetContact(grpcContext("invalid-token"), &pb.GetContactRequest{Id: contactID})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	stream, err := contacts.ListContacts(context.Background(), &pb.ListContactsRequest{})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Contacts of others are not found
+	_, err = contacts.GetContact(grpcContext(otherToken), &pb.GetContactRequest{Id: contactID})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Organizations the user is not a member of are not found either
+	ctx := metadata.AppendToOutgoingContext(grpcContext(otherToken), "x-organization-id", defaultOrganizationID(t, ownerToken))
+	_, err = contacts.GetContact(ctx, &pb.GetContactRequest{Id: contactID})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	cleanupTestData()
+}
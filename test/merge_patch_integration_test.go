@@ -0,0 +1,190 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePatchContactKeepsAbsentMembers(t *testing.T) {
+	cleanupTestData()
+
+	token := registerAndLogin(t, "testpatch1", "password123", "Test Patch User 1")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+
+	req := httptest.NewRequest("PATCH", "/api/contacts/"+contactID, bytes.NewReader([]byte(`{"phone":"08999999999"}`)))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var response web.Response
+	err = json.Unmarshal(body, &response)
+	assert.NoError(t, err)
+
+	contactResponse, ok := response.Data.(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "John", contactResponse["first_name"])
+	assert.Equal(t, "john@example.com", contactResponse["email"])
+	assert.Equal(t, "08999999999", contactResponse["phone"])
+
+	cleanupTestData()
+}
+
+func TestMergePatchContactNullClearsOptionalMember(t *testing.T) {
+	cleanupTestData()
+
+	token := registerAndLogin(t, "testpatch2", "password123", "Test Patch User 2")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+
+	req := httptest.NewRequest("PATCH", "/api/contacts/"+contactID, bytes.NewReader([]byte(`{"phone":null}`)))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var response web.Response
+	err = json.Unmarshal(body, &response)
+	assert.NoError(t, err)
+
+	contactResponse, ok := response.Data.(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "", contactResponse["phone"])
+	assert.Equal(t, "john@example.com", contactResponse["email"])
+
+	// The column is NULL, and stays cleared when read again
+	var phone *string
+	err = testDB.Raw("SELECT phone FROM contacts WHERE id = ?", contactID).Scan(&phone).Error
+	assert.NoError(t, err)
+	assert.Nil(t, phone)
+
+	req = httptest.NewRequest("GET", "/api/contacts/"+contactID, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	body, _ = io.ReadAll(resp.Body)
+	response = web.Response{}
+	json.Unmarshal(body, &response)
+	assert.Equal(t, "", response.Data.(map[string]interface{})["phone"])
+
+	// Creating a contact still requires a phone
+	req = httptest.NewRequest("POST", "/api/contacts", bytes.NewReader([]byte(`{"first_name":"Jane","last_name":"Doe","email":"jane@example.com"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err = testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+
+	cleanupTestData()
+}
+
+func TestMergePatchAddressNullClearsOptionalMember(t *testing.T) {
+	cleanupTestData()
+
+	token := registerAndLogin(t, "testpatch5", "password123", "Test Patch User 5")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	addressID := createTestAddress(t, token, contactID, "Jl. Sudirman", "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+
+	req := httptest.NewRequest("PATCH", "/api/contacts/"+contactID+"/addresses/"+addressID, bytes.NewReader([]byte(`{"street":null,"postal_code":null}`)))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var response web.Response
+	err = json.Unmarshal(body, &response)
+	assert.NoError(t, err)
+
+	addressResponse, ok := response.Data.(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "", addressResponse["street"])
+	assert.Equal(t, "", addressResponse["postal_code"])
+	assert.Equal(t, "DKI Jakarta", addressResponse["province"])
+
+	// Creating an address still requires every member
+	req = httptest.NewRequest("POST", "/api/contacts/"+contactID+"/addresses", bytes.NewReader([]byte(`{"city":"Bandung","country":"Indonesia"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err = testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+
+	cleanupTestData()
+}
+
+func TestMergePatchContactNullClearsRequiredMember(t *testing.T) {
+	cleanupTestData()
+
+	token := registerAndLogin(t, "testpatch2", "password123", "Test Patch User 2")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+
+	// Clearing a member the merged contact requires fails validation
+	req := httptest.NewRequest("PATCH", "/api/contacts/"+contactID, bytes.NewReader([]byte(`{"last_name":null}`)))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+
+	cleanupTestData()
+}
+
+func TestMergePatchUnsupportedMediaType(t *testing.T) {
+	cleanupTestData()
+
+	token := registerAndLogin(t, "testpatch3", "password123", "Test Patch User 3")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+
+	req := httptest.NewRequest("PATCH", "/api/contacts/"+contactID, bytes.NewReader([]byte(`first_name=Jane`)))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 415, resp.StatusCode)
+
+	cleanupTestData()
+}
+
+func TestMergePatchCurrentUserNameOnly(t *testing.T) {
+	cleanupTestData()
+
+	token := registerAndLogin(t, "testpatch4", "password123", "Test Patch User 4")
+
+	req := httptest.NewRequest("PATCH", "/api/users/current", bytes.NewReader([]byte(`{"name":"Patched Name"}`)))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var response web.Response
+	err = json.Unmarshal(body, &response)
+	assert.NoError(t, err)
+
+	userData, ok := response.Data.(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "Patched Name", userData["name"])
+
+	cleanupTestData()
+}
@@ -0,0 +1,89 @@
+package test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPIRejectsInvalidContactID(t *testing.T) {
+	cleanupTestData()
+
+	token := registerAndLogin(t, "testopenapi1", "password123", "Test OpenAPI User 1")
+
+	req := httptest.NewRequest("GET", "/api/contacts/abc", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+
+	cleanupTestData()
+}
+
+func TestOpenAPIEmptyAddressListIsArray(t *testing.T) {
+	cleanupTestData()
+
+	token := registerAndLogin(t, "testopenapi2", "password123", "Test OpenAPI User 2")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+
+	req := httptest.NewRequest("GET", "/api/contacts/"+contactID+"/addresses", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var response web.Response
+	err = json.Unmarshal(body, &response)
+	assert.NoError(t, err)
+
+	addresses, ok := response.Data.([]interface{})
+	assert.True(t, ok)
+	assert.Empty(t, addresses)
+
+	cleanupTestData()
+}
+
+func TestOpenAPIAuthenticatesBeforeValidating(t *testing.T) {
+	cleanupTestData()
+
+	token := registerAndLogin(t, "testopenapi3", "password123", "Test OpenAPI User 3")
+
+	// An invalid body is not described to a caller without a valid token
+	for _, authorization := range []string{"", "Bearer invalid-token"} {
+		req := httptest.NewRequest("POST", "/api/contacts", strings.NewReader(`{"first_name":42}`))
+		req.Header.Set("Content-Type", "application/json")
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+
+		resp, err := testApp.Test(req, -1)
+		assert.NoError(t, err)
+		assert.Equal(t, 401, resp.StatusCode, authorization)
+	}
+
+	req := httptest.NewRequest("POST", "/api/contacts", strings.NewReader(`{"first_name":42}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+
+	// Public routes are validated as before
+	req = httptest.NewRequest("POST", "/api/users/login", strings.NewReader(`{"username":42}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err = testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+
+	cleanupTestData()
+}
@@ -0,0 +1,262 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/addressbook"
+	"github.com/sorfian/go-contact-management-api/model/web/organization"
+	"github.com/stretchr/testify/assert"
+)
+
+// organizationRequest is adminRequest with the X-Organization-ID header set
+func organizationRequest(t *testing.T, method, url, token, organizationID string, body interface{}) (int, web.Response) {
+	var reader io.Reader
+	if body != nil {
+		bodyJSON, _ := json.Marshal(body)
+		reader = bytes.NewReader(bodyJSON)
+	}
+	req := httptest.NewRequest(method, url, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("X-Organization-ID", organizationID)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+
+	response := web.Response{}
+	responseBody, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(responseBody, &response)
+	return resp.StatusCode, response
+}
+
+// defaultOrganizationID returns the ID of the first organization listed for the user
+func defaultOrganizationID(t *testing.T, token string) string {
+	status, response := adminRequest(t, "GET", "/api/organizations", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	organizations := response.Data.([]interface{})
+	assert.NotEmpty(t, organizations)
+	return strconv.FormatInt(int64(organizations[0].(map[string]interface{})["id"].(float64)), 10)
+}
+
+// joinOrganization adds username as a member of the owner's default organization,
+// address books are only shared within an organization
+func joinOrganization(t *testing.T, ownerToken, username string) {
+	url := fmt.Sprintf("/api/organizations/%s/members", defaultOrganizationID(t, ownerToken))
+	status, _ := adminRequest(t, "POST", url, ownerToken, organization.MemberCreateRequest{
+		Username: username,
+		Role:     "member",
+	})
+	assert.Equal(t, fiber.StatusCreated, status)
+}
+
+func TestOrganizationPersonalDefault(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testorgowner", "password123", "Test Org Owner")
+
+	status, response := adminRequest(t, "GET", "/api/organizations", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	organizations := response.Data.([]interface{})
+	assert.Len(t, organizations, 1)
+	personal := organizations[0].(map[string]interface{})
+	assert.Equal(t, "Personal", personal["name"])
+	assert.Equal(t, "owner", personal["role"])
+
+	status, response = adminRequest(t, "GET", fmt.Sprintf("/api/organizations/%d", int64(personal["id"].(float64))), token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "Personal", response.Data.(map[string]interface{})["name"])
+
+	cleanupTestData()
+}
+
+func TestOrganizationIsolation(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testorgowner", "password123", "Test Org Owner")
+	personalID := defaultOrganizationID(t, token)
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+
+	status, response := adminRequest(t, "POST", "/api/organizations", token, organization.OrganizationCreateRequest{Name: "Acme"})
+	assert.Equal(t, fiber.StatusCreated, status)
+	acmeID := strconv.FormatInt(int64(response.Data.(map[string]interface{})["id"].(float64)), 10)
+
+	status, response = organizationRequest(t, "GET", "/api/contacts", token, acmeID, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, response.Data.(map[string]interface{})["contacts"], 0)
+
+	status, _ = organizationRequest(t, "GET", "/api/contacts/"+contactID, token, acmeID, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	status, _ = organizationRequest(t, "GET", "/api/contacts/"+contactID, token, personalID, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	// The default address book of each organization is its own
+	status, response = organizationRequest(t, "GET", "/api/address-books", token, acmeID, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, response.Data, 1)
+	acmeBookID := response.Data.([]interface{})[0].(map[string]interface{})["id"]
+	assert.NotEqual(t, defaultAddressBookID(t, token), acmeBookID)
+
+	cleanupTestData()
+}
+
+func TestOrganizationHeaderRequiresMembership(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testorgowner", "password123", "Test Org Owner")
+	otherToken := registerAndLogin(t, "testorgother", "password123", "Test Org Other")
+	organizationID := defaultOrganizationID(t, ownerToken)
+
+	status, response := organizationRequest(t, "GET", "/api/contacts", otherToken, organizationID, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+	assert.Equal(t, "organization not found", response.Data)
+
+	status, _ = adminRequest(t, "GET", "/api/organizations/"+organizationID, otherToken, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	status, _ = organizationRequest(t, "GET", "/api/contacts", otherToken, "abc", nil)
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	cleanupTestData()
+}
+
+func TestOrganizationMembers(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testorgowner", "password123", "Test Org Owner")
+	memberToken := registerAndLogin(t, "testorgmember", "password123", "Test Org Member")
+	registerAndLogin(t, "testorgother", "password123", "Test Org Other")
+	organizationID := defaultOrganizationID(t, ownerToken)
+	owner := findTestUser("testorgowner")
+	member := findTestUser("testorgmember")
+	other := findTestUser("testorgother")
+	url := fmt.Sprintf("/api/organizations/%s/members", organizationID)
+
+	status, response := adminRequest(t, "POST", url, ownerToken, organization.MemberCreateRequest{Username: "testorgmember", Role: "member"})
+	assert.Equal(t, fiber.StatusCreated, status)
+	assert.Equal(t, "member", response.Data.(map[string]interface{})["role"])
+
+	status, _ = adminRequest(t, "POST", url, ownerToken, organization.MemberCreateRequest{Username: "testorgmember", Role: "member"})
+	assert.Equal(t, fiber.StatusConflict, status)
+
+	status, _ = adminRequest(t, "POST", url, ownerToken, organization.MemberCreateRequest{Username: "testorgmissing", Role: "member"})
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	status, response = adminRequest(t, "GET", url, memberToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, response.Data, 2)
+
+	// Members cannot manage the organization
+	status, response = adminRequest(t, "POST", url, memberToken, organization.MemberCreateRequest{Username: "testorgother", Role: "member"})
+	assert.Equal(t, fiber.StatusForbidden, status)
+	assert.Equal(t, "organization role admin required", response.Data)
+
+	status, _ = adminRequest(t, "PATCH", "/api/organizations/"+organizationID, memberToken, map[string]interface{}{"name": "Renamed"})
+	assert.Equal(t, fiber.StatusForbidden, status)
+
+	status, response = adminRequest(t, "PUT", fmt.Sprintf("%s/%d", url, member.ID), ownerToken, organization.MemberUpdateRequest{Role: "admin"})
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "admin", response.Data.(map[string]interface{})["role"])
+
+	// Admins manage members, only the owner grants or revokes admin
+	status, _ = adminRequest(t, "POST", url, memberToken, organization.MemberCreateRequest{Username: "testorgother", Role: "admin"})
+	assert.Equal(t, fiber.StatusForbidden, status)
+
+	status, _ = adminRequest(t, "POST", url, memberToken, organization.MemberCreateRequest{Username: "testorgother", Role: "member"})
+	assert.Equal(t, fiber.StatusCreated, status)
+
+	status, _ = adminRequest(t, "PUT", fmt.Sprintf("%s/%d", url, owner.ID), memberToken, organization.MemberUpdateRequest{Role: "member"})
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	status, _ = adminRequest(t, "DELETE", fmt.Sprintf("%s/%d", url, owner.ID), ownerToken, nil)
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	status, _ = adminRequest(t, "DELETE", fmt.Sprintf("%s/%d", url, other.ID), memberToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	// Members can leave on their own
+	status, _ = adminRequest(t, "DELETE", fmt.Sprintf("%s/%d", url, member.ID), memberToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	status, _ = adminRequest(t, "GET", url, memberToken, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	cleanupTestData()
+}
+
+func TestOrganizationRemoveMemberOwningContacts(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testorgowner", "password123", "Test Org Owner")
+	memberToken := registerAndLogin(t, "testorgmember", "password123", "Test Org Member")
+	joinOrganization(t, ownerToken, "testorgmember")
+	member := findTestUser("testorgmember")
+	url := fmt.Sprintf("/api/organizations/%s/members/%d", defaultOrganizationID(t, ownerToken), member.ID)
+	contactID := createTestContact(t, memberToken, "John", "Doe", "john@example.com", "08123456789")
+
+	status, response := adminRequest(t, "DELETE", url, ownerToken, nil)
+	assert.Equal(t, fiber.StatusConflict, status)
+	assert.Equal(t, "member still owns address books with contacts", response.Data)
+
+	status, _ = adminRequest(t, "DELETE", "/api/contacts/"+contactID, memberToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	status, _ = adminRequest(t, "DELETE", url, ownerToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	cleanupTestData()
+}
+
+func TestOrganizationSettings(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testorgowner", "password123", "Test Org Owner")
+
+	status, response := adminRequest(t, "POST", "/api/organizations", token, organization.OrganizationCreateRequest{Name: "Acme"})
+	assert.Equal(t, fiber.StatusCreated, status)
+	organizationID := strconv.FormatInt(int64(response.Data.(map[string]interface{})["id"].(float64)), 10)
+
+	status, response = adminRequest(t, "PATCH", "/api/organizations/"+organizationID, token, map[string]interface{}{
+		"settings": map[string]interface{}{"max_contacts": 1, "default_address_book_name": "Clients"},
+	})
+	assert.Equal(t, fiber.StatusOK, status)
+	settings := response.Data.(map[string]interface{})["settings"].(map[string]interface{})
+	assert.Equal(t, float64(1), settings["max_contacts"])
+	assert.Equal(t, "Acme", response.Data.(map[string]interface{})["name"])
+
+	status, response = organizationRequest(t, "GET", "/api/address-books", token, organizationID, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "Clients", response.Data.([]interface{})[0].(map[string]interface{})["name"])
+
+	contact := map[string]interface{}{"first_name": "John", "last_name": "Doe", "email": "john@example.com", "phone": "08123456789"}
+	status, _ = organizationRequest(t, "POST", "/api/contacts", token, organizationID, contact)
+	assert.Equal(t, fiber.StatusCreated, status)
+
+	status, response = organizationRequest(t, "POST", "/api/contacts", token, organizationID, contact)
+	assert.Equal(t, fiber.StatusConflict, status)
+	assert.Equal(t, "organization contact limit reached", response.Data)
+
+	// The limit of one organization does not apply to another
+	status, _ = adminRequest(t, "POST", "/api/contacts", token, contact)
+	assert.Equal(t, fiber.StatusCreated, status)
+
+	cleanupTestData()
+}
+
+func TestOrganizationInvitationRequiresMembership(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testorgowner", "password123", "Test Org Owner")
+	registerAndLogin(t, "testorgother", "password123", "Test Org Other")
+	url := fmt.Sprintf("/api/address-books/%d/invitations", int64(defaultAddressBookID(t, ownerToken)))
+
+	status, response := adminRequest(t, "POST", url, ownerToken, addressbook.InvitationCreateRequest{Username: "testorgother", Permission: "viewer"})
+	assert.Equal(t, fiber.StatusNotFound, status)
+	assert.Equal(t, "user not found", response.Data)
+
+	cleanupTestData()
+}
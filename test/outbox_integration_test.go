@@ -0,0 +1,182 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/event"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+	"github.com/sorfian/go-contact-management-api/model/web/user"
+	"github.com/stretchr/testify/assert"
+)
+
+// dispatchPendingEvents runs the outbox dispatcher once
+func dispatchPendingEvents() {
+	testOutboxService.DispatchPending(helper.NewContext(context.Background()), 100)
+}
+
+// outboxEvents returns the events recorded by the user, oldest first
+func outboxEvents(actor domain.User, name string) []domain.OutboxEvent {
+	var outboxEvents []domain.OutboxEvent
+	testDB.Where("actor_id = ? AND name = ?", actor.ID, name).Order("id").Find(&outboxEvents)
+	return outboxEvents
+}
+
+func TestEventRecordedWithChange(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testoutboxuser", "password123", "Test Outbox User")
+	actor := findTestUser("testoutboxuser")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+
+	recorded := outboxEvents(actor, event.NameContactCreated)
+	assert.Len(t, recorded, 1)
+	assert.Equal(t, parseTestID(defaultOrganizationID(t, token)), *recorded[0].OrganizationID)
+	assert.Nil(t, recorded[0].DispatchedAt)
+
+	var payload map[string]interface{}
+	assert.NoError(t, json.Unmarshal(recorded[0].Payload, &payload))
+	assert.Equal(t, float64(parseTestID(contactID)), payload["id"])
+	assert.Equal(t, "John", payload["first_name"])
+
+	dispatchPendingEvents()
+	recorded = outboxEvents(actor, event.NameContactCreated)
+	assert.NotNil(t, recorded[0].DispatchedAt)
+	assert.Nil(t, recorded[0].NextAttemptAt)
+	assert.Equal(t, 1, recorded[0].Attempts)
+
+	cleanupTestData()
+}
+
+func TestEventRolledBackWithChange(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testoutboxuser", "password123", "Test Outbox User")
+	actor := findTestUser("testoutboxuser")
+
+	result := sendBulkRequest(t, token, contact.BulkRequest{
+		Operations: []contact.BulkOperation{
+			{Operation: "create", Data: json.RawMessage(`{"first_name":"Jane","last_name":"Doe","email":"jane@example.com","phone":"08123456789"}`)},
+			{Operation: "delete", ID: 999999999},
+		},
+	})
+	assert.Equal(t, false, result["committed"])
+
+	assert.Len(t, outboxEvents(actor, event.NameContactCreated), 0)
+
+	cleanupTestData()
+}
+
+func TestLoginRecordsEvent(t *testing.T) {
+	cleanupTestData()
+	registerAndLogin(t, "testoutboxuser", "password123", "Test Outbox User")
+	actor := findTestUser("testoutboxuser")
+	assert.Len(t, outboxEvents(actor, event.NameUserLoggedIn), 0)
+
+	status, _ := adminRequest(t, "POST", "/api/users/login", "", user.UserLoginRequest{Username: "testoutboxuser", Password: "password123"})
+	assert.Equal(t, fiber.StatusOK, status)
+
+	recorded := outboxEvents(actor, event.NameUserLoggedIn)
+	if !assert.Len(t, recorded, 1) {
+		return
+	}
+	assert.Nil(t, recorded[0].OrganizationID)
+	var loggedIn event.UserLoggedIn
+	assert.NoError(t, json.Unmarshal(recorded[0].Payload, &loggedIn))
+	assert.Equal(t, event.UserLoggedIn{UserID: actor.ID, Username: "testoutboxuser"}, loggedIn)
+
+	cleanupTestData()
+}
+
+func TestEventBusDeliversTypedEvents(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testoutboxuser", "password123", "Test Outbox User")
+	actor := findTestUser("testoutboxuser")
+
+	var messages []event.Message
+	handler := func(ctx *fiber.Ctx, message event.Message) {
+		if message.ActorID != nil && *message.ActorID == actor.ID {
+			messages = append(messages, message)
+		}
+	}
+	unsubscribeContact := testEventBus.Subscribe(event.NameContactCreated, handler)
+	unsubscribeAddress := testEventBus.Subscribe(event.NameAddressCreated, handler)
+
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	createTestAddress(t, token, contactID, "Jl. Test", "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+	dispatchPendingEvents()
+
+	assert.Len(t, messages, 2)
+	contactCreated, ok := messages[0].Event.(*event.ContactCreated)
+	assert.True(t, ok)
+	assert.Equal(t, "John", contactCreated.FirstName)
+	assert.Equal(t, outboxEvents(actor, event.NameContactCreated)[0].EventID, messages[0].ID)
+	assert.Equal(t, parseTestID(defaultOrganizationID(t, token)), *messages[0].OrganizationID)
+
+	addressCreated, ok := messages[1].Event.(*event.AddressCreated)
+	assert.True(t, ok)
+	assert.Equal(t, parseTestID(contactID), addressCreated.ContactID)
+	assert.Equal(t, "Jakarta", addressCreated.Address.City)
+
+	// An unsubscribed handler hears nothing more
+	unsubscribeContact()
+	unsubscribeAddress()
+	createTestContact(t, token, "Jane", "Doe", "jane@example.com", "08123456789")
+	dispatchPendingEvents()
+	assert.Len(t, messages, 2)
+
+	cleanupTestData()
+}
+
+func TestEventDispatchedAgainAfterSubscriberFails(t *testing.T) {
+	cleanupTestData()
+	receiver := newWebhookReceiver(t)
+	token := registerAndLogin(t, "testoutboxuser", "password123", "Test Outbox User")
+	actor := findTestUser("testoutboxuser")
+	webhookID := createTestWebhook(t, token, receiver.server.URL, "contact.updated")
+
+	calls := 0
+	failing := true
+	unsubscribe := testEventBus.Subscribe(event.NameContactUpdated, func(ctx *fiber.Ctx, message event.Message) {
+		if message.ActorID == nil || *message.ActorID != actor.ID {
+			return
+		}
+		calls++
+		if failing {
+			panic("subscriber failed")
+		}
+	})
+	defer unsubscribe()
+
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	status, _ := adminRequest(t, "PATCH", "/api/contacts/"+contactID, token, map[string]interface{}{"phone": "08987654321"})
+	assert.Equal(t, fiber.StatusOK, status)
+
+	dispatchPendingEvents()
+	recorded := outboxEvents(actor, event.NameContactUpdated)[0]
+	assert.Equal(t, 1, calls)
+	assert.Nil(t, recorded.DispatchedAt)
+	assert.Equal(t, 1, recorded.Attempts)
+	assert.Equal(t, "subscriber failed", recorded.Error)
+	assert.True(t, recorded.NextAttemptAt.After(time.Now()))
+
+	// Not due yet
+	dispatchPendingEvents()
+	assert.Equal(t, 1, calls)
+
+	failing = false
+	testDB.Model(&domain.OutboxEvent{}).Where("id = ?", recorded.ID).Update("next_attempt_at", time.Now().Add(-time.Second))
+	dispatchPendingEvents()
+	recorded = outboxEvents(actor, event.NameContactUpdated)[0]
+	assert.Equal(t, 2, calls)
+	assert.NotNil(t, recorded.DispatchedAt)
+	assert.Equal(t, "", recorded.Error)
+
+	// The webhook subscriber saw the event twice but queued it once
+	assert.Len(t, webhookDeliveries(t, token, webhookID), 1)
+
+	cleanupTestData()
+}
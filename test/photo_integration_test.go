@@ -0,0 +1,255 @@
+package test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/stretchr/testify/assert"
+)
+
+// testImage is an image of the size with a red top row, so thumbnails of
+// it are not blank
+func testImage(width, height int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, 0, color.NRGBA{R: 255, A: 255})
+	}
+	return img
+}
+
+func testPNG(width, height int) []byte {
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, testImage(width, height)); err != nil {
+		panic(err)
+	}
+	return buffer.Bytes()
+}
+
+func testJPEG(width, height int) []byte {
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, testImage(width, height), nil); err != nil {
+		panic(err)
+	}
+	return buffer.Bytes()
+}
+
+// uploadPhoto sends the photo as the raw body of the upload
+func uploadPhoto(t *testing.T, token, contactID, contentType string, data []byte, headers map[string]string) (int, web.Response) {
+	req := httptest.NewRequest("PUT", "/api/contacts/"+contactID+"/photo", bytes.NewReader(data))
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Authorization", "Bearer "+token)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+
+	response := web.Response{}
+	responseBody, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(responseBody, &response)
+	return resp.StatusCode, response
+}
+
+// contactPhoto returns the photo URLs of a contact response by size
+func contactPhoto(data interface{}) map[string]interface{} {
+	photo, _ := data.(map[string]interface{})["photo"].(map[string]interface{})
+	return photo
+}
+
+// fetchThumbnail reads a thumbnail by its URL, with no credentials
+func fetchThumbnail(t *testing.T, url interface{}) (int, image.Image) {
+	resp, err := testApp.Test(httptest.NewRequest("GET", url.(string), nil), -1)
+	assert.NoError(t, err)
+	if resp.StatusCode != fiber.StatusOK {
+		return resp.StatusCode, nil
+	}
+
+	assert.Equal(t, "image/jpeg", resp.Header.Get("Content-Type"))
+	assert.Contains(t, resp.Header.Get("Cache-Control"), "immutable")
+
+	thumbnail, err := jpeg.Decode(resp.Body)
+	assert.NoError(t, err)
+	return resp.StatusCode, thumbnail
+}
+
+func TestUploadContactPhoto(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testphotouser", "password123", "Test Photo User")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+
+	status, response := adminRequest(t, "GET", "/api/contacts/"+contactID, token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Nil(t, contactPhoto(response.Data))
+
+	status, response = uploadPhoto(t, token, contactID, "image/png", testPNG(600, 400), nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, float64(2), response.Data.(map[string]interface{})["version"])
+
+	photo := contactPhoto(response.Data)
+	assert.Len(t, photo, 3)
+	for size, bounds := range map[string]image.Point{
+		"small":  image.Pt(64, 42),
+		"medium": image.Pt(256, 170),
+		// Not scaled up
+		"large": image.Pt(600, 400),
+	} {
+		assert.True(t, strings.HasPrefix(photo[size].(string), "/photos/contacts/"+contactID+"/"), photo[size])
+
+		status, thumbnail := fetchThumbnail(t, photo[size])
+		assert.Equal(t, fiber.StatusOK, status, size)
+		if thumbnail != nil {
+			assert.Equal(t, bounds, thumbnail.Bounds().Size(), size)
+		}
+	}
+
+	status, response = adminRequest(t, "GET", "/api/contacts/"+contactID, token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, photo, contactPhoto(response.Data))
+
+	// A new photo gets new URLs and the old thumbnails are deleted
+	status, response = uploadPhoto(t, token, contactID, "image/jpeg", testJPEG(2000, 1000), map[string]string{"If-Match": `"2"`})
+	assert.Equal(t, fiber.StatusOK, status)
+	newPhoto := contactPhoto(response.Data)
+	assert.NotEqual(t, photo["large"], newPhoto["large"])
+
+	status, thumbnail := fetchThumbnail(t, newPhoto["large"])
+	assert.Equal(t, fiber.StatusOK, status)
+	if thumbnail != nil {
+		assert.Equal(t, image.Pt(1024, 512), thumbnail.Bounds().Size())
+	}
+	status, _ = fetchThumbnail(t, photo["large"])
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	files, err := filepath.Glob(filepath.Join(testPhotoDir, "contacts", contactID, "*", "*.jpg"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 3)
+
+	webp, err := os.ReadFile("../helper/testdata/photo.webp")
+	assert.NoError(t, err)
+	status, response = uploadPhoto(t, token, contactID, "image/webp", webp, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, contactPhoto(response.Data), 3)
+
+	// The upload is a change like any other
+	status, response = adminRequest(t, "GET", "/api/contacts/"+contactID+"/history", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	logs := auditLogs(response.Data)
+	assert.Len(t, logs, 4)
+	// Newest first, the second upload replaced the first photo
+	changes := logs[1].(map[string]interface{})["changes"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"before": photo["large"], "after": newPhoto["large"]}, changes["photo.large"])
+
+	cleanupTestData()
+}
+
+func TestUploadContactPhotoInvalid(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testphotouser", "password123", "Test Photo User")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+
+	// The data is sniffed, whatever the request claims
+	status, response := uploadPhoto(t, token, contactID, "image/png", []byte("GIF89a not a photo"), nil)
+	assert.Equal(t, fiber.StatusUnsupportedMediaType, status)
+	assert.Equal(t, "Unsupported Media Type", response.Status)
+
+	status, _ = uploadPhoto(t, token, contactID, "image/gif", []byte("GIF89a not a photo"), nil)
+	assert.Equal(t, fiber.StatusUnsupportedMediaType, status)
+
+	status, _ = uploadPhoto(t, token, contactID, "image/png", testPNG(10, 10)[:30], nil)
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	// PHOTO_MAX_SIZE is 256 KiB in the tests
+	tooLarge := append(testPNG(10, 10), make([]byte, 256*1024)...)
+	status, response = uploadPhoto(t, token, contactID, "image/png", tooLarge, nil)
+	assert.Equal(t, fiber.StatusRequestEntityTooLarge, status)
+	assert.Equal(t, "photo must be at most 262144 bytes", response.Data)
+
+	status, _ = uploadPhoto(t, token, contactID, "image/png", testPNG(10, 10), map[string]string{"If-Match": `"5"`})
+	assert.Equal(t, fiber.StatusPreconditionFailed, status)
+
+	status, _ = uploadPhoto(t, token, "999999999", "image/png", testPNG(10, 10), nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	status, _ = uploadPhoto(t, "invalid-token", contactID, "image/png", testPNG(10, 10), nil)
+	assert.Equal(t, fiber.StatusUnauthorized, status)
+
+	// Nothing was stored
+	status, response = adminRequest(t, "GET", "/api/contacts/"+contactID, token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Nil(t, contactPhoto(response.Data))
+	assert.Equal(t, float64(1), response.Data.(map[string]interface{})["version"])
+	files, err := filepath.Glob(filepath.Join(testPhotoDir, "contacts", contactID, "*", "*.jpg"))
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+
+	cleanupTestData()
+}
+
+func TestUploadContactPhotoViewer(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testphotoowner", "password123", "Test Photo Owner")
+	viewerToken := registerAndLogin(t, "testphotoviewer", "password123", "Test Photo Viewer")
+	joinOrganization(t, ownerToken, "testphotoviewer")
+	contactID := createTestContact(t, ownerToken, "John", "Doe", "john@example.com", "08123456789")
+	shareAddressBook(t, ownerToken, defaultAddressBookID(t, ownerToken), "testphotoviewer", viewerToken, "viewer")
+
+	status, _ := uploadPhoto(t, viewerToken, contactID, "image/png", testPNG(10, 10), nil)
+	assert.Equal(t, fiber.StatusForbidden, status)
+
+	cleanupTestData()
+}
+
+func TestServePhotoOnlyThumbnails(t *testing.T) {
+	for _, path := range []string{
+		"/photos/contacts/1/missing/small.jpg",
+		"/photos/contacts/1/missing/huge.jpg",
+		"/photos/other/1/key/small.jpg",
+		"/photos/contacts/x/key/small.jpg",
+	} {
+		resp, err := testApp.Test(httptest.NewRequest("GET", path, nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode, path)
+	}
+}
+
+func TestContactPhotoInVCard(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testphotodav", "password123", "Test Photo DAV")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	cardPath := davAddressBookPath(t, token) + contactID + ".vcf"
+
+	resp, card := davRequest(t, "GET", cardPath, "testphotodav", "password123", nil, "")
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.NotContains(t, card, "PHOTO")
+	etag := resp.Header.Get("ETag")
+
+	status, response := uploadPhoto(t, token, contactID, "image/png", testPNG(600, 400), nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	thumbnailResp, err := testApp.Test(httptest.NewRequest("GET", contactPhoto(response.Data)["medium"].(string), nil), -1)
+	assert.NoError(t, err)
+	medium, _ := io.ReadAll(thumbnailResp.Body)
+
+	// The medium thumbnail is embedded, and changes the entity tag
+	resp, card = davRequest(t, "GET", cardPath, "testphotodav", "password123", nil, "")
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.NotEqual(t, etag, resp.Header.Get("ETag"))
+	unfolded := strings.ReplaceAll(card, "\r\n ", "")
+	assert.Contains(t, unfolded, "PHOTO;ENCODING=b;TYPE=JPEG:"+base64.StdEncoding.EncodeToString(medium)+"\r\n")
+
+	cleanupTestData()
+}
@@ -0,0 +1,107 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/address"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceContact(t *testing.T) {
+	cleanupTestData()
+
+	token := registerAndLogin(t, "testreplace1", "password123", "Test Replace User 1")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+
+	replaceJSON, _ := json.Marshal(contact.ContactCreateRequest{
+		FirstName: "Jane",
+		LastName:  "Smith",
+		Email:     "jane@example.com",
+		Phone:     "08987654321",
+	})
+	req := httptest.NewRequest("PUT", "/api/contacts/"+contactID, bytes.NewReader(replaceJSON))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("If-Match", `"1"`)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, `"2"`, resp.Header.Get("ETag"))
+
+	body, _ := io.ReadAll(resp.Body)
+	var response web.Response
+	err = json.Unmarshal(body, &response)
+	assert.NoError(t, err)
+
+	contactResponse, ok := response.Data.(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "Jane", contactResponse["first_name"])
+	assert.Equal(t, "Smith", contactResponse["last_name"])
+	assert.Equal(t, "jane@example.com", contactResponse["email"])
+	assert.Equal(t, "08987654321", contactResponse["phone"])
+
+	cleanupTestData()
+}
+
+func TestReplaceContactRequiresAllFields(t *testing.T) {
+	cleanupTestData()
+
+	token := registerAndLogin(t, "testreplace2", "password123", "Test Replace User 2")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+
+	// Unlike PATCH, a member left out is not kept
+	req := httptest.NewRequest("PUT", "/api/contacts/"+contactID, bytes.NewReader([]byte(`{"first_name":"Jane"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+
+	cleanupTestData()
+}
+
+func TestReplaceAddress(t *testing.T) {
+	cleanupTestData()
+
+	token := registerAndLogin(t, "testreplace3", "password123", "Test Replace User 3")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	addressID := createTestAddress(t, token, contactID, "Old Street", "Old City", "Old Province", "Indonesia", "12345")
+
+	replaceJSON, _ := json.Marshal(address.AddressCreateRequest{
+		Street:     "New Street",
+		City:       "New City",
+		Province:   "New Province",
+		Country:    "Malaysia",
+		PostalCode: "54321",
+	})
+	req := httptest.NewRequest("PUT", "/api/contacts/"+contactID+"/addresses/"+addressID, bytes.NewReader(replaceJSON))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := testApp.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var response web.Response
+	err = json.Unmarshal(body, &response)
+	assert.NoError(t, err)
+
+	addressResponse, ok := response.Data.(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "New Street", addressResponse["street"])
+	assert.Equal(t, "New City", addressResponse["city"])
+	assert.Equal(t, "New Province", addressResponse["province"])
+	assert.Equal(t, "Malaysia", addressResponse["country"])
+	assert.Equal(t, "54321", addressResponse["postal_code"])
+
+	cleanupTestData()
+}
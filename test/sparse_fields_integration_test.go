@@ -0,0 +1,112 @@
+package test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// recordSelects records the columns selected by queries of the table run
+// until the returned function is called, which returns them
+func recordSelects(t *testing.T, table string) func() [][]string {
+	var mutex sync.Mutex
+	selects := [][]string{}
+	name := "test:selects_" + table
+	err := testDB.Callback().Query().Before("gorm:query").Register(name, func(db *gorm.DB) {
+		if db.Statement.Table == table {
+			mutex.Lock()
+			selects = append(selects, append([]string{}, db.Statement.Selects...))
+			mutex.Unlock()
+		}
+	})
+	assert.NoError(t, err)
+
+	return func() [][]string {
+		testDB.Callback().Query().Remove(name)
+		mutex.Lock()
+		defer mutex.Unlock()
+		return selects
+	}
+}
+
+func TestGetAllContactsSparseFields(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testfieldsuser", "password123", "Test Fields User")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	createTestAddress(t, token, contactID, "Jl. Test", "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+
+	stopRecording := recordSelects(t, "contacts")
+	status, response := adminRequest(t, "GET", "/api/contacts?fields=first_name,last_name", token, nil)
+	selects := stopRecording()
+	assert.Equal(t, fiber.StatusOK, status)
+
+	data := response.Data.(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{"first_name": "John", "last_name": "Doe"}}, data["contacts"])
+	assert.Equal(t, float64(1), data["paging"].(map[string]interface{})["total_item"])
+
+	// The contacts are read with only those columns and the primary key
+	assert.Contains(t, selects, []string{"id", "first_name", "last_name"})
+
+	// Included sub-resources are kept
+	status, response = adminRequest(t, "GET", "/api/contacts?fields=id&include=addresses", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	contact := response.Data.(map[string]interface{})["contacts"].([]interface{})[0].(map[string]interface{})
+	assert.Len(t, contact, 2)
+	assert.Equal(t, float64(parseTestID(contactID)), contact["id"])
+	assert.Len(t, contact["addresses"], 1)
+
+	status, _ = adminRequest(t, "GET", "/api/contacts?fields=id,password", token, nil)
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	cleanupTestData()
+}
+
+func TestGetContactSparseFields(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testfieldsuser", "password123", "Test Fields User")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+
+	stopRecording := recordSelects(t, "contacts")
+	status, response := adminRequest(t, "GET", "/api/contacts/"+contactID+"?fields=id,email", token, nil)
+	selects := stopRecording()
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, map[string]interface{}{"id": float64(parseTestID(contactID)), "email": "john@example.com"}, response.Data)
+
+	// The address book and version are read too, for the permission check
+	// and the entity tag
+	assert.Contains(t, selects, []string{"id", "address_book_id", "version", "email"})
+
+	status, _ = adminRequest(t, "GET", "/api/contacts/"+contactID+"?fields=user_id", token, nil)
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	cleanupTestData()
+}
+
+func TestAddressesSparseFields(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testfieldsuser", "password123", "Test Fields User")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	addressID := createTestAddress(t, token, contactID, "Jl. Test", "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+
+	stopRecording := recordSelects(t, "addresses")
+	status, response := adminRequest(t, "GET", "/api/contacts/"+contactID+"/addresses?fields=city", token, nil)
+	selects := stopRecording()
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, []interface{}{map[string]interface{}{"city": "Jakarta"}}, response.Data)
+	assert.Contains(t, selects, []string{"id", "city"})
+
+	stopRecording = recordSelects(t, "addresses")
+	status, response = adminRequest(t, "GET", "/api/contacts/"+contactID+"/addresses/"+addressID+"?fields=street,postal_code", token, nil)
+	selects = stopRecording()
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, map[string]interface{}{"street": "Jl. Test", "postal_code": "12345"}, response.Data)
+	assert.Contains(t, selects, []string{"id", "version", "street", "postal_code"})
+
+	status, _ = adminRequest(t, "GET", "/api/contacts/"+contactID+"/addresses?fields=contact_id", token, nil)
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	cleanupTestData()
+}
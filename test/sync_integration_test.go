@@ -0,0 +1,293 @@
+package test
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/model/web"
+	"github.com/sorfian/go-contact-management-api/model/web/addressbook"
+	"github.com/stretchr/testify/assert"
+)
+
+// backdateSyncData moves every contact, address and address book membership
+// an hour into the past, so a pull only returns what changes afterwards
+func backdateSyncData() {
+	past := time.Now().Add(-time.Hour)
+	testDB.Exec("UPDATE contacts SET updated_at = ?", past)
+	testDB.Exec("UPDATE addresses SET updated_at = ?", past)
+	testDB.Exec("UPDATE address_book_members SET created_at = ?", past)
+}
+
+// pullSync pulls the changes since the token in the organization, the user's
+// default one when organizationID is empty
+func pullSync(t *testing.T, token, organizationID, since string) map[string]interface{} {
+	path := "/api/sync"
+	if since != "" {
+		path += "?since=" + url.QueryEscape(since)
+	}
+
+	var status int
+	var response web.Response
+	if organizationID == "" {
+		status, response = adminRequest(t, "GET", path, token, nil)
+	} else {
+		status, response = organizationRequest(t, "GET", path, token, organizationID, nil)
+	}
+	if !assert.Equal(t, fiber.StatusOK, status) {
+		t.FailNow()
+	}
+	return response.Data.(map[string]interface{})
+}
+
+// syncIDs returns the IDs of the objects, or the IDs themselves, of a list of a pull
+func syncIDs(list interface{}) []int64 {
+	ids := []int64{}
+	for _, item := range list.([]interface{}) {
+		if object, ok := item.(map[string]interface{}); ok {
+			item = object["id"]
+		}
+		ids = append(ids, int64(item.(float64)))
+	}
+	return ids
+}
+
+func TestSyncPull(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testsyncuser", "password123", "Test Sync User")
+	deletedID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	deletedAddressID := createTestAddress(t, token, deletedID, "Jl. Test", "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+	updatedID := createTestContact(t, token, "Jane", "Doe", "jane@example.com", "08123456789")
+	unchangedID := createTestContact(t, token, "Jim", "Doe", "jim@example.com", "08123456789")
+	backdateSyncData()
+
+	// Without a token everything there is
+	pull := pullSync(t, token, "", "")
+	assert.ElementsMatch(t, []int64{parseTestID(deletedID), parseTestID(updatedID), parseTestID(unchangedID)}, syncIDs(pull["contacts"]))
+	assert.Equal(t, []int64{parseTestID(deletedAddressID)}, syncIDs(pull["addresses"]))
+	assert.Equal(t, float64(parseTestID(deletedID)), pull["addresses"].([]interface{})[0].(map[string]interface{})["contact_id"])
+	assert.Len(t, pull["address_book_ids"], 1)
+	assert.Empty(t, pull["deleted_contacts"])
+	since := pull["token"].(string)
+
+	status, _ := adminRequest(t, "PATCH", "/api/contacts/"+updatedID, token, map[string]interface{}{"phone": "08987654321"})
+	assert.Equal(t, fiber.StatusOK, status)
+	status, _ = adminRequest(t, "DELETE", "/api/contacts/"+deletedID, token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	createdID := createTestContact(t, token, "Jack", "Doe", "jack@example.com", "08123456789")
+	createdAddressID := createTestAddress(t, token, createdID, "Jl. Baru", "Bandung", "Jawa Barat", "Indonesia", "40111")
+
+	pull = pullSync(t, token, "", since)
+	assert.ElementsMatch(t, []int64{parseTestID(updatedID), parseTestID(createdID)}, syncIDs(pull["contacts"]))
+	assert.Equal(t, []int64{parseTestID(createdAddressID)}, syncIDs(pull["addresses"]))
+	assert.Equal(t, []int64{parseTestID(deletedID)}, syncIDs(pull["deleted_contacts"]))
+	assert.Equal(t, []int64{parseTestID(deletedAddressID)}, syncIDs(pull["deleted_addresses"]))
+	assert.NotEmpty(t, pull["token"])
+
+	cleanupTestData()
+}
+
+func TestSyncPullVisibility(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testsyncowner", "password123", "Test Sync Owner")
+	memberToken := registerAndLogin(t, "testsyncmember", "password123", "Test Sync Member")
+	joinOrganization(t, ownerToken, "testsyncmember")
+	organizationID := defaultOrganizationID(t, ownerToken)
+	contactID := createTestContact(t, ownerToken, "John", "Doe", "john@example.com", "08123456789")
+	addressID := createTestAddress(t, ownerToken, contactID, "Jl. Test", "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+	backdateSyncData()
+
+	pull := pullSync(t, memberToken, organizationID, "")
+	assert.Empty(t, pull["contacts"])
+	since := pull["token"].(string)
+
+	// Joining an address book brings everything in it
+	addressBookID := defaultAddressBookID(t, ownerToken)
+	shareAddressBook(t, ownerToken, addressBookID, "testsyncmember", memberToken, "viewer")
+
+	pull = pullSync(t, memberToken, organizationID, since)
+	assert.Equal(t, []int64{parseTestID(contactID)}, syncIDs(pull["contacts"]))
+	assert.Equal(t, []int64{parseTestID(addressID)}, syncIDs(pull["addresses"]))
+	assert.Contains(t, syncIDs(pull["address_book_ids"]), int64(addressBookID))
+	since = pull["token"].(string)
+
+	// Moving a contact out of view deletes it for the member
+	status, response := adminRequest(t, "POST", "/api/address-books", ownerToken, addressbook.AddressBookCreateRequest{Name: "Private"})
+	assert.Equal(t, fiber.StatusCreated, status)
+	privateID := response.Data.(map[string]interface{})["id"].(float64)
+	status, _ = adminRequest(t, "PATCH", "/api/contacts/"+contactID, ownerToken, map[string]interface{}{"address_book_id": privateID})
+	assert.Equal(t, fiber.StatusOK, status)
+
+	pull = pullSync(t, memberToken, organizationID, since)
+	assert.Empty(t, pull["contacts"])
+	assert.Equal(t, []int64{parseTestID(contactID)}, syncIDs(pull["deleted_contacts"]))
+
+	cleanupTestData()
+}
+
+func TestSyncPullMovedTwice(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testsyncowner", "password123", "Test Sync Owner")
+	memberToken := registerAndLogin(t, "testsyncmember", "password123", "Test Sync Member")
+	joinOrganization(t, ownerToken, "testsyncmember")
+	organizationID := defaultOrganizationID(t, ownerToken)
+	contactID := createTestContact(t, ownerToken, "John", "Doe", "john@example.com", "08123456789")
+	shareAddressBook(t, ownerToken, defaultAddressBookID(t, ownerToken), "testsyncmember", memberToken, "viewer")
+	backdateSyncData()
+
+	pull := pullSync(t, memberToken, organizationID, "")
+	assert.Equal(t, []int64{parseTestID(contactID)}, syncIDs(pull["contacts"]))
+	since := pull["token"].(string)
+
+	// Moved on from an address book the member cannot see, the member still
+	// learns that it left the shared one
+	for _, name := range []string{"Private", "Archive"} {
+		status, response := adminRequest(t, "POST", "/api/address-books", ownerToken, addressbook.AddressBookCreateRequest{Name: name})
+		assert.Equal(t, fiber.StatusCreated, status)
+		addressBookID := response.Data.(map[string]interface{})["id"].(float64)
+		status, _ = adminRequest(t, "PATCH", "/api/contacts/"+contactID, ownerToken, map[string]interface{}{"address_book_id": addressBookID})
+		assert.Equal(t, fiber.StatusOK, status)
+	}
+
+	pull = pullSync(t, memberToken, organizationID, since)
+	assert.Empty(t, pull["contacts"])
+	assert.Equal(t, []int64{parseTestID(contactID)}, syncIDs(pull["deleted_contacts"]))
+
+	cleanupTestData()
+}
+
+func TestSyncPullHidesOtherAddressBooks(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testsyncowner", "password123", "Test Sync Owner")
+	memberToken := registerAndLogin(t, "testsyncmember", "password123", "Test Sync Member")
+	joinOrganization(t, ownerToken, "testsyncmember")
+	organizationID := defaultOrganizationID(t, ownerToken)
+	updatedID := createTestContact(t, ownerToken, "John", "Doe", "john@example.com", "08123456789")
+	deletedID := createTestContact(t, ownerToken, "Jane", "Doe", "jane@example.com", "08123456789")
+	addressID := createTestAddress(t, ownerToken, deletedID, "Jl. Test", "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+	backdateSyncData()
+
+	pull := pullSync(t, memberToken, organizationID, "")
+	since := pull["token"].(string)
+
+	// Changes in the owner's private address book are not the member's
+	status, _ := adminRequest(t, "PATCH", "/api/contacts/"+updatedID, ownerToken, map[string]interface{}{"phone": "08987654321"})
+	assert.Equal(t, fiber.StatusOK, status)
+	status, _ = adminRequest(t, "PATCH", "/api/contacts/"+deletedID+"/addresses/"+addressID, ownerToken, map[string]interface{}{"city": "Bandung"})
+	assert.Equal(t, fiber.StatusOK, status)
+	status, _ = adminRequest(t, "DELETE", "/api/contacts/"+deletedID, ownerToken, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	pull = pullSync(t, memberToken, organizationID, since)
+	assert.Empty(t, pull["contacts"])
+	assert.Empty(t, pull["addresses"])
+	assert.Empty(t, pull["deleted_contacts"])
+	assert.Empty(t, pull["deleted_addresses"])
+
+	cleanupTestData()
+}
+
+func TestSyncPush(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testsyncuser", "password123", "Test Sync User")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	deletedID := createTestContact(t, token, "Jane", "Doe", "jane@example.com", "08123456789")
+
+	// Changed on the server while the client was offline
+	status, _ := adminRequest(t, "PATCH", "/api/contacts/"+contactID, token, map[string]interface{}{"phone": "08111111111"})
+	assert.Equal(t, fiber.StatusOK, status)
+
+	status, response := adminRequest(t, "POST", "/api/sync", token, map[string]interface{}{
+		"changes": []map[string]interface{}{
+			{"entity": "contact", "operation": "create", "client_id": "local-1", "data": map[string]interface{}{
+				"first_name": "Jack", "last_name": "Doe", "email": "jack@example.com", "phone": "08123456789",
+			}},
+			{"entity": "address", "operation": "create", "client_id": "local-2", "contact_client_id": "local-1", "data": map[string]interface{}{
+				"street": "Jl. Test", "city": "Jakarta", "province": "DKI Jakarta", "country": "Indonesia", "postal_code": "12345",
+			}},
+			{"entity": "contact", "operation": "update", "id": parseTestID(contactID), "version": 1, "data": map[string]interface{}{"phone": "08222222222"}},
+			{"entity": "contact", "operation": "delete", "id": parseTestID(deletedID), "version": 1},
+			{"entity": "address", "operation": "create", "contact_client_id": "local-9", "data": map[string]interface{}{
+				"street": "Jl. Test", "city": "Jakarta", "province": "DKI Jakarta", "country": "Indonesia", "postal_code": "12345",
+			}},
+		},
+	})
+	assert.Equal(t, fiber.StatusOK, status)
+	result := response.Data.(map[string]interface{})
+	assert.Equal(t, float64(3), result["applied"])
+	assert.Equal(t, float64(1), result["conflicts"])
+
+	results := result["results"].([]interface{})
+	created := results[0].(map[string]interface{})
+	assert.Equal(t, float64(fiber.StatusCreated), created["code"])
+	assert.Equal(t, "local-1", created["client_id"])
+	createdID := int64(created["data"].(map[string]interface{})["id"].(float64))
+
+	assert.Equal(t, float64(fiber.StatusCreated), results[1].(map[string]interface{})["code"])
+	status, response = adminRequest(t, "GET", fmt.Sprintf("/api/contacts/%d/addresses", createdID), token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, response.Data, 1)
+
+	conflict := results[2].(map[string]interface{})
+	assert.Equal(t, float64(fiber.StatusConflict), conflict["code"])
+	current := conflict["data"].(map[string]interface{})["current"].(map[string]interface{})
+	assert.Equal(t, "08111111111", current["phone"])
+	assert.Equal(t, float64(2), current["version"])
+
+	assert.Equal(t, float64(fiber.StatusOK), results[3].(map[string]interface{})["code"])
+	status, _ = adminRequest(t, "GET", "/api/contacts/"+deletedID, token, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	unknown := results[4].(map[string]interface{})
+	assert.Equal(t, float64(fiber.StatusBadRequest), unknown["code"])
+	assert.Equal(t, "contact_client_id must be the client_id of a contact created earlier in the push", unknown["data"])
+
+	cleanupTestData()
+}
+
+func TestSyncPushConflictWithDeleted(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testsyncuser", "password123", "Test Sync User")
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	addressID := createTestAddress(t, token, contactID, "Jl. Test", "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+	status, _ := adminRequest(t, "PATCH", "/api/contacts/"+contactID+"/addresses/"+addressID, token, map[string]interface{}{"city": "Bandung"})
+	assert.Equal(t, fiber.StatusOK, status)
+
+	status, response := adminRequest(t, "POST", "/api/sync", token, map[string]interface{}{
+		"changes": []map[string]interface{}{
+			{"entity": "address", "operation": "delete", "contact_id": parseTestID(contactID), "id": parseTestID(addressID), "version": 1},
+			{"entity": "address", "operation": "update", "id": parseTestID(addressID), "version": 2, "data": map[string]interface{}{"city": "Bogor"}},
+		},
+	})
+	assert.Equal(t, fiber.StatusOK, status)
+	results := response.Data.(map[string]interface{})["results"].([]interface{})
+
+	conflict := results[0].(map[string]interface{})
+	assert.Equal(t, float64(fiber.StatusConflict), conflict["code"])
+	assert.Equal(t, "Bandung", conflict["data"].(map[string]interface{})["current"].(map[string]interface{})["city"])
+
+	missing := results[1].(map[string]interface{})
+	assert.Equal(t, float64(fiber.StatusBadRequest), missing["code"])
+	assert.Equal(t, "contact_id or contact_client_id is required", missing["data"])
+
+	cleanupTestData()
+}
+
+func TestSyncRejected(t *testing.T) {
+	cleanupTestData()
+	token := registerAndLogin(t, "testsyncuser", "password123", "Test Sync User")
+
+	status, response := adminRequest(t, "GET", "/api/sync?since=yesterday", token, nil)
+	assert.Equal(t, fiber.StatusBadRequest, status)
+	assert.Equal(t, "since must be a token returned by an earlier sync", response.Data)
+
+	status, _ = adminRequest(t, "POST", "/api/sync", token, map[string]interface{}{"changes": []interface{}{}})
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	status, _ = adminRequest(t, "GET", "/api/sync", "", nil)
+	assert.Equal(t, fiber.StatusUnauthorized, status)
+
+	cleanupTestData()
+}
@@ -5,13 +5,17 @@ import (
 	"encoding/json"
 	"io"
 	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/event"
 	"github.com/sorfian/go-contact-management-api/model/web"
 	"github.com/sorfian/go-contact-management-api/model/web/user"
 	"github.com/sorfian/go-contact-management-api/repository"
+	"github.com/sorfian/go-contact-management-api/service"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
 )
 
@@ -19,23 +23,56 @@ var (
 	testDB             *gorm.DB
 	testApp            *fiber.App
 	testUserRepository repository.UserRepository
+	testWebhookService service.WebhookService
+	testOutboxService  service.OutboxService
+	testEventBus       *event.Bus
+	testGRPCServer     *grpc.Server
+	testPhotoDir       string
 )
 
 func setupTestApp() {
+	// Short stream intervals keep the event stream tests fast
+	os.Setenv("STREAM_POLL_INTERVAL", "100ms")
+	os.Setenv("STREAM_HEARTBEAT_INTERVAL", "500ms")
+
+	// The webhook receivers are local HTTP servers
+	os.Setenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS", "true")
+
+	// Photos go to a directory of their own, a small limit keeps the size
+	// test quick
+	var err error
+	testPhotoDir, err = os.MkdirTemp("", "contact-photos")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("BLOB_STORE", "local")
+	os.Setenv("BLOB_LOCAL_DIR", testPhotoDir)
+	os.Setenv("PHOTO_BASE_URL", "/photos")
+	os.Setenv("PHOTO_MAX_SIZE", "262144")
+
 	// Initialize app with all dependencies using Wire
 	deps := InitializeTestApp()
 	testApp = deps.App
 	testDB = deps.DB
 	testUserRepository = deps.UserRepository
+	testWebhookService = deps.WebhookService
+	testOutboxService = deps.OutboxService
+	testEventBus = deps.EventBus
+	testGRPCServer = deps.GRPCServer
 }
 
 func cleanupTestData() {
 	testDB.Exec("DELETE FROM users WHERE username LIKE 'test%'")
+	testDB.Exec("DELETE FROM admin_actions WHERE target_user_id NOT IN (SELECT id FROM users)")
+	testDB.Exec("DELETE FROM outbox_events WHERE actor_id NOT IN (SELECT id FROM users)")
+	testDB.Exec("DELETE FROM organizations WHERE id NOT IN (SELECT organization_id FROM organization_members)")
+	testDB.Exec("DELETE FROM stream_events WHERE organization_id NOT IN (SELECT id FROM organizations)")
 }
 
 func TestMain(m *testing.M) {
 	setupTestApp()
 	m.Run()
+	os.RemoveAll(testPhotoDir)
 }
 
 func TestRegisterSuccess(t *testing.T) {
@@ -0,0 +1,306 @@
+package test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/app"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/model/domain"
+	"github.com/sorfian/go-contact-management-api/model/web/contact"
+	"github.com/sorfian/go-contact-management-api/model/web/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+const testWebhookSecret = "test-webhook-secret"
+
+// webhookReceiver is a local stand-in for a webhook endpoint, it records the
+// requests it is sent and answers them with status
+type webhookReceiver struct {
+	server   *httptest.Server
+	mutex    sync.Mutex
+	status   int
+	requests []receivedWebhook
+}
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+func newWebhookReceiver(t *testing.T) *webhookReceiver {
+	receiver := &webhookReceiver{status: http.StatusOK}
+	receiver.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		receiver.mutex.Lock()
+		defer receiver.mutex.Unlock()
+		receiver.requests = append(receiver.requests, receivedWebhook{header: r.Header.Clone(), body: body})
+		w.WriteHeader(receiver.status)
+	}))
+	t.Cleanup(receiver.server.Close)
+	return receiver
+}
+
+func (receiver *webhookReceiver) respondWith(status int) {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+	receiver.status = status
+}
+
+func (receiver *webhookReceiver) received() []receivedWebhook {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+	return append([]receivedWebhook{}, receiver.requests...)
+}
+
+func createTestWebhook(t *testing.T, token, url string, events ...string) string {
+	status, response := adminRequest(t, "POST", "/api/webhooks", token, webhook.WebhookCreateRequest{
+		URL:    url,
+		Secret: testWebhookSecret,
+		Events: events,
+	})
+	assert.Equal(t, fiber.StatusCreated, status)
+	return strconv.FormatInt(int64(response.Data.(map[string]interface{})["id"].(float64)), 10)
+}
+
+// deliverDueWebhooks runs the outbox dispatcher, which queues the deliveries,
+// and then the delivery worker once
+func deliverDueWebhooks() {
+	dispatchPendingEvents()
+	testWebhookService.DeliverDue(helper.NewContext(context.Background()), 100)
+}
+
+func webhookDeliveries(t *testing.T, token, webhookID string) []interface{} {
+	status, response := adminRequest(t, "GET", "/api/webhooks/"+webhookID+"/deliveries", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	return response.Data.(map[string]interface{})["deliveries"].([]interface{})
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	cleanupTestData()
+	receiver := newWebhookReceiver(t)
+	token := registerAndLogin(t, "testwebhookuser", "password123", "Test Webhook User")
+	webhookID := createTestWebhook(t, token, receiver.server.URL, "contact.created", "address.deleted")
+
+	contactID := createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	addressID := createTestAddress(t, token, contactID, "Jl. Test", "Jakarta", "DKI Jakarta", "Indonesia", "12345")
+	status, _ := adminRequest(t, "DELETE", "/api/contacts/"+contactID+"/addresses/"+addressID, token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	// Nothing is sent before the worker runs
+	assert.Len(t, receiver.received(), 0)
+	deliverDueWebhooks()
+
+	received := receiver.received()
+	assert.Len(t, received, 2)
+
+	created := received[0]
+	assert.Equal(t, "contact.created", created.header.Get("X-Webhook-Event"))
+	mac := hmac.New(sha256.New, []byte(testWebhookSecret))
+	mac.Write(created.body)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), created.header.Get("X-Webhook-Signature"))
+
+	payload := webhook.EventPayload{}
+	assert.NoError(t, json.Unmarshal(created.body, &payload))
+	assert.Equal(t, "contact.created", payload.Event)
+	assert.Equal(t, created.header.Get("X-Webhook-ID"), payload.ID)
+	assert.Equal(t, "John", payload.Data.(map[string]interface{})["first_name"])
+
+	payload = webhook.EventPayload{}
+	assert.NoError(t, json.Unmarshal(received[1].body, &payload))
+	assert.Equal(t, "address.deleted", payload.Event)
+	assert.Equal(t, float64(parseTestID(contactID)), payload.Data.(map[string]interface{})["contact_id"])
+	assert.Equal(t, "Jakarta", payload.Data.(map[string]interface{})["address"].(map[string]interface{})["city"])
+
+	deliveries := webhookDeliveries(t, token, webhookID)
+	assert.Len(t, deliveries, 2)
+	delivery := deliveries[0].(map[string]interface{})
+	assert.Equal(t, "address.deleted", delivery["event"])
+	assert.Equal(t, "succeeded", delivery["status"])
+	assert.Equal(t, float64(1), delivery["attempts"])
+	assert.Equal(t, float64(200), delivery["response_status"])
+	assert.NotNil(t, delivery["delivered_at"])
+
+	// Delivered once only
+	deliverDueWebhooks()
+	assert.Len(t, receiver.received(), 2)
+
+	cleanupTestData()
+}
+
+func TestWebhookRetryAndRedeliver(t *testing.T) {
+	cleanupTestData()
+	receiver := newWebhookReceiver(t)
+	receiver.respondWith(http.StatusInternalServerError)
+	token := registerAndLogin(t, "testwebhookuser", "password123", "Test Webhook User")
+	webhookID := createTestWebhook(t, token, receiver.server.URL, "contact.created")
+	createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+
+	deliverDueWebhooks()
+	assert.Len(t, receiver.received(), 1)
+
+	deliveries := webhookDeliveries(t, token, webhookID)
+	failed := deliveries[0].(map[string]interface{})
+	assert.Equal(t, "pending", failed["status"])
+	assert.Equal(t, float64(1), failed["attempts"])
+	assert.Equal(t, float64(500), failed["response_status"])
+	assert.Equal(t, "endpoint responded with status 500", failed["error"])
+	nextAttemptAt, err := time.Parse(time.RFC3339, failed["next_attempt_at"].(string))
+	assert.NoError(t, err)
+	assert.True(t, nextAttemptAt.After(time.Now()))
+
+	// The retry waits for its backoff
+	deliverDueWebhooks()
+	assert.Len(t, receiver.received(), 1)
+
+	// A manual redelivery sends the same event again right away
+	receiver.respondWith(http.StatusNoContent)
+	url := fmt.Sprintf("/api/webhooks/%s/deliveries/%d/redeliver", webhookID, int64(failed["id"].(float64)))
+	status, response := adminRequest(t, "POST", url, token, nil)
+	assert.Equal(t, fiber.StatusCreated, status)
+	redelivery := response.Data.(map[string]interface{})
+	assert.Equal(t, "succeeded", redelivery["status"])
+	assert.Equal(t, float64(204), redelivery["response_status"])
+	assert.Equal(t, failed["event_id"], redelivery["event_id"])
+
+	received := receiver.received()
+	assert.Len(t, received, 2)
+	assert.Equal(t, received[0].body, received[1].body)
+
+	status, response = adminRequest(t, "GET", "/api/webhooks/"+webhookID+"/deliveries?status=pending", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, response.Data.(map[string]interface{})["deliveries"], 1)
+
+	status, _ = adminRequest(t, "POST", "/api/webhooks/"+webhookID+"/deliveries/999999999/redeliver", token, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	cleanupTestData()
+}
+
+func TestWebhookDeliveryGivesUp(t *testing.T) {
+	cleanupTestData()
+	receiver := newWebhookReceiver(t)
+	receiver.respondWith(http.StatusBadGateway)
+	token := registerAndLogin(t, "testwebhookuser", "password123", "Test Webhook User")
+	webhookID := createTestWebhook(t, token, receiver.server.URL, "contact.created")
+	createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	dispatchPendingEvents()
+
+	// One attempt short of the limit and due again
+	testDB.Model(&domain.WebhookDelivery{}).Where("webhook_id = ?", parseTestID(webhookID)).
+		Update("attempts", app.AppConfig.Webhook.MaxAttempts-1)
+	deliverDueWebhooks()
+
+	delivery := webhookDeliveries(t, token, webhookID)[0].(map[string]interface{})
+	assert.Equal(t, "failed", delivery["status"])
+	assert.Nil(t, delivery["next_attempt_at"])
+	assert.Equal(t, float64(502), delivery["response_status"])
+
+	cleanupTestData()
+}
+
+func TestWebhookNotQueuedForRolledBackChange(t *testing.T) {
+	cleanupTestData()
+	receiver := newWebhookReceiver(t)
+	token := registerAndLogin(t, "testwebhookuser", "password123", "Test Webhook User")
+	webhookID := createTestWebhook(t, token, receiver.server.URL, "contact.created", "contact.deleted")
+
+	result := sendBulkRequest(t, token, contact.BulkRequest{
+		Operations: []contact.BulkOperation{
+			{Operation: "create", Data: json.RawMessage(`{"first_name":"Jane","last_name":"Doe","email":"jane@example.com","phone":"08123456789"}`)},
+			{Operation: "delete", ID: 999999999},
+		},
+	})
+	assert.Equal(t, false, result["committed"])
+
+	deliverDueWebhooks()
+	assert.Len(t, receiver.received(), 0)
+	assert.Len(t, webhookDeliveries(t, token, webhookID), 0)
+
+	cleanupTestData()
+}
+
+func TestWebhookManagement(t *testing.T) {
+	cleanupTestData()
+	receiver := newWebhookReceiver(t)
+	token := registerAndLogin(t, "testwebhookuser", "password123", "Test Webhook User")
+	webhookID := createTestWebhook(t, token, receiver.server.URL, "contact.created")
+
+	status, response := adminRequest(t, "GET", "/api/webhooks/"+webhookID, token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	details := response.Data.(map[string]interface{})
+	assert.Equal(t, receiver.server.URL, details["url"])
+	assert.Equal(t, true, details["active"])
+	assert.NotContains(t, details, "secret")
+
+	// Inactive webhooks are not sent events
+	status, response = adminRequest(t, "PATCH", "/api/webhooks/"+webhookID, token, map[string]interface{}{"active": false})
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, false, response.Data.(map[string]interface{})["active"])
+	assert.Equal(t, []interface{}{"contact.created"}, response.Data.(map[string]interface{})["events"])
+
+	createTestContact(t, token, "John", "Doe", "john@example.com", "08123456789")
+	deliverDueWebhooks()
+	assert.Len(t, receiver.received(), 0)
+
+	status, _ = adminRequest(t, "PATCH", "/api/webhooks/"+webhookID, token, map[string]interface{}{"secret": "short"})
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	status, _ = adminRequest(t, "POST", "/api/webhooks", token, map[string]interface{}{
+		"url":    receiver.server.URL,
+		"secret": testWebhookSecret,
+		"events": []string{"contact.merged"},
+	})
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	status, response = adminRequest(t, "GET", "/api/webhooks", token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Len(t, response.Data, 1)
+
+	status, _ = adminRequest(t, "DELETE", "/api/webhooks/"+webhookID, token, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	status, _ = adminRequest(t, "GET", "/api/webhooks/"+webhookID, token, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	cleanupTestData()
+}
+
+func TestWebhookRequiresOrganizationAdmin(t *testing.T) {
+	cleanupTestData()
+	ownerToken := registerAndLogin(t, "testwebhookowner", "password123", "Test Webhook Owner")
+	memberToken := registerAndLogin(t, "testwebhookmember", "password123", "Test Webhook Member")
+	otherToken := registerAndLogin(t, "testwebhookother", "password123", "Test Webhook Other")
+	joinOrganization(t, ownerToken, "testwebhookmember")
+	organizationID := defaultOrganizationID(t, ownerToken)
+	webhookID := createTestWebhook(t, ownerToken, "http://127.0.0.1:9/hooks", "contact.created")
+
+	status, _ := organizationRequest(t, "GET", "/api/webhooks", memberToken, organizationID, nil)
+	assert.Equal(t, fiber.StatusForbidden, status)
+
+	status, _ = organizationRequest(t, "POST", "/api/webhooks", memberToken, organizationID, webhook.WebhookCreateRequest{
+		URL:    "http://127.0.0.1:9/hooks",
+		Secret: testWebhookSecret,
+		Events: []string{"contact.created"},
+	})
+	assert.Equal(t, fiber.StatusForbidden, status)
+
+	// Webhooks of another organization are not found
+	status, _ = adminRequest(t, "GET", "/api/webhooks/"+webhookID, otherToken, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	cleanupTestData()
+}
@@ -8,8 +8,11 @@ import (
 	"github.com/google/wire"
 	"github.com/sorfian/go-contact-management-api/app"
 	"github.com/sorfian/go-contact-management-api/controller"
+	"github.com/sorfian/go-contact-management-api/event"
 	"github.com/sorfian/go-contact-management-api/repository"
+	"github.com/sorfian/go-contact-management-api/rpc"
 	"github.com/sorfian/go-contact-management-api/service"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
 )
 
@@ -18,6 +21,10 @@ type TestDependencies struct {
 	App            *fiber.App
 	DB             *gorm.DB
 	UserRepository repository.UserRepository
+	WebhookService service.WebhookService
+	OutboxService  service.OutboxService
+	EventBus       *event.Bus
+	GRPCServer     *grpc.Server
 }
 
 // InitializeTestApp initializes the test application with all dependencies
@@ -35,7 +42,11 @@ func InitializeTestApp() *TestDependencies {
 		// Controllers
 		controller.Set,
 
+		// gRPC server
+		rpc.Set,
+
 		// Test app setup
+		wire.Struct(new(app.RouterDeps), "*"),
 		ProvideTestDependencies,
 	)
 	return nil
@@ -43,16 +54,19 @@ func InitializeTestApp() *TestDependencies {
 
 // ProvideTestDependencies creates and configures all test dependencies
 func ProvideTestDependencies(
-	userController controller.UserController,
-	contactController controller.ContactController,
-	addressController controller.AddressController,
-	userRepository repository.UserRepository,
-	db *gorm.DB,
+	routerDeps app.RouterDeps,
+	webhookService service.WebhookService,
+	outboxService service.OutboxService,
+	eventBus *event.Bus,
+	grpcServer *grpc.Server,
 ) *TestDependencies {
-	app := setupTestFiberApp(userController, contactController, addressController, userRepository, db)
 	return &TestDependencies{
-		App:            app,
-		DB:             db,
-		UserRepository: userRepository,
+		App:            setupTestFiberApp(routerDeps),
+		DB:             routerDeps.DB,
+		UserRepository: routerDeps.UserRepository,
+		WebhookService: webhookService,
+		OutboxService:  outboxService,
+		EventBus:       eventBus,
+		GRPCServer:     grpcServer,
 	}
 }
@@ -10,8 +10,11 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/sorfian/go-contact-management-api/app"
 	"github.com/sorfian/go-contact-management-api/controller"
+	"github.com/sorfian/go-contact-management-api/event"
 	"github.com/sorfian/go-contact-management-api/repository"
+	"github.com/sorfian/go-contact-management-api/rpc"
 	"github.com/sorfian/go-contact-management-api/service"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
 )
 
@@ -20,17 +23,82 @@ import (
 // InitializeTestApp initializes the test application with all dependencies
 func InitializeTestApp() *TestDependencies {
 	userRepository := repository.NewUserRepository()
+	auditLogRepository := repository.NewAuditLogRepository()
+	outboxRepository := repository.NewOutboxRepository()
 	db := app.ProvideDatabase()
 	validate := app.ProvideValidator()
-	userService := service.NewUserService(userRepository, db, validate)
+	userService := service.NewUserService(userRepository, auditLogRepository, outboxRepository, db, validate)
 	userController := controller.NewUserController(userService)
 	contactRepository := repository.NewContactRepository()
-	contactService := service.NewContactService(contactRepository, db, validate)
-	contactController := controller.NewContactController(contactService)
 	addressRepository := repository.NewAddressRepository()
-	addressService := service.NewAddressService(addressRepository, contactRepository, db, validate)
+	addressBookRepository := repository.NewAddressBookRepository()
+	addressBookMemberRepository := repository.NewAddressBookMemberRepository()
+	contactVersionRepository := repository.NewContactVersionRepository()
+	contactMoveRepository := repository.NewContactMoveRepository()
+	photoOptions := app.ProvidePhotoOptions()
+	contactService := service.NewContactService(contactRepository, addressRepository, addressBookRepository, addressBookMemberRepository, auditLogRepository, contactVersionRepository, contactMoveRepository, outboxRepository, db, validate, photoOptions)
+	contactController := controller.NewContactController(contactService)
+	addressService := service.NewAddressService(addressRepository, contactRepository, addressBookMemberRepository, auditLogRepository, contactVersionRepository, outboxRepository, db, validate)
 	addressController := controller.NewAddressController(addressService)
-	testDependencies := ProvideTestDependencies(userController, contactController, addressController, userRepository, db)
+	adminActionRepository := repository.NewAdminActionRepository()
+	adminService := service.NewAdminService(userRepository, adminActionRepository, auditLogRepository, db, validate)
+	adminController := controller.NewAdminController(adminService)
+	addressBookService := service.NewAddressBookService(addressBookRepository, addressBookMemberRepository, contactRepository, db, validate)
+	addressBookController := controller.NewAddressBookController(addressBookService)
+	addressBookInvitationRepository := repository.NewAddressBookInvitationRepository()
+	organizationMemberRepository := repository.NewOrganizationMemberRepository()
+	invitationService := service.NewInvitationService(addressBookInvitationRepository, addressBookMemberRepository, organizationMemberRepository, userRepository, db, validate)
+	invitationController := controller.NewInvitationController(invitationService)
+	organizationRepository := repository.NewOrganizationRepository()
+	organizationService := service.NewOrganizationService(organizationRepository, organizationMemberRepository, userRepository, addressBookRepository, addressBookMemberRepository, contactRepository, db, validate)
+	organizationController := controller.NewOrganizationController(organizationService)
+	webhookRepository := repository.NewWebhookRepository()
+	webhookDeliveryRepository := repository.NewWebhookDeliveryRepository()
+	webhookOptions := app.ProvideWebhookOptions()
+	webhookService := service.NewWebhookService(webhookRepository, webhookDeliveryRepository, organizationMemberRepository, db, validate, webhookOptions)
+	webhookController := controller.NewWebhookController(webhookService)
+	streamEventRepository := repository.NewStreamEventRepository()
+	eventStreamOptions := app.ProvideEventStreamOptions()
+	eventStreamService := service.NewEventStreamService(streamEventRepository, userRepository, db, eventStreamOptions)
+	eventStreamController := controller.NewEventStreamController(eventStreamService, eventStreamOptions)
+	contactViewerRepository := repository.NewContactViewerRepository()
+	collaborationService := service.NewCollaborationService(contactRepository, addressBookMemberRepository, contactViewerRepository, db)
+	collaborationController := controller.NewCollaborationController(collaborationService, eventStreamService, eventStreamOptions)
+	syncService := service.NewSyncService(contactService, addressService, contactRepository, addressRepository, addressBookMemberRepository, db, validate, photoOptions)
+	syncController := controller.NewSyncController(syncService)
+	blobStore := app.ProvideBlobStore()
+	cardDAVService := service.NewCardDAVService(contactService, addressService, contactRepository, addressRepository, addressBookRepository, addressBookMemberRepository, organizationMemberRepository, blobStore, db, validate)
+	cardDAVController := controller.NewCardDAVController(cardDAVService)
+	graphQLController := controller.NewGraphQLController(contactService, addressService, userService)
+	photoService := service.NewPhotoService(contactRepository, addressRepository, addressBookMemberRepository, auditLogRepository, contactVersionRepository, outboxRepository, blobStore, db, photoOptions)
+	photoController := controller.NewPhotoController(photoService)
+	routerDeps := app.RouterDeps{
+		UserController:          userController,
+		ContactController:       contactController,
+		AddressController:       addressController,
+		AdminController:         adminController,
+		AddressBookController:   addressBookController,
+		InvitationController:    invitationController,
+		OrganizationController:  organizationController,
+		WebhookController:       webhookController,
+		EventStreamController:   eventStreamController,
+		CollaborationController: collaborationController,
+		SyncController:          syncController,
+		CardDAVController:       cardDAVController,
+		GraphQLController:       graphQLController,
+		PhotoController:         photoController,
+		OrganizationService:     organizationService,
+		UserRepository:          userRepository,
+		DB:                      db,
+	}
+	bus := service.NewEventBus(webhookService, eventStreamService)
+	outboxOptions := app.ProvideOutboxOptions()
+	outboxService := service.NewOutboxService(outboxRepository, bus, db, outboxOptions)
+	contactServiceServer := rpc.NewContactServer(contactService)
+	addressServiceServer := rpc.NewAddressServer(addressService)
+	authenticator := rpc.NewAuthenticator(userRepository, organizationService, db)
+	server := rpc.NewServer(contactServiceServer, addressServiceServer, authenticator)
+	testDependencies := ProvideTestDependencies(routerDeps, webhookService, outboxService, bus, server)
 	return testDependencies
 }
 
@@ -41,20 +109,27 @@ type TestDependencies struct {
 	App            *fiber.App
 	DB             *gorm.DB
 	UserRepository repository.UserRepository
+	WebhookService service.WebhookService
+	OutboxService  service.OutboxService
+	EventBus       *event.Bus
+	GRPCServer     *grpc.Server
 }
 
 // ProvideTestDependencies creates and configures all test dependencies
 func ProvideTestDependencies(
-	userController controller.UserController,
-	contactController controller.ContactController,
-	addressController controller.AddressController,
-	userRepository repository.UserRepository,
-	db *gorm.DB,
+	routerDeps app.RouterDeps,
+	webhookService service.WebhookService,
+	outboxService service.OutboxService,
+	eventBus *event.Bus,
+	grpcServer *grpc.Server,
 ) *TestDependencies {
-	app2 := setupTestFiberApp(userController, contactController, addressController, userRepository, db)
 	return &TestDependencies{
-		App:            app2,
-		DB:             db,
-		UserRepository: userRepository,
+		App:            setupTestFiberApp(routerDeps),
+		DB:             routerDeps.DB,
+		UserRepository: routerDeps.UserRepository,
+		WebhookService: webhookService,
+		OutboxService:  outboxService,
+		EventBus:       eventBus,
+		GRPCServer:     grpcServer,
 	}
 }
@@ -6,15 +6,28 @@ package main
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/wire"
+	"github.com/sorfian/go-contact-management-api/admin"
 	"github.com/sorfian/go-contact-management-api/app"
 	"github.com/sorfian/go-contact-management-api/controller"
 	"github.com/sorfian/go-contact-management-api/repository"
+	"github.com/sorfian/go-contact-management-api/rpc"
 	"github.com/sorfian/go-contact-management-api/service"
+	"github.com/sorfian/go-contact-management-api/worker"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
+// Server is the API and the background workers that run beside it
+type Server struct {
+	App              *fiber.App
+	GRPCServer       *grpc.Server
+	WebhookWorker    *worker.WebhookWorker
+	OutboxDispatcher *worker.OutboxDispatcher
+}
+
 // InitializeApp initializes the application with all dependencies
-func InitializeApp() *fiber.App {
+func InitializeApp() *Server {
 	wire.Build(
 		// App dependencies (database, validator)
 		app.Set,
@@ -28,19 +41,40 @@ func InitializeApp() *fiber.App {
 		// Controllers
 		controller.Set,
 
+		// gRPC server
+		rpc.Set,
+
+		// Background workers
+		worker.NewWebhookWorker,
+		worker.NewOutboxDispatcher,
+
 		// Fiber app setup
+		wire.Struct(new(app.RouterDeps), "*"),
 		ProvideFiberApp,
+		wire.Struct(new(Server), "*"),
+	)
+	return nil
+}
+
+// InitializeAdmin initializes the operator command line with the services it uses
+func InitializeAdmin() *admin.Admin {
+	wire.Build(
+		app.Set,
+		repository.Set,
+		service.Set,
+		ProvideAdmin,
 	)
 	return nil
 }
 
+// ProvideAdmin creates the operator command line. SQL logging is turned down
+// so that it does not mix with the command output.
+func ProvideAdmin(db *gorm.DB, userAdminService service.UserAdminService) *admin.Admin {
+	db.Logger = db.Logger.LogMode(logger.Warn)
+	return admin.NewAdmin(userAdminService)
+}
+
 // ProvideFiberApp creates and configures the Fiber app
-func ProvideFiberApp(
-	userController controller.UserController,
-	contactController controller.ContactController,
-	addressController controller.AddressController,
-	userRepository repository.UserRepository,
-	db *gorm.DB,
-) *fiber.App {
-	return setupFiberApp(userController, contactController, addressController, userRepository, db)
+func ProvideFiberApp(deps app.RouterDeps) *fiber.App {
+	return setupFiberApp(deps)
 }
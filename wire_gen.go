@@ -8,41 +8,139 @@ package main
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/admin"
 	"github.com/sorfian/go-contact-management-api/app"
 	"github.com/sorfian/go-contact-management-api/controller"
 	"github.com/sorfian/go-contact-management-api/repository"
+	"github.com/sorfian/go-contact-management-api/rpc"
 	"github.com/sorfian/go-contact-management-api/service"
+	"github.com/sorfian/go-contact-management-api/worker"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 // Injectors from wire.go:
 
 // InitializeApp initializes the application with all dependencies
-func InitializeApp() *fiber.App {
+func InitializeApp() *Server {
 	userRepository := repository.NewUserRepository()
+	auditLogRepository := repository.NewAuditLogRepository()
+	outboxRepository := repository.NewOutboxRepository()
 	db := app.ProvideDatabase()
 	validate := app.ProvideValidator()
-	userService := service.NewUserService(userRepository, db, validate)
+	userService := service.NewUserService(userRepository, auditLogRepository, outboxRepository, db, validate)
 	userController := controller.NewUserController(userService)
 	contactRepository := repository.NewContactRepository()
-	contactService := service.NewContactService(contactRepository, db, validate)
-	contactController := controller.NewContactController(contactService)
 	addressRepository := repository.NewAddressRepository()
-	addressService := service.NewAddressService(addressRepository, contactRepository, db, validate)
+	addressBookRepository := repository.NewAddressBookRepository()
+	addressBookMemberRepository := repository.NewAddressBookMemberRepository()
+	contactVersionRepository := repository.NewContactVersionRepository()
+	contactMoveRepository := repository.NewContactMoveRepository()
+	photoOptions := app.ProvidePhotoOptions()
+	contactService := service.NewContactService(contactRepository, addressRepository, addressBookRepository, addressBookMemberRepository, auditLogRepository, contactVersionRepository, contactMoveRepository, outboxRepository, db, validate, photoOptions)
+	contactController := controller.NewContactController(contactService)
+	addressService := service.NewAddressService(addressRepository, contactRepository, addressBookMemberRepository, auditLogRepository, contactVersionRepository, outboxRepository, db, validate)
 	addressController := controller.NewAddressController(addressService)
-	fiberApp := ProvideFiberApp(userController, contactController, addressController, userRepository, db)
-	return fiberApp
+	adminActionRepository := repository.NewAdminActionRepository()
+	adminService := service.NewAdminService(userRepository, adminActionRepository, auditLogRepository, db, validate)
+	adminController := controller.NewAdminController(adminService)
+	addressBookService := service.NewAddressBookService(addressBookRepository, addressBookMemberRepository, contactRepository, db, validate)
+	addressBookController := controller.NewAddressBookController(addressBookService)
+	addressBookInvitationRepository := repository.NewAddressBookInvitationRepository()
+	organizationMemberRepository := repository.NewOrganizationMemberRepository()
+	invitationService := service.NewInvitationService(addressBookInvitationRepository, addressBookMemberRepository, organizationMemberRepository, userRepository, db, validate)
+	invitationController := controller.NewInvitationController(invitationService)
+	organizationRepository := repository.NewOrganizationRepository()
+	organizationService := service.NewOrganizationService(organizationRepository, organizationMemberRepository, userRepository, addressBookRepository, addressBookMemberRepository, contactRepository, db, validate)
+	organizationController := controller.NewOrganizationController(organizationService)
+	webhookRepository := repository.NewWebhookRepository()
+	webhookDeliveryRepository := repository.NewWebhookDeliveryRepository()
+	webhookOptions := app.ProvideWebhookOptions()
+	webhookService := service.NewWebhookService(webhookRepository, webhookDeliveryRepository, organizationMemberRepository, db, validate, webhookOptions)
+	webhookController := controller.NewWebhookController(webhookService)
+	streamEventRepository := repository.NewStreamEventRepository()
+	eventStreamOptions := app.ProvideEventStreamOptions()
+	eventStreamService := service.NewEventStreamService(streamEventRepository, userRepository, db, eventStreamOptions)
+	eventStreamController := controller.NewEventStreamController(eventStreamService, eventStreamOptions)
+	contactViewerRepository := repository.NewContactViewerRepository()
+	collaborationService := service.NewCollaborationService(contactRepository, addressBookMemberRepository, contactViewerRepository, db)
+	collaborationController := controller.NewCollaborationController(collaborationService, eventStreamService, eventStreamOptions)
+	syncService := service.NewSyncService(contactService, addressService, contactRepository, addressRepository, addressBookMemberRepository, db, validate, photoOptions)
+	syncController := controller.NewSyncController(syncService)
+	blobStore := app.ProvideBlobStore()
+	cardDAVService := service.NewCardDAVService(contactService, addressService, contactRepository, addressRepository, addressBookRepository, addressBookMemberRepository, organizationMemberRepository, blobStore, db, validate)
+	cardDAVController := controller.NewCardDAVController(cardDAVService)
+	graphQLController := controller.NewGraphQLController(contactService, addressService, userService)
+	photoService := service.NewPhotoService(contactRepository, addressRepository, addressBookMemberRepository, auditLogRepository, contactVersionRepository, outboxRepository, blobStore, db, photoOptions)
+	photoController := controller.NewPhotoController(photoService)
+	routerDeps := app.RouterDeps{
+		UserController:          userController,
+		ContactController:       contactController,
+		AddressController:       addressController,
+		AdminController:         adminController,
+		AddressBookController:   addressBookController,
+		InvitationController:    invitationController,
+		OrganizationController:  organizationController,
+		WebhookController:       webhookController,
+		EventStreamController:   eventStreamController,
+		CollaborationController: collaborationController,
+		SyncController:          syncController,
+		CardDAVController:       cardDAVController,
+		GraphQLController:       graphQLController,
+		PhotoController:         photoController,
+		OrganizationService:     organizationService,
+		UserRepository:          userRepository,
+		DB:                      db,
+	}
+	fiberApp := ProvideFiberApp(routerDeps)
+	contactServiceServer := rpc.NewContactServer(contactService)
+	addressServiceServer := rpc.NewAddressServer(addressService)
+	authenticator := rpc.NewAuthenticator(userRepository, organizationService, db)
+	server := rpc.NewServer(contactServiceServer, addressServiceServer, authenticator)
+	webhookWorker := worker.NewWebhookWorker(webhookService, webhookOptions)
+	bus := service.NewEventBus(webhookService, eventStreamService)
+	outboxOptions := app.ProvideOutboxOptions()
+	outboxService := service.NewOutboxService(outboxRepository, bus, db, outboxOptions)
+	outboxDispatcher := worker.NewOutboxDispatcher(outboxService, eventStreamService, outboxOptions)
+	mainServer := &Server{
+		App:              fiberApp,
+		GRPCServer:       server,
+		WebhookWorker:    webhookWorker,
+		OutboxDispatcher: outboxDispatcher,
+	}
+	return mainServer
+}
+
+// InitializeAdmin initializes the operator command line with the services it uses
+func InitializeAdmin() *admin.Admin {
+	db := app.ProvideDatabase()
+	userRepository := repository.NewUserRepository()
+	adminActionRepository := repository.NewAdminActionRepository()
+	validate := app.ProvideValidator()
+	userAdminService := service.NewUserAdminService(userRepository, adminActionRepository, db, validate)
+	adminAdmin := ProvideAdmin(db, userAdminService)
+	return adminAdmin
 }
 
 // wire.go:
 
+// Server is the API and the background workers that run beside it
+type Server struct {
+	App              *fiber.App
+	GRPCServer       *grpc.Server
+	WebhookWorker    *worker.WebhookWorker
+	OutboxDispatcher *worker.OutboxDispatcher
+}
+
+// ProvideAdmin creates the operator command line. SQL logging is turned down
+// so that it does not mix with the command output.
+func ProvideAdmin(db *gorm.DB, userAdminService service.UserAdminService) *admin.Admin {
+	db.Logger = db.Logger.LogMode(logger.Warn)
+	return admin.NewAdmin(userAdminService)
+}
+
 // ProvideFiberApp creates and configures the Fiber app
-func ProvideFiberApp(
-	userController controller.UserController,
-	contactController controller.ContactController,
-	addressController controller.AddressController,
-	userRepository repository.UserRepository,
-	db *gorm.DB,
-) *fiber.App {
-	return setupFiberApp(userController, contactController, addressController, userRepository, db)
+func ProvideFiberApp(deps app.RouterDeps) *fiber.App {
+	return setupFiberApp(deps)
 }
@@ -0,0 +1,82 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/service"
+)
+
+// outboxBatchSize is how many due events are read from the outbox at once
+const outboxBatchSize = 100
+
+// streamPruneInterval is how often the events past the retention are deleted
+// from the live event stream
+const streamPruneInterval = time.Hour
+
+// OutboxDispatcher publishes the recorded domain events to their subscribers.
+// It also prunes the live event stream, which the dispatcher alone writes.
+type OutboxDispatcher struct {
+	OutboxService      service.OutboxService
+	EventStreamService service.EventStreamService
+	PollInterval       time.Duration
+}
+
+func NewOutboxDispatcher(outboxService service.OutboxService, eventStreamService service.EventStreamService, options service.OutboxOptions) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		OutboxService:      outboxService,
+		EventStreamService: eventStreamService,
+		PollInterval:       options.PollInterval,
+	}
+}
+
+// Run polls the outbox until ctx is done
+func (dispatcher *OutboxDispatcher) Run(ctx context.Context) {
+	fiberCtx := helper.NewContext(ctx)
+	defer helper.ReleaseContext(fiberCtx)
+	ticker := time.NewTicker(dispatcher.PollInterval)
+	defer ticker.Stop()
+
+	var prunedAt time.Time
+	for {
+		dispatcher.dispatchPending(fiberCtx)
+		if time.Since(prunedAt) >= streamPruneInterval {
+			dispatcher.pruneStream(fiberCtx)
+			prunedAt = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchPending publishes due events until the outbox is drained. A failed
+// poll is logged and tried again on the next tick.
+func (dispatcher *OutboxDispatcher) dispatchPending(ctx *fiber.Ctx) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			log.Printf("outbox dispatcher: %v", recovered)
+		}
+	}()
+
+	for dispatcher.OutboxService.DispatchPending(ctx, outboxBatchSize) == outboxBatchSize {
+	}
+}
+
+// pruneStream deletes the stream events past the retention. A failure is
+// logged and tried again after the next interval.
+func (dispatcher *OutboxDispatcher) pruneStream(ctx *fiber.Ctx) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			log.Printf("outbox dispatcher: prune event stream: %v", recovered)
+		}
+	}()
+
+	dispatcher.EventStreamService.Prune(ctx, time.Now())
+}
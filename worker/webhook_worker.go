@@ -0,0 +1,56 @@
+// Package worker implements the background jobs of the API server
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sorfian/go-contact-management-api/helper"
+	"github.com/sorfian/go-contact-management-api/service"
+)
+
+// webhookBatchSize is how many due deliveries are read from the queue at once
+const webhookBatchSize = 50
+
+// WebhookWorker sends the queued webhook deliveries once they are due
+type WebhookWorker struct {
+	WebhookService service.WebhookService
+	PollInterval   time.Duration
+}
+
+func NewWebhookWorker(webhookService service.WebhookService, options service.WebhookOptions) *WebhookWorker {
+	return &WebhookWorker{WebhookService: webhookService, PollInterval: options.PollInterval}
+}
+
+// Run polls the delivery queue until ctx is done
+func (worker *WebhookWorker) Run(ctx context.Context) {
+	fiberCtx := helper.NewContext(ctx)
+	ticker := time.NewTicker(worker.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		worker.deliverDue(fiberCtx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverDue sends due deliveries until the queue is drained. Services report
+// failures by panicking, a failed poll is logged and tried again on the next
+// tick.
+func (worker *WebhookWorker) deliverDue(ctx *fiber.Ctx) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			log.Printf("webhook worker: %v", recovered)
+		}
+	}()
+
+	for worker.WebhookService.DeliverDue(ctx, webhookBatchSize) == webhookBatchSize {
+	}
+}